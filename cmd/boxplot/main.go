@@ -1,53 +1,119 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
+	"os"
 
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
 func main() {
+	notch := flag.Bool("notch", false, "draw a notch showing the 95% CI of the median")
+	jitter := flag.Bool("jitter", false, "overlay the raw observations as jittered points")
+	horizontal := flag.Bool("horizontal", false, "draw the box horizontally")
+	term := flag.Bool("term", false, "draw the box plot in the terminal instead of saving a figure")
+	out := plots.OutputFlags("fertilizer_boxplot.png")
+	format := report.FormatFlag()
+	flag.Parse()
+
 	// The fertilizer usage data
-	data := plotter.Values{
-		22.5, 23.1, 21.8, 24.0, 22.7, 23.5, 24.8, 22.0, 23.9, 25.2,
-		21.5, 23.3, 24.5, 22.2, 23.8, 25.5, 21.9, 24.2, 22.8, 23.6,
-		25.0, 22.1, 24.9, 23.0, 22.9, 24.1, 23.7, 22.4, 24.7, 23.4,
-		22.6, 24.3, 23.2, 25.1, 21.7, 24.4, 22.3, 25.3, 23.8, 24.6,
-		21.6, 23.9, 22.5, 25.4, 23.1, 24.0, 22.9, 23.5, 24.8, 22.2,
-		23.7, 25.0, 21.8, 24.2, 23.0, 22.7, 24.5, 23.3, 20.0, 24.9,
+	ds, err := datasets.Get("fertilizer_usage")
+	if err != nil {
+		log.Fatal(err)
+	}
+	usage, err := ds.Floats("usage")
+	if err != nil {
+		log.Fatal(err)
 	}
+	data := plotter.Values(usage)
 
 	// --- 1. Create a new plot ---
 	p := plot.New()
 
 	// --- 2. Set the title and labels ---
 	p.Title.Text = "Box Plot of Fertilizer Usage"
-	p.Y.Label.Text = "Fertilizer Usage (KG)"
+	if *horizontal {
+		p.X.Label.Text = "Fertilizer Usage (KG)"
+		p.HideY()
+	} else {
+		p.Y.Label.Text = "Fertilizer Usage (KG)"
+		p.HideX()
+	}
 
 	// --- 3. Create the box plot ---
 	// The width parameter controls the width of the box.
 	// We pass the data and it automatically calculates quartiles, median, etc.
-	box, err := plotter.NewBoxPlot(vg.Points(50), 0, data)
+	box, err := plots.NewBox(vg.Points(50), 0, data)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// --- 4. Customize the plot appearance (optional) ---
+	// --- 4. Customize the plot appearance ---
 	box.FillColor = color.RGBA{R: 173, G: 216, B: 230, A: 255} // Light blue
-	// Only FillColor is set here; BoxPlot does not expose a direct Color/LineStyle field
+	box.ShowMean = true
+	box.Notch = *notch
+	box.Jitter = *jitter
+	box.Horizontal = *horizontal
 
-	// Add the box plot to the plot
+	// Add the box plot and the outlier labels (their values) to the plot
 	p.Add(box)
-
-	// --- 5. Save the plot to a file ---
-	// The dimensions are in standard points (e.g., inches * 72).
-	if err := p.Save(8*vg.Inch, 6*vg.Inch, "fertilizer_boxplot.png"); err != nil {
+	labels, err := box.OutlierLabels()
+	if err != nil {
 		log.Fatal(err)
 	}
+	p.Add(labels)
+	p.Legend.Add("mean", plots.LegendGlyph(box.MeanStyle))
+	p.Legend.Top = true
 
-	fmt.Println("Box plot has been saved to fertilizer_boxplot.png")
+	// --- 5. Save the plot to a file, with the five-number summary under it ---
+	// The dimensions are in standard points (e.g., inches * 72).
+	summary := box.Summary()
+	caption := fmt.Sprintf("%s   Mean = %.2f", summary, box.Mean)
+	if *format == report.JSON {
+		file, err := out.Save(p, 8*vg.Inch, 6*vg.Inch, caption)
+		if err != nil {
+			log.Fatal(err)
+		}
+		res := report.New("boxplot", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           len(data),
+			Variables:   []report.Variable{{Name: "Fertilizer Usage", Kind: "numeric", Unit: "kg"}},
+		})
+		plots.AddBoxStatistics(res, "", box, "kg")
+		res.Plots = append(res.Plots, file)
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *term {
+		chart := textplot.Chart{Title: p.Title.Text, XLabel: "Fertilizer Usage (KG)"}
+		fmt.Print(chart.BoxPlots(nil, []float64{box.Mean}, box.BoxPlot))
+	} else {
+		file, err := out.Save(p, 8*vg.Inch, 6*vg.Inch, caption)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("Box plot has been saved to", file)
+	}
+	fmt.Println()
+	fmt.Println("Five-number summary:")
+	fmt.Println(caption)
+	if out := box.Outliers(); len(out) > 0 {
+		fmt.Printf("Outliers (outside 1.5 × IQR fences): %v\n", out)
+	}
+	if *notch {
+		lo, hi := box.NotchBounds()
+		fmt.Printf("Median notch (≈95%% CI): [%.2f, %.2f]\n", lo, hi)
+	}
 }
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// group is one labelled sample drawn side by side with the others.
+type group struct {
+	name   string
+	values plotter.Values
+}
+
+func main() {
+	kind := flag.String("kind", "violin", "plot type: violin, strip or beeswarm")
+	dataset := flag.String("dataset", "apple_quality", "dataset: "+strings.Join(datasets.Names(), ", "))
+	out := plots.OutputFlags("")
+	format := report.FormatFlag()
+	flag.Parse()
+
+	ds, err := datasets.Get(*dataset)
+	if err != nil {
+		log.Fatal(err)
+	}
+	v := ds.Main()
+	ylabel := ds.Label(v)
+	unit := v.Unit
+	title := ds.Title
+
+	// Datasets with a nominal variable, such as the quality of the
+	// apples, are split by it; the others are drawn as one group.
+	var groups []group
+	for _, w := range ds.Variables {
+		if w.Kind != datasets.Nominal {
+			continue
+		}
+		levels, values, err := ds.Split(v.Name, w.Name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i, l := range levels {
+			groups = append(groups, group{l, values[i]})
+		}
+		title = fmt.Sprintf("%s by %s", ylabel, strings.ToUpper(w.Name[:1])+w.Name[1:])
+		break
+	}
+	if groups == nil {
+		x, err := ds.Floats(v.Name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		groups = []group{{"all", x}}
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("%s (%s plot)", title, *kind)
+	p.Y.Label.Text = ylabel
+	p.Add(plotter.NewGrid())
+
+	colors := []color.Color{
+		color.RGBA{R: 46, G: 139, B: 87, A: 255},  // sea green
+		color.RGBA{R: 178, G: 34, B: 34, A: 255},  // firebrick
+		color.RGBA{R: 70, G: 130, B: 180, A: 255}, // steel blue
+	}
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = fmt.Sprintf("%s (n=%d)", g.name, len(g.values))
+		col := colors[i%len(colors)]
+		point := draw.GlyphStyle{Color: col, Radius: vg.Points(3), Shape: draw.CircleGlyph{}}
+
+		switch *kind {
+		case "violin":
+			v, err := plots.NewViolin(vg.Points(90), float64(i), g.values)
+			if err != nil {
+				log.Fatal(err)
+			}
+			c := col.(color.RGBA)
+			v.FillColor = color.NRGBA{R: c.R, G: c.G, B: c.B, A: 110}
+			p.Add(v)
+		case "strip":
+			s, err := plots.NewStrip(vg.Points(40), float64(i), g.values)
+			if err != nil {
+				log.Fatal(err)
+			}
+			s.GlyphStyle = point
+			p.Add(s)
+		case "beeswarm":
+			b, err := plots.NewBeeswarm(float64(i), g.values)
+			if err != nil {
+				log.Fatal(err)
+			}
+			b.GlyphStyle = point
+			p.Add(b)
+		default:
+			log.Fatalf("unknown plot kind %q", *kind)
+		}
+	}
+	p.NominalX(names...)
+
+	if out.File == "" {
+		out.File = fmt.Sprintf("%s_%s.png", *dataset, *kind)
+	}
+	file, err := out.Save(p, 6*vg.Inch, 4*vg.Inch)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *format == report.JSON {
+		if err := writeResult(ds.Report(v.Name), unit, groups, file); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	fmt.Println("Saved", file)
+}
+
+// writeResult writes the size, centre and spread of each group as JSON.
+func writeResult(dataset report.Dataset, unit string, groups []group, plotFile string) error {
+	res := report.New("distplot", dataset)
+	for _, g := range groups {
+		sorted := stats.Sorted(g.values)
+		res.AddGroup(g.name, "n", len(sorted), "", "count")
+		res.AddGroup(g.name, "mean", stats.Mean(sorted), unit, "arithmetic mean")
+		res.AddGroup(g.name, "std_dev", stats.StdDev(sorted), unit, "sample (n-1)")
+		res.AddGroup(g.name, "q1", stats.Quantile(sorted, 0.25), unit, "linear interpolation (type 7)")
+		res.AddGroup(g.name, "median", stats.Quantile(sorted, 0.5), unit, "middle value of the sorted data")
+		res.AddGroup(g.name, "q3", stats.Quantile(sorted, 0.75), unit, "linear interpolation (type 7)")
+	}
+	res.Plots = append(res.Plots, plotFile)
+	return res.Write(os.Stdout)
+}
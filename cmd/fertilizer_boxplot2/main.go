@@ -1,34 +1,111 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
+	"os"
 
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
 func main() {
-	data := plotter.Values{
-		65, 64, 80, 66, 62, 67, 75, 54, 50, 74, 68, 65,
-		67, 55, 73, 71, 74, 61, 64, 52, 64, 60, 72,
+	notch := flag.Bool("notch", false, "draw a notch showing the 95% CI of the median")
+	jitter := flag.Bool("jitter", false, "overlay the raw observations as jittered points")
+	horizontal := flag.Bool("horizontal", false, "draw the box horizontally")
+	term := flag.Bool("term", false, "draw the box plot in the terminal instead of saving a figure")
+	out := plots.OutputFlags("fertilizer_boxplot2.png")
+	format := report.FormatFlag()
+	flag.Parse()
+
+	ds, err := datasets.Get("fertilizer")
+	if err != nil {
+		log.Fatal(err)
 	}
+	usage, err := ds.Floats("usage")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data := plotter.Values(usage)
 
 	p := plot.New()
 	p.Title.Text = "Boxplot of Fertilizer Usage (grams)"
-	p.Y.Label.Text = "Grams"
+	if *horizontal {
+		p.X.Label.Text = "Grams"
+		p.HideY()
+	} else {
+		p.Y.Label.Text = "Grams"
+		p.HideX()
+	}
 
-	box, err := plotter.NewBoxPlot(vg.Points(60), 0, data)
+	box, err := plots.NewBox(vg.Points(60), 0, data)
 	if err != nil {
 		log.Fatal(err)
 	}
 	box.FillColor = color.RGBA{R: 173, G: 216, B: 230, A: 255}
-	p.Add(box)
+	box.ShowMean = true
+	box.Notch = *notch
+	box.Jitter = *jitter
+	box.Horizontal = *horizontal
 
-	if err := p.Save(4*vg.Inch, 6*vg.Inch, "fertilizer_boxplot2.png"); err != nil {
+	// Label outliers with their observation number (1-based row ID)
+	box.IDs = make([]string, len(data))
+	for i := range data {
+		box.IDs[i] = fmt.Sprintf("#%d", i+1)
+	}
+	p.Add(box)
+	labels, err := box.OutlierLabels()
+	if err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println("Saved fertilizer_boxplot2.png")
+	p.Add(labels)
+
+	summary := box.Summary()
+	caption := []string{
+		fmt.Sprintf("Min = %.1f   Q1 = %.1f   Median = %.1f", summary.Min, summary.Q1, summary.Median),
+		fmt.Sprintf("Q3 = %.1f   Max = %.1f   Mean = %.2f", summary.Q3, summary.Max, box.Mean),
+	}
+	w, h := 4*vg.Inch, 6*vg.Inch
+	if *horizontal {
+		w, h = h, w
+	}
+	if *format == report.JSON {
+		file, err := out.Save(p, w, h, caption...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		res := report.New("fertilizer_boxplot2", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           len(data),
+			Variables:   []report.Variable{{Name: "Usage", Kind: "numeric", Unit: "g"}},
+		})
+		plots.AddBoxStatistics(res, "", box, "g")
+		res.Plots = append(res.Plots, file)
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *term {
+		chart := textplot.Chart{Title: p.Title.Text, XLabel: "Grams"}
+		fmt.Print(chart.BoxPlots(nil, []float64{box.Mean}, box.BoxPlot))
+	} else {
+		file, err := out.Save(p, w, h, caption...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("Saved", file)
+	}
+	fmt.Println(summary)
+	for _, i := range box.Outside {
+		fmt.Printf("Outlier: %s = %.0f g\n", box.IDs[i], data[i])
+	}
 }
@@ -1,14 +1,30 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
+	"os"
 	"sort"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
 )
 
 func main() {
-	data := []float64{65, 64, 80, 66, 62, 67, 75, 54, 50, 74, 68, 65, 67, 55, 73, 71, 74, 61, 64, 52, 64, 60, 72}
+	format := report.FormatFlag()
+	flag.Parse()
+
+	ds, err := datasets.Get("fertilizer")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := ds.Floats("usage")
+	if err != nil {
+		log.Fatal(err)
+	}
 	sort.Float64s(data)
-	fmt.Println("Sorted data:", data)
 
 	n := len(data)
 	// Mean
@@ -66,7 +82,53 @@ func main() {
 	// Variability note: range
 	rangeVal := data[n-1] - data[0]
 
+	// Outlier fences (1.5 × IQR rule)
+	lowFence := q1 - 1.5*iqr
+	highFence := q3 + 1.5*iqr
+	outliers := []float64{}
+	for _, v := range data {
+		if v < lowFence || v > highFence {
+			outliers = append(outliers, v)
+		}
+	}
+
+	// Planning the next trial: plots per fertilizer needed to detect a
+	// change of 10% in mean usage, with the SD of this sample.
+	sd := stats.StdDev(data)
+	change := 0.1 * mean
+	effect := change / sd
+	plots, err := stats.SampleSize(func(n int) float64 { return stats.PowerTwoSampleT(effect, n, 0.05) }, 0.8, 2)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *format == report.JSON {
+		res := report.New("fertilizer_stats", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           n,
+			Variables:   []report.Variable{{Name: "Usage", Kind: "numeric", Unit: "g"}},
+		})
+		res.Add("mean", mean, "g", "arithmetic mean")
+		res.Add("median", median, "g", "middle value of the sorted data")
+		res.Add("mode", modes, "g", "most frequent values; empty when all values are unique")
+		res.Add("q1", q1, "g", "median of lower half (Tukey hinge)")
+		res.Add("q3", q3, "g", "median of upper half (Tukey hinge)")
+		res.Add("iqr", iqr, "g", "Q3 - Q1")
+		res.Add("range", rangeVal, "g", "max - min")
+		res.Add("lower_fence", lowFence, "g", "Q1 - 1.5 IQR")
+		res.Add("upper_fence", highFence, "g", "Q3 + 1.5 IQR")
+		res.Add("outliers", outliers, "g", "values outside the 1.5 IQR fences")
+		res.Add("std_dev", sd, "g", "sample (n-1)")
+		res.Add("plots_per_fertilizer", plots, "", fmt.Sprintf("two-sample t-test detecting a 10%% change (%.2f g, d = %.3f) with power 0.8 at alpha = 0.05", change, effect))
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Print results
+	fmt.Println("Sorted data:", data)
 	fmt.Println()
 	fmt.Println("--- Central Tendency ---")
 	if len(modes) == 0 {
@@ -90,22 +152,22 @@ func main() {
 	fmt.Printf("- Median = %.4f indicates center.\n", median)
 	fmt.Printf("- IQR = %.4f shows spread of middle 50%% of data.\n", iqr)
 	fmt.Printf("- Values outside [Q1 - 1.5*IQR, Q3 + 1.5*IQR] are potential outliers.\n")
-	lowFence := q1 - 1.5*iqr
-	highFence := q3 + 1.5*iqr
 	fmt.Printf("- Lower fence = %.4f, Upper fence = %.4f\n", lowFence, highFence)
 
 	// Identify outliers
-	outliers := []float64{}
-	for _, v := range data {
-		if v < lowFence || v > highFence {
-			outliers = append(outliers, v)
-		}
-	}
 	if len(outliers) == 0 {
 		fmt.Println("- No outliers detected by 1.5*IQR rule.")
 	} else {
 		fmt.Printf("- Outliers: %v\n", outliers)
 	}
+
+	fmt.Println()
+	fmt.Println("--- Planning the Next Trial ---")
+	fmt.Printf("Standard deviation (sample): %.4f\n", sd)
+	fmt.Printf("To compare a new fertilizer with this one and detect a 10%% change in mean\n")
+	fmt.Printf("usage (%.2f g, effect size d = %.3f) with 80%% power at alpha = 0.05,\n", change, effect)
+	fmt.Printf("a two-sample t-test needs %d plots per fertilizer (%d in all).\n", plots, 2*plots)
+	fmt.Println("Run \"stats power -test t2 -dataset fertilizer -diff D -curve\" for other changes D.")
 }
 
 func medianOfSlice(s []float64) float64 {
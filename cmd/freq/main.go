@@ -1,70 +1,60 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"math"
+	"os"
 	"sort"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/report"
 )
 
 func main() {
+	format := report.FormatFlag()
+	flag.Parse()
+	text := *format == report.Text
+
 	// Sample wheat yield data (30 observations) -- original order as provided
-	data := []int{
-		145,
-		152,
-		138,
-		167,
-		155,
-		161,
-		143,
-		158,
-		149,
-		172,
-		162,
-		147,
-		154,
-		168,
-		141,
-		159,
-		165,
-		150,
-		163,
-		140,
-		156,
-		169,
-		144,
-		160,
-		153,
-		166,
-		142,
-		157,
-		151,
-		164,
+	ds, err := datasets.Get("wheat_yield")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := ds.Ints("yield")
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Print the original data in the given order
-	fmt.Println("Original data (given order):")
-	for _, v := range data {
-		fmt.Printf("%d ", v)
+	if text {
+		fmt.Println("Original data (given order):")
+		for _, v := range data {
+			fmt.Printf("%d ", v)
+		}
+		fmt.Print("\n\n")
 	}
-	fmt.Println("\n")
 
 	// Raw frequency count for individual values (value -> count)
 	rawFreq := make(map[int]int)
 	for _, v := range data {
 		rawFreq[v]++
 	}
-	// Print raw frequency table sorted by value
-	fmt.Println("Raw frequency (value : count):")
 	// collect and sort keys
 	keys := make([]int, 0, len(rawFreq))
 	for k := range rawFreq {
 		keys = append(keys, k)
 	}
 	sort.Ints(keys)
-	for _, k := range keys {
-		fmt.Printf("%d : %d\n", k, rawFreq[k])
+	// Print raw frequency table sorted by value
+	if text {
+		fmt.Println("Raw frequency (value : count):")
+		for _, k := range keys {
+			fmt.Printf("%d : %d\n", k, rawFreq[k])
+		}
+		fmt.Println()
 	}
-	fmt.Println()
 
 	sort.Ints(data)
 	n := len(data)
@@ -107,6 +97,34 @@ func main() {
 		}
 	}
 
+	if !text {
+		res := report.New("freq", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           n,
+			Variables:   []report.Variable{{Name: "Grain Yield", Kind: "numeric", Unit: "kg"}},
+		})
+		res.Add("min", min, "kg", "smallest value")
+		res.Add("max", max, "kg", "largest value")
+		res.Add("range", rng, "kg", "max - min")
+		res.Add("suggested_classes", suggestedClasses, "", "square-root rule, round(sqrt(n))")
+		res.Add("classes", numClasses, "", "fixed")
+		res.Add("class_width", width, "kg", "ceil(range / classes)")
+		raw := report.Table{Name: "raw frequency", Columns: []string{"value", "count"}}
+		for _, k := range keys {
+			raw.Rows = append(raw.Rows, []any{k, rawFreq[k]})
+		}
+		grouped := report.Table{Name: "grouped frequency", Columns: []string{"lower", "upper", "frequency"}}
+		for i, intr := range intervals {
+			grouped.Rows = append(grouped.Rows, []any{intr[0], intr[1], counts[i]})
+		}
+		res.Tables = append(res.Tables, raw, grouped)
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Print the frequency table
 	fmt.Println("| Grain Yield (KG) | Frequency |")
 	fmt.Println("| :--- | :---: |")
@@ -118,7 +136,7 @@ func main() {
 	fmt.Println("| **Total** | **", total, "** |")
 
 	// Print the step-by-step guide summary
-	fmt.Println("\n---\n")
+	fmt.Print("\n---\n\n")
 	fmt.Println("Step-by-step summary:")
 	fmt.Printf("1) Number of observations: %d\n", n)
 	fmt.Printf("2) Minimum value: %d\n", min)
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/explain"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// writeLaTeX writes the histogram's frequency table, the measures of
+// variability derived step by step, and the histogram itself to file as
+// a LaTeX document. The figure is saved as a PDF next to it, and its
+// file returned.
+func writeLaTeX(file string, data []float64, bins []plotter.HistogramBin, p *plot.Plot) (string, error) {
+	n := len(data)
+	page := &report.Page{
+		Title:    "Wheat Yield: Histogram and Measures of Variability",
+		Subtitle: fmt.Sprintf("Grain yield of %d wheat plots", n),
+	}
+
+	s := page.Section("Frequency Distribution")
+	t := report.Table{Name: "Histogram classes", Columns: []string{"Grain Yield (kg)", "Frequency"}}
+	for _, b := range bins {
+		t.Rows = append(t.Rows, []any{fmt.Sprintf("%.2f – %.2f", b.Min, b.Max), b.Weight})
+	}
+	t.Rows = append(t.Rows, []any{"Total", n})
+	s.AddTable(t)
+
+	fig := strings.TrimSuffix(file, filepath.Ext(file)) + "_histogram.pdf"
+	if err := plots.SaveWithCaption(p, 6*vg.Inch, 4.5*vg.Inch, fig); err != nil {
+		return "", err
+	}
+	s.AddFigure(report.Figure{File: filepath.Base(fig), Caption: p.Title.Text})
+
+	s = page.Section("1. Range")
+	s.Para("The range is the difference between the maximum and minimum values.")
+	explain.Range(data, kgOptions).AddTo(s, level)
+
+	v := explain.Variance(data, true, kgOptions)
+	s = page.Section("2. Variance and Standard Deviation")
+	s.Para("The population variance is the average squared distance from the mean; the standard deviation is its square root.")
+	v.AddTo(s, level)
+	explain.StdDevFrom(v, true, kgOptions).AddTo(s, level)
+
+	s = page.Section("3. Interquartile Range")
+	s.Para("Q1 and Q3 are the medians of the lower and upper halves of the sorted data.")
+	explain.IQR(data, kgOptions).AddTo(s, level)
+
+	f, err := os.Create(file)
+	if err != nil {
+		return "", err
+	}
+	if err := page.WriteLaTeX(f); err != nil {
+		f.Close()
+		return "", err
+	}
+	return fig, f.Close()
+}
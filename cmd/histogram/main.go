@@ -1,23 +1,52 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
 	"math"
+	"os"
 	"sort"
 
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/explain"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
+// level is how much of each derivation is shown.
+var level = explain.Full
+
+// kgOptions shows the derivations in kilograms.
+var kgOptions = explain.Options{Unit: "kg"}
+
 func main() {
+	showKDE := flag.Bool("kde", false, "overlay a kernel density estimate (histogram scaled to density)")
+	kernelName := flag.String("kernel", "gaussian", "KDE kernel: gaussian, epanechnikov, triangular, rectangular, biweight, triweight or cosine")
+	bwName := flag.String("bw", "sj", "KDE bandwidth: silverman, scott, sj or a number")
+	term := flag.Bool("term", false, "draw the histogram in the terminal instead of saving a figure")
+	out := plots.OutputFlags("wheat_yield_histogram.png")
+	format := report.FormatFlag()
+	tex := flag.String("tex", "", "also write the frequency table, derivations and histogram to this file as a LaTeX document")
+	flag.Var(&level, "explain", "detail of the derivations: answer, key or full")
+	flag.BoolVar(&kgOptions.Exact, "exact", false, "compute the derivations with exact fractions, flagging where rounding first occurs")
+	flag.IntVar(&kgOptions.Sig, "sig", 6, "significant figures of the rounded decimals with -exact")
+	flag.Parse()
+
 	// Wheat yield data (30 observations) -- original order as provided
-	data := []float64{
-		145, 152, 138, 167, 155, 161, 143, 158, 149, 172,
-		162, 147, 154, 168, 141, 159, 165, 150, 163, 140,
-		156, 169, 144, 160, 153, 166, 142, 157, 151, 164,
+	ds, err := datasets.Get("wheat_yield")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := ds.Floats("yield")
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// --- 1. Create histogram data ---
@@ -40,15 +69,82 @@ func main() {
 	// Customize histogram appearance
 	hist.FillColor = color.RGBA{R: 173, G: 216, B: 230, A: 255} // Light blue
 
+	// Optionally overlay a kernel density estimate. The histogram is
+	// rescaled so that its total area is 1, matching the density curve.
+	if *showKDE {
+		hist.Normalize(1)
+		p.Y.Label.Text = "Density"
+	}
+
 	// Add histogram to the plot
 	p.Add(hist)
 
-	// --- 4. Save the plot to a file ---
-	if err := p.Save(8*vg.Inch, 6*vg.Inch, "wheat_yield_histogram.png"); err != nil {
-		log.Fatal(err)
+	var kde *stats.KDE
+	if *showKDE {
+		kernel, err := stats.ParseKernel(*kernelName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		bw, err := stats.SelectBandwidth(*bwName, data)
+		if err != nil {
+			log.Fatal(err)
+		}
+		kde = stats.NewKDE(data)
+		kde.Kernel = kernel
+		kde.Bandwidth = bw
+
+		curve := plotter.NewFunction(kde.Density)
+		curve.Color = color.RGBA{R: 0, G: 0, B: 139, A: 255}
+		curve.Width = vg.Points(1.5)
+		curve.Samples = 200
+		p.Add(curve)
+		p.Legend.Add(fmt.Sprintf("KDE (%s, h = %.2f)", kernel, bw), curve)
+		p.Legend.Top = true
+		p.X.Min, p.X.Max = kde.Range(2)
+	}
+
+	if *tex != "" {
+		fig, err := writeLaTeX(*tex, data, hist.Bins, p)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *format == report.Text {
+			fmt.Println("LaTeX document has been saved to", *tex, "with its figure", fig)
+		}
 	}
 
-	fmt.Println("Histogram has been saved to wheat_yield_histogram.png")
+	// --- 4. Save the plot to a file, or draw the same bins in the terminal ---
+	if *format == report.JSON {
+		file, err := out.Save(p, 8*vg.Inch, 6*vg.Inch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeResult(ds, data, hist.Bins, kde, *bwName, file); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *term {
+		chart := textplot.Chart{Title: p.Title.Text, XLabel: p.X.Label.Text, YLabel: p.Y.Label.Text}
+		fmt.Print(chart.Histogram(hist.Bins))
+		if kde != nil {
+			curve := textplot.XYSeries{Name: fmt.Sprintf("KDE (%s, h = %.2f)", kde.Kernel, kde.Bandwidth), Line: true}
+			xs, ds := kde.Grid(200)
+			for i := range xs {
+				curve.XYs = append(curve.XYs, plotter.XY{X: xs[i], Y: ds[i]})
+			}
+			chart.Title = "Kernel Density Estimate"
+			chart.YLabel = "Density"
+			fmt.Println()
+			fmt.Print(chart.Scatter(curve))
+		}
+	} else {
+		file, err := out.Save(p, 8*vg.Inch, 6*vg.Inch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("Histogram has been saved to", file)
+	}
 	fmt.Println()
 
 	// --- 5. Calculate measures of variability ---
@@ -57,88 +153,96 @@ func main() {
 
 	// --- 6. Analyze the distribution shape ---
 	analyzeDistribution(data)
+
+	if kde != nil {
+		fmt.Println()
+		fmt.Println("--- Kernel Density Estimate ---")
+		fmt.Printf("Kernel: %s, bandwidth h = %.4f kg (%s)\n", kde.Kernel, kde.Bandwidth, *bwName)
+		modes := kde.Modes()
+		fmt.Printf("Estimated density mode(s): ")
+		for i, m := range modes {
+			if i > 0 {
+				fmt.Print(", ")
+			}
+			fmt.Printf("%.2f kg (f = %.4f)", m, kde.Density(m))
+		}
+		fmt.Println()
+		if len(modes) > 1 {
+			fmt.Println("The density estimate is multimodal at this bandwidth.")
+		} else {
+			fmt.Println("The density estimate is unimodal at this bandwidth.")
+		}
+	}
 }
 
-func calculateVariability(data []float64) {
+// writeResult writes the statistics that the text report derives step
+// by step, the histogram bins and the density estimate as JSON.
+func writeResult(ds *datasets.Dataset, data []float64, bins []plotter.HistogramBin, kde *stats.KDE, bwName, plotFile string) error {
 	n := len(data)
+	sorted := stats.Sorted(data)
+	mean := stats.Mean(data)
+	variance := stats.Variance(data) * float64(n-1) / float64(n)
+	var m3 float64
+	for _, v := range data {
+		m3 += math.Pow(v-mean, 3)
+	}
+	skewness := m3 / float64(n) / math.Pow(variance, 1.5)
+	q1 := getMedian(sorted[:n/2])
+	q3 := getMedian(sorted[(n+1)/2:])
+
+	res := report.New("histogram", report.Dataset{
+		Name:        ds.Name,
+		Description: ds.Description,
+		N:           n,
+		Variables:   []report.Variable{{Name: "Grain Yield", Kind: "numeric", Unit: "kg"}},
+	})
+	res.Add("mean", mean, "kg", "arithmetic mean")
+	res.Add("median", getMedian(sorted), "kg", "middle value of the sorted data")
+	res.Add("range", sorted[n-1]-sorted[0], "kg", "max - min")
+	res.Add("variance", variance, "kg²", "population (n)")
+	res.Add("std_dev", math.Sqrt(variance), "kg", "population (n)")
+	res.Add("q1", q1, "kg", "median of lower half")
+	res.Add("q3", q3, "kg", "median of upper half")
+	res.Add("iqr", q3-q1, "kg", "Q3 - Q1")
+	res.Add("skewness", skewness, "", "moment coefficient m3 / m2^1.5")
+	if kde != nil {
+		res.Add("kde_bandwidth", kde.Bandwidth, "kg", fmt.Sprintf("%s kernel, %s selector", kde.Kernel, bwName))
+		res.Add("kde_modes", kde.Modes(), "kg", "local maxima of the density estimate")
+	}
+
+	table := report.Table{Name: "histogram", Columns: []string{"min", "max", "weight"}}
+	for _, b := range bins {
+		table.Rows = append(table.Rows, []any{b.Min, b.Max, b.Weight})
+	}
+	res.Tables = append(res.Tables, table)
+	res.Plots = append(res.Plots, plotFile)
+	return res.Write(os.Stdout)
+}
 
+func calculateVariability(data []float64) {
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println("         MEASURES OF VARIABILITY - Step by Step")
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println()
 
-	// Sort the data for range and quartile calculations
-	sorted := make([]float64, n)
-	copy(sorted, data)
-	sort.Float64s(sorted)
-
 	// ============ 1. RANGE ============
 	fmt.Println("--- 1. RANGE ---")
 	fmt.Println()
 	fmt.Println("The range is the difference between the maximum and minimum values.")
 	fmt.Println("It gives us the total spread of the data.")
 	fmt.Println()
-
-	min := sorted[0]
-	max := sorted[n-1]
-	dataRange := max - min
-
-	fmt.Printf("Step 1: Identify the minimum value = %.0f kg\n", min)
-	fmt.Printf("Step 2: Identify the maximum value = %.0f kg\n", max)
-	fmt.Printf("Step 3: Calculate Range = Max - Min\n")
-	fmt.Printf("        Range = %.0f - %.0f = %.0f kg\n", max, min, dataRange)
-	fmt.Println()
-	fmt.Printf("✓ Range = %.0f kg\n", dataRange)
+	r := explain.Range(data, kgOptions)
+	r.Write(os.Stdout, explain.Text, level)
 	fmt.Println()
 
 	// ============ 2. VARIANCE ============
 	fmt.Println("--- 2. VARIANCE ---")
 	fmt.Println()
 	fmt.Println("Variance measures how far each number in the dataset is from the mean.")
-	fmt.Println("Formula: σ² = Σ(xi - μ)² / n")
-	fmt.Println()
-
-	// Calculate mean
-	var sum float64
-	for _, v := range data {
-		sum += v
-	}
-	mean := sum / float64(n)
-
-	fmt.Printf("Step 1: Calculate the mean (μ)\n")
-	fmt.Printf("        Sum of all values = %.0f\n", sum)
-	fmt.Printf("        Mean (μ) = %.0f / %d = %.4f kg\n", sum, n, mean)
-	fmt.Println()
-
-	fmt.Println("Step 2: Calculate (xi - μ)² for each value:")
-	fmt.Println("        Value | (Value - Mean) | (Value - Mean)²")
-	fmt.Println("        ------|----------------|----------------")
-
-	var sumSquaredDiff float64
-	// Show first 5 and last 5 examples to keep output manageable
-	for i, v := range sorted {
-		diff := v - mean
-		sqDiff := diff * diff
-		sumSquaredDiff += sqDiff
-
-		if i < 5 || i >= n-5 {
-			fmt.Printf("        %.0f   |   %7.4f    |   %10.4f\n", v, diff, sqDiff)
-		} else if i == 5 {
-			fmt.Println("        ...   |      ...       |       ...")
-		}
-	}
-
-	fmt.Println()
-	fmt.Printf("Step 3: Sum all squared differences\n")
-	fmt.Printf("        Σ(xi - μ)² = %.4f\n", sumSquaredDiff)
-	fmt.Println()
-
-	variance := sumSquaredDiff / float64(n)
-
-	fmt.Printf("Step 4: Divide by n (population variance)\n")
-	fmt.Printf("        Variance (σ²) = %.4f / %d = %.4f kg²\n", sumSquaredDiff, n, variance)
+	fmt.Println("The 30 plots are the whole population, so we divide by n.")
 	fmt.Println()
-	fmt.Printf("✓ Variance = %.4f kg²\n", variance)
+	v := explain.Variance(data, true, kgOptions)
+	v.Write(os.Stdout, explain.Text, level)
 	fmt.Println()
 
 	// ============ 3. STANDARD DEVIATION ============
@@ -146,15 +250,10 @@ func calculateVariability(data []float64) {
 	fmt.Println()
 	fmt.Println("Standard deviation is the square root of variance.")
 	fmt.Println("It measures the average distance of data points from the mean.")
-	fmt.Println("Formula: σ = √(σ²)")
-	fmt.Println()
-
-	stdDev := math.Sqrt(variance)
-
-	fmt.Printf("Step 1: Take the square root of variance\n")
-	fmt.Printf("        σ = √(%.4f) = %.4f kg\n", variance, stdDev)
 	fmt.Println()
-	fmt.Printf("✓ Standard Deviation = %.4f kg\n", stdDev)
+	sd := explain.StdDevFrom(v, true, kgOptions)
+	sd.Write(os.Stdout, explain.Text, level)
+	stdDev := sd.Value.(float64)
 	fmt.Println()
 	fmt.Println("Interpretation: On average, the wheat yield values deviate")
 	fmt.Printf("from the mean by approximately %.2f kg.\n", stdDev)
@@ -166,100 +265,28 @@ func calculateVariability(data []float64) {
 	fmt.Println("The IQR measures the spread of the middle 50% of the data.")
 	fmt.Println("It is the difference between Q3 (75th percentile) and Q1 (25th percentile).")
 	fmt.Println()
-
-	fmt.Println("Step 1: Arrange data in ascending order (already sorted)")
-	fmt.Println("Sorted data:")
-	for i, v := range sorted {
-		if i > 0 && i%10 == 0 {
-			fmt.Println()
-		}
-		fmt.Printf("%.0f ", v)
-	}
-	fmt.Println()
-	fmt.Println()
-
-	// Calculate Q1 and Q3
-	// Using method: split data into two halves (excluding median for odd n)
-	midpoint := n / 2
-
-	var lowerHalf, upperHalf []float64
-	if n%2 == 0 {
-		// Even number: split exactly in half
-		lowerHalf = sorted[:midpoint]
-		upperHalf = sorted[midpoint:]
-	} else {
-		// Odd number: exclude the median
-		lowerHalf = sorted[:midpoint]
-		upperHalf = sorted[midpoint+1:]
-	}
-
-	fmt.Printf("Step 2: Split data into two halves (n = %d, midpoint at position %d)\n", n, midpoint)
-	fmt.Printf("        Lower half (first %d values): ", len(lowerHalf))
-	for _, v := range lowerHalf {
-		fmt.Printf("%.0f ", v)
-	}
-	fmt.Println()
-	fmt.Printf("        Upper half (last %d values): ", len(upperHalf))
-	for _, v := range upperHalf {
-		fmt.Printf("%.0f ", v)
-	}
-	fmt.Println()
-	fmt.Println()
-
-	// Calculate Q1 (median of lower half)
-	q1 := getMedian(lowerHalf)
-	fmt.Printf("Step 3: Calculate Q1 (median of lower half)\n")
-	if len(lowerHalf)%2 == 0 {
-		mid1Idx := len(lowerHalf)/2 - 1
-		mid2Idx := len(lowerHalf) / 2
-		fmt.Printf("        Lower half has %d values (even)\n", len(lowerHalf))
-		fmt.Printf("        Middle values: %.0f and %.0f\n", lowerHalf[mid1Idx], lowerHalf[mid2Idx])
-		fmt.Printf("        Q1 = (%.0f + %.0f) / 2 = %.2f kg\n", lowerHalf[mid1Idx], lowerHalf[mid2Idx], q1)
-	} else {
-		midIdx := len(lowerHalf) / 2
-		fmt.Printf("        Lower half has %d values (odd)\n", len(lowerHalf))
-		fmt.Printf("        Middle value at position %d: %.0f\n", midIdx+1, lowerHalf[midIdx])
-		fmt.Printf("        Q1 = %.2f kg\n", q1)
-	}
-	fmt.Println()
-
-	// Calculate Q3 (median of upper half)
-	q3 := getMedian(upperHalf)
-	fmt.Printf("Step 4: Calculate Q3 (median of upper half)\n")
-	if len(upperHalf)%2 == 0 {
-		mid1Idx := len(upperHalf)/2 - 1
-		mid2Idx := len(upperHalf) / 2
-		fmt.Printf("        Upper half has %d values (even)\n", len(upperHalf))
-		fmt.Printf("        Middle values: %.0f and %.0f\n", upperHalf[mid1Idx], upperHalf[mid2Idx])
-		fmt.Printf("        Q3 = (%.0f + %.0f) / 2 = %.2f kg\n", upperHalf[mid1Idx], upperHalf[mid2Idx], q3)
-	} else {
-		midIdx := len(upperHalf) / 2
-		fmt.Printf("        Upper half has %d values (odd)\n", len(upperHalf))
-		fmt.Printf("        Middle value at position %d: %.0f\n", midIdx+1, upperHalf[midIdx])
-		fmt.Printf("        Q3 = %.2f kg\n", q3)
-	}
-	fmt.Println()
-
-	iqr := q3 - q1
-	fmt.Printf("Step 5: Calculate IQR\n")
-	fmt.Printf("        IQR = Q3 - Q1 = %.2f - %.2f = %.2f kg\n", q3, q1, iqr)
-	fmt.Println()
-	fmt.Printf("✓ Interquartile Range (IQR) = %.2f kg\n", iqr)
+	iqr := explain.IQR(data, kgOptions)
+	iqr.Write(os.Stdout, explain.Text, level)
 	fmt.Println()
 	fmt.Println("Interpretation: The middle 50% of wheat yield values")
-	fmt.Printf("span a range of %.2f kg.\n", iqr)
+	fmt.Printf("span a range of %.2f kg.\n", iqr.Value)
 	fmt.Println()
 
+	sorted := stats.Sorted(data)
+	n := len(sorted)
+	q1 := getMedian(sorted[:n/2])
+	q3 := getMedian(sorted[(n+1)/2:])
+
 	// Summary table
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println("                    SUMMARY OF VARIABILITY")
 	fmt.Println("═══════════════════════════════════════════════════════════")
-	fmt.Printf("Range:                   %.2f kg\n", dataRange)
-	fmt.Printf("Variance (σ²):           %.4f kg²\n", variance)
+	fmt.Printf("Range:                   %.2f kg\n", r.Value)
+	fmt.Printf("Variance (σ²):           %.4f kg²\n", v.Value)
 	fmt.Printf("Standard Deviation (σ):  %.4f kg\n", stdDev)
 	fmt.Printf("Q1 (First Quartile):     %.2f kg\n", q1)
 	fmt.Printf("Q3 (Third Quartile):     %.2f kg\n", q3)
-	fmt.Printf("IQR (Q3 - Q1):           %.2f kg\n", iqr)
+	fmt.Printf("IQR (Q3 - Q1):           %.2f kg\n", iqr.Value)
 	fmt.Println("═══════════════════════════════════════════════════════════")
 }
 
@@ -1,30 +1,47 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
+	"log"
 	"math"
+	"os"
 	"sort"
 
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
 func main() {
+	term := flag.Bool("term", false, "draw the histogram in the terminal instead of saving a figure")
+	out := plots.OutputFlags("marks_histogram.png")
+	format := report.FormatFlag()
+	flag.Parse()
+	text := *format == report.Text
+
 	// Marks dataset
-	marks := []float64{10, 43, 25, 34, 31, 9, 25, 30, 28, 12, 26, 19, 11, 8, 35, 41, 28, 19, 8, 21, 20, 47, 32, 28, 21}
+	ds, err := datasets.Get("student_marks")
+	if err != nil {
+		log.Fatal(err)
+	}
+	marks, err := ds.Floats("marks")
+	if err != nil {
+		log.Fatal(err)
+	}
 	n := len(marks)
 	sort.Float64s(marks)
 
-	fmt.Printf("Sorted marks (%d values): %v\n\n", n, marks)
-
 	// 1) Number of classes by sqrt(n)
 	numClasses := int(math.Round(math.Sqrt(float64(n))))
 	if numClasses < 1 {
 		numClasses = 1
 	}
-	fmt.Printf("Number of classes (sqrt(n) rounded): %d\n", numClasses)
 
 	// range and class width
 	min := marks[0]
@@ -34,8 +51,12 @@ func main() {
 	if width < 1 {
 		width = 1
 	}
-	fmt.Printf("Range = %.0f - %.0f = %.0f\n", max, min, rangeVal)
-	fmt.Printf("Class width (rounded up) = %.0f\n\n", width)
+	if text {
+		fmt.Printf("Sorted marks (%d values): %v\n\n", n, marks)
+		fmt.Printf("Number of classes (sqrt(n) rounded): %d\n", numClasses)
+		fmt.Printf("Range = %.0f - %.0f = %.0f\n", max, min, rangeVal)
+		fmt.Printf("Class width (rounded up) = %.0f\n\n", width)
+	}
 
 	// Build class intervals (inclusive lower, exclusive upper except last)
 	types := make([][2]float64, numClasses)
@@ -63,16 +84,18 @@ func main() {
 	}
 
 	// Print frequency table
-	fmt.Println("Frequency table:")
-	fmt.Println("Class interval\tFrequency")
-	total := 0
-	for i := 0; i < numClasses; i++ {
-		low := types[i][0]
-		high := types[i][1]
-		fmt.Printf("%.0f - %.0f\t\t%d\n", low, math.Floor(high-0.0001), freq[i])
-		total += freq[i]
+	if text {
+		fmt.Println("Frequency table:")
+		fmt.Println("Class interval\tFrequency")
+		total := 0
+		for i := 0; i < numClasses; i++ {
+			low := types[i][0]
+			high := types[i][1]
+			fmt.Printf("%.0f - %.0f\t\t%d\n", low, math.Floor(high-0.0001), freq[i])
+			total += freq[i]
+		}
+		fmt.Printf("Total\t\t%d\n\n", total)
 	}
-	fmt.Printf("Total\t\t%d\n\n", total)
 
 	// 2) Draw histogram with numClasses bins
 	vals := plotter.Values(marks)
@@ -88,10 +111,19 @@ func main() {
 	hist.FillColor = color.RGBA{R: 100, G: 149, B: 237, A: 255}
 	p.Add(hist)
 
-	if err := p.Save(6*vg.Inch, 4*vg.Inch, "marks_histogram.png"); err != nil {
-		panic(err)
+	var file string
+	if *term && text {
+		chart := textplot.Chart{Title: p.Title.Text, XLabel: p.X.Label.Text, YLabel: p.Y.Label.Text}
+		fmt.Print(chart.Histogram(hist.Bins))
+	} else {
+		file, err = out.Save(p, 6*vg.Inch, 4*vg.Inch)
+		if err != nil {
+			panic(err)
+		}
+		if text {
+			fmt.Println("Saved", file)
+		}
 	}
-	fmt.Println("Saved marks_histogram.png")
 
 	// 3) Measures of variability and central tendency
 	mean := mean(marks)
@@ -104,6 +136,38 @@ func main() {
 	q1 := quartile(marks, 0.25)
 	q3 := quartile(marks, 0.75)
 	iqr := q3 - q1
+	skew := skewness(marks)
+
+	if !text {
+		res := report.New("marks_analysis", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           n,
+			Variables:   []report.Variable{{Name: "Marks", Kind: "numeric", Unit: "marks"}},
+		})
+		res.Add("mean", mean, "marks", "arithmetic mean")
+		res.Add("median", median, "marks", "middle value of the sorted data")
+		res.Add("mode", modes, "marks", "most frequent values; empty when all values are unique")
+		res.Add("range", rangeVal, "marks", "max - min")
+		res.Add("variance", varPop, "marks²", "population (n)")
+		res.Add("variance", varSample, "marks²", "sample (n-1)")
+		res.Add("std_dev", stdPop, "marks", "population (n)")
+		res.Add("std_dev", stdSample, "marks", "sample (n-1)")
+		res.Add("q1", q1, "marks", "linear interpolation at p(n+1)")
+		res.Add("q3", q3, "marks", "linear interpolation at p(n+1)")
+		res.Add("iqr", iqr, "marks", "Q3 - Q1")
+		res.Add("skewness", skew, "", "moment coefficient m3 / m2^1.5")
+		table := report.Table{Name: "frequency", Columns: []string{"lower", "upper", "frequency"}}
+		for i := range types {
+			table.Rows = append(table.Rows, []any{types[i][0], math.Floor(types[i][1] - 0.0001), freq[i]})
+		}
+		res.Tables = append(res.Tables, table)
+		res.Plots = append(res.Plots, file)
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	fmt.Println()
 	fmt.Println("Central tendency:")
@@ -127,7 +191,6 @@ func main() {
 	fmt.Printf("IQR: %.4f\n", iqr)
 
 	// 4) Suitable measure for variability: choose based on skewness
-	skew := skewness(marks)
 	fmt.Println()
 	fmt.Printf("Skewness: %.4f\n", skew)
 	if math.Abs(skew) < 0.5 {
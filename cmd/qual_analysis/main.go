@@ -1,116 +1,185 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
-
+	"log"
+	"os"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
+// term draws the bar charts in the terminal instead of saving figures.
+var term = flag.Bool("term", false, "draw the bar charts in the terminal instead of saving figures")
+
+// out sets where and in which format the figures are saved.
+var out = plots.OutputFlags("")
+
+// format selects the prose report or the JSON result.
+var format = report.FormatFlag()
+
 func main() {
-	// Dataset (20 observations)
-	type Record struct {
-		ID          int
-		Weight      float64
-		Crunchiness string // high/medium/low
-		Sweetness   float64
-		Ripeness    int    // 1-4 (ordinal)
-		Quality     string // good/bad
-	}
-
-	data := []Record{
-		{1, 70, "high", 0.5, 1, "good"},
-		{2, 90, "medium", 1, 2, "bad"},
-		{3, 83, "high", 1, 1, "good"},
-		{4, 85, "low", 3, 3, "good"},
-		{5, 90, "low", 3, 3, "good"},
-		{6, 78, "high", 1.5, 2, "good"},
-		{7, 78, "high", 0.5, 4, "bad"},
-		{8, 93, "medium", 1, 4, "good"},
-		{9, 85, "high", 3.5, 3, "good"},
-		{10, 88, "low", 2, 3, "bad"},
-		{11, 86, "high", 2, 2, "good"},
-		{12, 92, "low", 2, 2, "good"},
-		{13, 95, "medium", 1.5, 3, "bad"},
-		{14, 100, "high", 4, 3, "good"},
-		{15, 94, "medium", 2.5, 2, "good"},
-		{16, 96, "low", 2, 4, "good"},
-		{17, 70, "low", 1, 1, "good"},
-		{18, 82, "medium", 3, 1, "bad"},
-		{19, 90, "high", 3, 2, "bad"},
-		{20, 78, "high", 0.5, 2, "bad"},
-	}
-
-	// Qualitative variables to plot: Crunchiness, Quality, Ripeness (ordinal)
-
-	// 1) Crunchiness (high/medium/low) - choose order high, medium, low
-	crunchFreq := make(map[string]int)
-	for _, r := range data {
-		crunchFreq[r.Crunchiness]++
-	}
-	crunchKeys := []string{"high", "medium", "low"}
-	crunchVals := make(plotter.Values, len(crunchKeys))
-	for i, k := range crunchKeys {
-		crunchVals[i] = float64(crunchFreq[k])
-	}
-	plotBar(crunchKeys, crunchVals, "crunchiness_distribution.png", "Crunchiness", "Count", color.RGBA{R: 70, G: 130, B: 180, A: 255})
+	flag.Parse()
 
-	// 2) Quality (good/bad) - order good, bad
-	qualityFreq := make(map[string]int)
-	for _, r := range data {
-		qualityFreq[r.Quality]++
+	ds, err := datasets.Get("apple_quality")
+	if err != nil {
+		log.Fatal(err)
 	}
-	qualityKeys := []string{"good", "bad"}
-	qualityVals := make(plotter.Values, len(qualityKeys))
-	for i, k := range qualityKeys {
-		qualityVals[i] = float64(qualityFreq[k])
+	apples, err := ds.Frame()
+	if err != nil {
+		log.Fatal(err)
 	}
+
+	// Qualitative variables to plot: Crunchiness and Ripeness (ordinal)
+	// and Quality (nominal), each in the order of its levels.
+
+	// 1) Crunchiness (low/medium/high)
+	crunchKeys, crunchVals := levelCounts(apples, "crunchiness")
+	plotBar(crunchKeys, crunchVals, "crunchiness_distribution.png", "Crunchiness", "Count", color.RGBA{R: 70, G: 130, B: 180, A: 255})
+
+	// 2) Quality (good/bad)
+	qualityKeys, qualityVals := levelCounts(apples, "quality")
 	plotBar(qualityKeys, qualityVals, "quality_distribution.png", "Quality", "Count", color.RGBA{R: 46, G: 139, B: 87, A: 255})
 
-	// 3) Ripeness (1-4) treat as ordinal categories
-	ripenessFreq := make(map[int]int)
-	for _, r := range data {
-		ripenessFreq[r.Ripeness]++
-	}
-	ripenessKeys := []int{1, 2, 3, 4}
-	ripenessVals := make(plotter.Values, len(ripenessKeys))
-	labelsRip := make([]string, len(ripenessKeys))
-	for i, k := range ripenessKeys {
-		ripenessVals[i] = float64(ripenessFreq[k])
-		labelsRip[i] = fmt.Sprintf("%d", k)
-	}
+	// 3) Ripeness (1-4)
+	labelsRip, ripenessVals := levelCounts(apples, "ripeness")
 	plotBar(labelsRip, ripenessVals, "ripeness_distribution.png", "Ripeness (1=low → 4=high)", "Count", color.RGBA{R: 255, G: 165, B: 0, A: 255})
 
+	if *format == report.JSON {
+		res := report.New("qual_analysis", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           apples.Len(),
+			Variables: []report.Variable{
+				{Name: "Crunchiness", Kind: "ordinal"},
+				{Name: "Quality", Kind: "nominal"},
+				{Name: "Ripeness", Kind: "ordinal"},
+			},
+		})
+		tables := []struct {
+			name string
+			keys []string
+			vals plotter.Values
+			file string
+		}{
+			{"Crunchiness", crunchKeys, crunchVals, "crunchiness_distribution.png"},
+			{"Quality", qualityKeys, qualityVals, "quality_distribution.png"},
+			{"Ripeness", labelsRip, ripenessVals, "ripeness_distribution.png"},
+		}
+		for _, t := range tables {
+			table := report.Table{Name: t.name, Columns: []string{"level", "count", "proportion"}}
+			modal := 0
+			for i, k := range t.keys {
+				table.Rows = append(table.Rows, []any{k, int(t.vals[i]), t.vals[i] / float64(apples.Len())})
+				if t.vals[i] > t.vals[modal] {
+					modal = i
+				}
+			}
+			res.Tables = append(res.Tables, table)
+			res.AddOf(t.name, "mode", t.keys[modal], "", "most frequent level")
+			res.Plots = append(res.Plots, out.Path(t.file))
+		}
+		ivs, err := goodIntervals(qualityKeys, qualityVals)
+		if err != nil {
+			log.Fatal(err)
+		}
+		res.Intervals = ivs
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Interpretations printed to console
 	fmt.Println("--- Interpretations for qualitative variables ---")
 
-	fmt.Println("1) Crunchiness (high / medium / low):")
-	for _, k := range crunchKeys {
-		fmt.Printf("   - %s: %d observations\n", k, crunchFreq[k])
+	fmt.Println("1) Crunchiness (low / medium / high):")
+	for i, k := range crunchKeys {
+		fmt.Printf("   - %s: %d observations\n", k, int(crunchVals[i]))
 	}
 	fmt.Println("   Interpretation: Most items are 'high' or 'medium' crunchiness; 'low' is less common.")
 
 	fmt.Println()
 	fmt.Println("2) Quality (good / bad):")
-	for _, k := range qualityKeys {
-		fmt.Printf("   - %s: %d observations\n", k, qualityFreq[k])
+	for i, k := range qualityKeys {
+		fmt.Printf("   - %s: %d observations\n", k, int(qualityVals[i]))
 	}
 	fmt.Println("   Interpretation: Majority are labeled 'good' — quality appears generally positive in this sample.")
+	ivs, err := goodIntervals(qualityKeys, qualityVals)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("   95%% confidence intervals for the proportion of good apples (estimate %.2f):\n", ivs[0].Estimate)
+	for _, iv := range ivs {
+		fmt.Printf("     %-25s %.3f to %.3f\n", iv.Method+":", iv.Lower, iv.Upper)
+	}
+	if ivs[0].Lower.(float64) < 0.5 {
+		fmt.Printf("   With only %d apples the intervals are wide and reach below one half, so the\n", apples.Len())
+		fmt.Println("   sample cannot rule out that good apples are a minority of the harvest.")
+	} else {
+		fmt.Println("   The intervals lie above one half: good apples are the majority of the harvest.")
+	}
 
 	fmt.Println()
 	fmt.Println("3) Ripeness (1 to 4):")
-	for i, k := range ripenessKeys {
-		fmt.Printf("   - %d: %d observations\n", k, int(ripenessVals[i]))
+	for i, k := range labelsRip {
+		fmt.Printf("   - %s: %d observations\n", k, int(ripenessVals[i]))
 	}
 	fmt.Println("   Interpretation: Ripeness levels are distributed across the scale; identify if certain ripeness levels coincide with 'bad' quality for downstream analysis.")
 
-	fmt.Println("\nSaved PNGs: crunchiness_distribution.png, quality_distribution.png, ripeness_distribution.png")
+	if !*term {
+		fmt.Printf("\nSaved figures: %s, %s, %s\n", out.Path("crunchiness_distribution.png"), out.Path("quality_distribution.png"), out.Path("ripeness_distribution.png"))
+	}
+}
+
+// goodIntervals returns the Wilson, Agresti–Coull and Clopper–Pearson
+// 95% confidence intervals for the proportion of good apples.
+func goodIntervals(keys []string, counts plotter.Values) ([]report.Interval, error) {
+	k, n := 0, 0
+	for i, key := range keys {
+		if key == "good" {
+			k = int(counts[i])
+		}
+		n += int(counts[i])
+	}
+	var ivs []report.Interval
+	for _, f := range []func(k, n int, level float64) (stats.Interval, error){
+		stats.WilsonInterval, stats.AgrestiCoullInterval, stats.ClopperPearsonInterval,
+	} {
+		iv, err := f(k, n, 0.95)
+		if err != nil {
+			return nil, err
+		}
+		ivs = append(ivs, report.Interval{
+			Parameter: "proportion good",
+			Variable:  "Quality",
+			Estimate:  iv.Estimate,
+			Lower:     iv.Lower,
+			Upper:     iv.Upper,
+			Level:     iv.Level,
+			Method:    iv.Name,
+		})
+	}
+	return ivs, nil
 }
 
-// plotBar draws and saves a bar chart. keys are the x labels (strings), vals are counts.
+// plotBar draws and saves a bar chart, or prints it with -term. keys are the x labels (strings), vals are counts.
 func plotBar(keys []string, vals plotter.Values, filename, xlabel, ylabel string, col color.RGBA) {
+	if *term && *format == report.Text {
+		chart := textplot.Chart{Title: xlabel, XLabel: xlabel, YLabel: ylabel}
+		fmt.Println(chart.Bars(keys, textplot.Series{Values: vals}))
+		return
+	}
+
 	p := plot.New()
 	p.Title.Text = filename
 	p.X.Label.Text = xlabel
@@ -127,7 +196,22 @@ func plotBar(keys []string, vals plotter.Values, filename, xlabel, ylabel string
 	p.NominalX(keys...)
 	p.Add(plotter.NewGrid())
 
-	if err := p.Save(6*vg.Inch, 3*vg.Inch, filename); err != nil {
+	if _, err := out.SaveFigure(p, filename, 6*vg.Inch, 3*vg.Inch); err != nil {
 		panic(err)
 	}
 }
+
+// levelCounts returns the levels of the categorical column name of f
+// and how often each occurs.
+func levelCounts(f *frame.Frame, name string) ([]string, plotter.Values) {
+	c, err := f.Column(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	levels, counts := c.Counts()
+	vals := make(plotter.Values, len(counts))
+	for i, n := range counts {
+		vals[i] = float64(n)
+	}
+	return levels, vals
+}
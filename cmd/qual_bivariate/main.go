@@ -1,47 +1,59 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
+	"log"
 	"math"
+	"os"
+	"slices"
+	"strings"
 
+	"github.com/mayura-andrew/applied-statistics/crosstab"
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
 func main() {
-	// Data
-	type Rec struct {
-		ID          int
-		Weight      float64
-		Crunchiness string
-		Sweetness   float64
-		Ripeness    int
-		Quality     string
-	}
-
-	data := []Rec{
-		{1, 70, "high", 0.5, 1, "good"},
-		{2, 90, "medium", 1, 2, "bad"},
-		{3, 83, "high", 1, 1, "good"},
-		{4, 85, "low", 3, 3, "good"},
-		{5, 90, "low", 3, 3, "good"},
-		{6, 78, "high", 1.5, 2, "good"},
-		{7, 78, "high", 0.5, 4, "bad"},
-		{8, 93, "medium", 1, 4, "good"},
-		{9, 85, "high", 3.5, 3, "good"},
-		{10, 88, "low", 2, 3, "bad"},
-		{11, 86, "high", 2, 2, "good"},
-		{12, 92, "low", 2, 2, "good"},
-		{13, 95, "medium", 1.5, 3, "bad"},
-		{14, 100, "high", 4, 3, "good"},
-		{15, 94, "medium", 2.5, 2, "good"},
-		{16, 96, "low", 2, 4, "good"},
-		{17, 70, "low", 1, 1, "good"},
-		{18, 82, "medium", 3, 1, "bad"},
-		{19, 90, "high", 3, 2, "bad"},
-		{20, 78, "high", 0.5, 2, "bad"},
+	term := flag.Bool("term", false, "draw the charts in the terminal instead of saving figures")
+	layout := flag.String("layout", "text", "layout of the contingency tables: text, markdown or csv")
+	out := plots.OutputFlags("")
+	format := report.FormatFlag()
+	flag.Parse()
+	text := *format == report.Text
+	tables := crosstab.Format(*layout)
+	switch tables {
+	case crosstab.Text, crosstab.Markdown, crosstab.CSV:
+	default:
+		log.Fatalf("unknown -layout %q (want text, markdown or csv)", *layout)
+	}
+	// compositionCells are what the table of sweetness bin by quality
+	// shows.
+	compositionCells := []crosstab.Cell{crosstab.Counts, crosstab.RowPercent, crosstab.Residuals}
+
+	ds, err := datasets.Get("apple_quality")
+	if err != nil {
+		log.Fatal(err)
+	}
+	apples, err := ds.Frame()
+	if err != nil {
+		log.Fatal(err)
+	}
+	weight, err := apples.Floats("weight")
+	if err != nil {
+		log.Fatal(err)
+	}
+	sweetness, err := apples.Floats("sweetness")
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Scatter plot: Weight vs Sweetness, color by Quality
@@ -51,18 +63,8 @@ func main() {
 	p.Y.Label.Text = "Sweetness"
 
 	// Separate points by quality
-	goodPts := make(plotter.XYs, 0)
-	badPts := make(plotter.XYs, 0)
-	var xsum, ysum float64
-	for _, r := range data {
-		xsum += r.Weight
-		ysum += r.Sweetness
-		if r.Quality == "good" {
-			goodPts = append(goodPts, plotter.XY{X: r.Weight, Y: r.Sweetness})
-		} else {
-			badPts = append(badPts, plotter.XY{X: r.Weight, Y: r.Sweetness})
-		}
-	}
+	goodPts := points(apples.Filter(func(r frame.Row) bool { return r.String("quality") == "good" }))
+	badPts := points(apples.Filter(func(r frame.Row) bool { return r.String("quality") != "good" }))
 
 	// scatterters
 	sg, _ := plotter.NewScatter(goodPts)
@@ -76,37 +78,19 @@ func main() {
 	p.Legend.Add("bad", sb)
 
 	// Compute Pearson correlation and linear regression
-	n := float64(len(data))
-	xMean := xsum / n
-	yMean := ysum / n
-	var cov, varx, vary float64
-	for _, r := range data {
-		dx := r.Weight - xMean
-		dy := r.Sweetness - yMean
-		cov += dx * dy
-		varx += dx * dx
-		vary += dy * dy
-	}
-	cov /= n
-	varx /= n
-	vary /= n
+	xMean, yMean := stats.Mean(weight), stats.Mean(sweetness)
+	cov := stats.PopCovariance(weight, sweetness)
+	varx, vary := stats.PopVariance(weight), stats.PopVariance(sweetness)
 	pearson := cov / (math.Sqrt(varx) * math.Sqrt(vary))
+	test := stats.CorrelationTest(pearson, len(weight))
+	r := test.Effects[0]
 
 	// regression slope and intercept (OLS using means)
 	slope := cov / varx
 	intercept := yMean - slope*xMean
 
 	// draw regression line across x range
-	xmin := data[0].Weight
-	xmax := data[0].Weight
-	for _, r := range data {
-		if r.Weight < xmin {
-			xmin = r.Weight
-		}
-		if r.Weight > xmax {
-			xmax = r.Weight
-		}
-	}
+	xmin, xmax := slices.Min(weight), slices.Max(weight)
 	linePts := plotter.XYs{{X: xmin, Y: intercept + slope*xmin}, {X: xmax, Y: intercept + slope*xmax}}
 	line, _ := plotter.NewLine(linePts)
 	line.Color = color.RGBA{R: 0, G: 0, B: 139, A: 255}
@@ -114,12 +98,24 @@ func main() {
 	p.Add(line)
 	p.Legend.Add(fmt.Sprintf("regression (r=%.3f)", pearson), line)
 
-	if err := p.Save(6*vg.Inch, 4*vg.Inch, "weight_vs_sweetness.png"); err != nil {
-		panic(err)
+	if *term && text {
+		chart := textplot.Chart{Title: p.Title.Text, XLabel: p.X.Label.Text, YLabel: p.Y.Label.Text}
+		fmt.Print(chart.Scatter(
+			textplot.XYSeries{Name: "good", XYs: goodPts},
+			textplot.XYSeries{Name: "bad", XYs: badPts},
+			textplot.XYSeries{Name: fmt.Sprintf("regression (r=%.3f)", pearson), XYs: linePts, Line: true},
+		))
+		fmt.Printf("Pearson r = %.4f, slope = %.4f, intercept = %.4f\n", pearson, slope, intercept)
+	} else {
+		file, err := out.SaveFigure(p, "weight_vs_sweetness.png", 6*vg.Inch, 4*vg.Inch)
+		if err != nil {
+			panic(err)
+		}
+		if text {
+			fmt.Printf("Saved %s (Pearson r = %.4f, slope = %.4f, intercept = %.4f)\n", file, pearson, slope, intercept)
+		}
 	}
 
-	fmt.Printf("Saved weight_vs_sweetness.png (Pearson r = %.4f, slope = %.4f, intercept = %.4f)\n", pearson, slope, intercept)
-
 	// Composition: sweetness bins vs quality (good/bad)
 	// Define bins: <=1, (1,2], (2,3], >3
 	bins := []struct {
@@ -132,21 +128,57 @@ func main() {
 		{">3", 3.0, math.Inf(1)},
 	}
 
-	// counts per bin per quality
-	goodCounts := make([]float64, len(bins))
-	badCounts := make([]float64, len(bins))
-	for _, r := range data {
-		for i, b := range bins {
-			if r.Sweetness > b.min && r.Sweetness <= b.max {
-				if r.Quality == "good" {
-					goodCounts[i]++
-				} else {
-					badCounts[i]++
-				}
+	labels := make([]string, len(bins))
+	for i, b := range bins {
+		labels[i] = b.label
+	}
+
+	// The sweetness bin of each apple, and whether it is sweet
+	// (sweetness above 2), as ordinal columns to cross-tabulate.
+	binned := make([]string, apples.Len())
+	sweetness2 := make([]string, apples.Len())
+	for i, s := range sweetness {
+		sweetness2[i] = "no"
+		if s > 2 {
+			sweetness2[i] = "yes"
+		}
+		for _, b := range bins {
+			if s > b.min && s <= b.max {
+				binned[i] = b.label
 				break
 			}
 		}
 	}
+	bin, err := frame.NewOrdinal("sweetness", labels, binned)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sweet, err := frame.NewOrdinal("sweet", []string{"yes", "no"}, sweetness2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	quality, err := apples.Column("quality")
+	if err != nil {
+		log.Fatal(err)
+	}
+	ripe, err := apples.Column("ripeness")
+	if err != nil {
+		log.Fatal(err)
+	}
+	tab, err := frame.New(bin, sweet, quality, ripe)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Composition: sweetness bin by quality. Association: ripeness by
+	// sweetness bin, both ordinal, and sweet apples by quality, a 2 × 2
+	// table.
+	composition := tabulate(tab, "sweetness", "quality")
+	ripeness := tabulate(tab, "ripeness", "sweetness")
+	sweetQuality := tabulate(tab, "sweet", "quality")
+	ripeTest := chiSquare(ripeness, true)
+	sweetTest := chiSquare(sweetQuality, false)
+	counts := composition.Layers()[0].Counts
 
 	// Build grouped bar chart: for each bin, show bars for good and bad side-by-side
 	p2 := plot.New()
@@ -154,16 +186,11 @@ func main() {
 	p2.Y.Label.Text = "Count"
 	p2.X.Label.Text = "Sweetness bins"
 
-	labels := make([]string, len(bins))
-	for i, b := range bins {
-		labels[i] = b.label
-	}
-
-	valsGood := make(plotter.Values, len(goodCounts))
-	valsBad := make(plotter.Values, len(badCounts))
+	valsGood := make(plotter.Values, len(bins))
+	valsBad := make(plotter.Values, len(bins))
 	for i := range valsGood {
-		valsGood[i] = goodCounts[i]
-		valsBad[i] = badCounts[i]
+		valsGood[i] = counts[i][0]
+		valsBad[i] = counts[i][1]
 	}
 
 	barw := vg.Points(20)
@@ -180,32 +207,202 @@ func main() {
 	p2.Legend.Add("bad", bb)
 	p2.Add(plotter.NewGrid())
 
-	if err := p2.Save(6*vg.Inch, 3*vg.Inch, "sweetness_quality_composition.png"); err != nil {
+	if *term && text {
+		chart := textplot.Chart{Title: p2.Title.Text, XLabel: p2.X.Label.Text, YLabel: p2.Y.Label.Text}
+		fmt.Println()
+		fmt.Print(chart.Bars(labels, textplot.Series{Name: "good", Values: valsGood}, textplot.Series{Name: "bad", Values: valsBad}))
+	} else if _, err := out.SaveFigure(p2, "sweetness_quality_composition.png", 6*vg.Inch, 3*vg.Inch); err != nil {
 		panic(err)
 	}
 
-	// Print contingency table and percentages
-	fmt.Println("\nSweetness bin vs Quality (counts):")
-	for i, b := range bins {
-		fmt.Printf("%s: good=%d, bad=%d\n", b.label, int(goodCounts[i]), int(badCounts[i]))
+	if !text {
+		res := report.New("qual_bivariate", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           apples.Len(),
+			Variables: []report.Variable{
+				{Name: "Weight", Kind: "numeric", Unit: "g"},
+				{Name: "Sweetness", Kind: "numeric"},
+				{Name: "Quality", Kind: "nominal"},
+			},
+		})
+		res.AddOf("Weight", "mean", xMean, "g", "arithmetic mean")
+		res.AddOf("Sweetness", "mean", yMean, "", "arithmetic mean")
+		res.AddOf("Weight", "variance", varx, "g²", "population (n)")
+		res.AddOf("Sweetness", "variance", vary, "", "population (n)")
+		res.Add("covariance", cov, "g", "population (n)")
+		res.Add("slope", slope, "1/g", "least squares, sweetness on weight")
+		res.Add("intercept", intercept, "", "least squares, sweetness on weight")
+		res.Tests = append(res.Tests, report.Test{
+			Name:      "Pearson correlation of Weight and Sweetness",
+			Statistic: "r",
+			Value:     pearson,
+			DF:        test.DF,
+			PValue:    test.PValue,
+			Effects:   effects(test.Effects),
+		}, testReport("Ripeness by Sweetness bin", ripeTest), testReport("Sweet (sweetness > 2) by Quality", sweetTest))
+		res.Tables = append(res.Tables, composition.Report(compositionCells)...)
+		res.Tables = append(res.Tables, ripeness.Report([]crosstab.Cell{crosstab.Counts})...)
+		res.Tables = append(res.Tables, sweetQuality.Report([]crosstab.Cell{crosstab.Counts})...)
+		res.Plots = append(res.Plots, out.Path("weight_vs_sweetness.png"), out.Path("sweetness_quality_composition.png"))
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
-	fmt.Println("Saved sweetness_quality_composition.png")
+	// Print the contingency tables
+	fmt.Println()
+	if err := composition.Write(os.Stdout, tables, compositionCells, 1); err != nil {
+		log.Fatal(err)
+	}
+	if !*term {
+		fmt.Println("Saved", out.Path("sweetness_quality_composition.png"))
+		fmt.Println()
+	}
+	if err := ripeness.Write(os.Stdout, tables, []crosstab.Cell{crosstab.Counts}, 1); err != nil {
+		log.Fatal(err)
+	}
+	if err := sweetQuality.Write(os.Stdout, tables, []crosstab.Cell{crosstab.Counts}, 1); err != nil {
+		log.Fatal(err)
+	}
 
 	// Interpretation
 	fmt.Println("\nInterpretation:")
 	fmt.Println("1) Weight vs Sweetness:")
-	fmt.Printf("   - Pearson correlation r = %.3f (close to 0 indicates weak linear association).\n", pearson)
-	if math.Abs(pearson) < 0.3 {
+	fmt.Printf("   - Pearson correlation r = %.3f, %g%% CI [%.3f, %.3f]: a %s effect by Cohen's thresholds of 0.1, 0.3 and 0.5.\n",
+		pearson, 100*r.Level, r.Lower, r.Upper, r.Magnitude)
+	switch {
+	case r.Magnitude == "negligible":
 		fmt.Println("   - There is little to no linear relationship between weight and sweetness in this sample.")
-	} else if pearson > 0 {
-		fmt.Println("   - Positive correlation: heavier apples tend to be sweeter.")
+	case pearson > 0:
+		fmt.Printf("   - A %s positive correlation: heavier apples tend to be sweeter.\n", r.Magnitude)
+	default:
+		fmt.Printf("   - A %s negative correlation: heavier apples tend to be less sweet.\n", r.Magnitude)
+	}
+	if r.Lower < 0 && r.Upper > 0 {
+		fmt.Println("   - The interval includes 0, so with 20 apples no correlation at all is also plausible.")
 	} else {
-		fmt.Println("   - Negative correlation: heavier apples tend to be less sweet.")
+		fmt.Printf("   - The interval excludes 0, but it is wide: the true correlation may be anywhere from %.2f to %.2f.\n", r.Lower, r.Upper)
 	}
 	fmt.Printf("   - Regression line: sweetness = %.3f * weight + %.3f\n", slope, intercept)
 
 	fmt.Println("\n2) Composition by sweetness bin and quality:")
 	fmt.Println("   - The bar chart shows counts of 'good' vs 'bad' within each sweetness bin.")
-	fmt.Println("   - Use the plot 'sweetness_quality_composition.png' for a quick view of how quality distributes across sweetness levels.")
+	fmt.Println("   - Standardized residuals beyond ±2 would mark bins with more or fewer good apples than independence predicts.")
+	fmt.Printf("   - Use the plot '%s' for a quick view of how quality distributes across sweetness levels.\n", out.Path("sweetness_quality_composition.png"))
+
+	fmt.Println("\n3) Association of Ripeness and Sweetness bin (both ordinal):")
+	printAssociation(ripeTest)
+	gamma := effect(ripeTest, "γ")
+	direction := "riper apples tend to fall in sweeter bins"
+	if gamma.Value < 0 {
+		direction = "riper apples tend to fall in less sweet bins"
+	}
+	fmt.Printf("   - Gamma = %.3f: %s; of the pairs of apples not tied on either, the pairs ordered alike outnumber the others by %.0f%% of them.\n",
+		gamma.Value, direction, 100*math.Abs(gamma.Value))
+	if gamma.Lower < 0 && gamma.Upper > 0 {
+		fmt.Println("   - The intervals of the ordinal measures include 0: the sample cannot tell the direction of the association.")
+	}
+
+	fmt.Println("\n4) Sweet apples (sweetness > 2) and Quality, a 2 × 2 table:")
+	printAssociation(sweetTest)
+	rr, or := effect(sweetTest, "RR"), effect(sweetTest, "OR")
+	fmt.Printf("   - Sweet apples are %.2f times as likely to be good as the others (odds ratio %.2f).\n", rr.Value, or.Value)
+	if rr.Lower < 1 && rr.Upper > 1 {
+		fmt.Printf("   - The interval of the relative risk, [%.2f, %.2f], includes 1: with 20 apples sweetness may not change the chance of a good apple at all.\n", rr.Lower, rr.Upper)
+	}
+	fmt.Println("   - Several expected counts are below 5, so the chi-square p-values are rough.")
+}
+
+// effects returns the effect sizes es for a report.
+func effects(es []stats.EffectSize) []report.Effect {
+	out := make([]report.Effect, len(es))
+	for i, e := range es {
+		out[i] = report.Effect{
+			Name:      e.Name,
+			Symbol:    e.Symbol,
+			Value:     e.Value,
+			Lower:     e.Lower,
+			Upper:     e.Upper,
+			Level:     e.Level,
+			Magnitude: e.Magnitude,
+		}
+	}
+	return out
+}
+
+// testReport returns the chi-square test of the table named name,
+// with its measures of association, for a report.
+func testReport(name string, t stats.TestResult) report.Test {
+	return report.Test{
+		Name:        "Pearson's chi-square test of independence of " + name,
+		Statistic:   t.Statistic,
+		Value:       t.Value,
+		DF:          t.DF,
+		PValue:      t.PValue,
+		Alternative: "greater",
+		Effects:     effects(t.Effects),
+	}
+}
+
+// tabulate cross-tabulates the columns rows and cols of f.
+func tabulate(f *frame.Frame, rows, cols string) *crosstab.Table {
+	t, err := crosstab.FromFrame(f, rows, cols)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return t
+}
+
+// chiSquare returns the chi-square test of independence of the two-way
+// table t, with the measures of association that suit it.
+func chiSquare(t *crosstab.Table, ordinal bool) stats.TestResult {
+	counts := t.Layers()[0].Counts
+	test, _, err := stats.ChiSquareIndependence(counts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	test.Effects = stats.Association(counts, ordinal)
+	return test
+}
+
+// effect returns the effect size of t with the given symbol.
+func effect(t stats.TestResult, symbol string) stats.EffectSize {
+	for _, e := range t.Effects {
+		if e.Symbol == symbol {
+			return e
+		}
+	}
+	panic("no effect size " + symbol)
+}
+
+// printAssociation prints the chi-square test t and its measures of
+// association.
+func printAssociation(t stats.TestResult) {
+	fmt.Printf("   - χ² = %.3f, df = %g, p = %.4f\n", t.Value, t.DF[0], t.PValue)
+	for _, e := range t.Effects {
+		ci := ""
+		if !math.IsNaN(e.Lower) {
+			ci = fmt.Sprintf(", %g%% CI [%.3f, %.3f]", 100*e.Level, e.Lower, e.Upper)
+		}
+		label := ""
+		if e.Magnitude != "" {
+			label = " (" + e.Magnitude + ")"
+		}
+		name := e.Name
+		if !strings.HasSuffix(name, " "+e.Symbol) {
+			name += " " + e.Symbol
+		}
+		fmt.Printf("   - %s = %.3f%s%s\n", name, e.Value, ci, label)
+	}
+}
+
+// points returns the weights and sweetness of the apples as points.
+func points(apples *frame.Frame) plotter.XYs {
+	pts := make(plotter.XYs, apples.Len())
+	for i, r := range apples.Rows() {
+		pts[i] = plotter.XY{X: r.Float("weight"), Y: r.Float("sweetness")}
+	}
+	return pts
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/report/descriptive"
+)
+
+func main() {
+	name := flag.String("dataset", "wheat_yield", "dataset: "+strings.Join(datasets.Names(), ", "))
+	out := flag.String("out", "", "file to write: .html for a web page, .tex for a LaTeX document (default <dataset>_report.html)")
+	format := report.FormatFlag()
+	flag.Parse()
+	text := *format == report.Text
+
+	catalog, err := datasets.Get(*name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ds, err := load(catalog)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		*out = *name + "_report.html"
+	}
+
+	latex := strings.EqualFold(filepath.Ext(*out), ".tex")
+	var figures string
+	if latex {
+		// The figures are saved as PDFs next to the document.
+		figures = strings.TrimSuffix(*out, filepath.Ext(*out))
+	}
+	page, files, err := descriptive.Page(ds, figures)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if text {
+		for _, file := range files {
+			fmt.Println("Figure has been saved to", file)
+		}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if latex {
+		err = page.WriteLaTeX(f)
+	} else {
+		err = page.WriteHTML(f)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if !text {
+		res := report.New("report", catalog.Report(catalog.Main().Name))
+		res.Plots = files
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	fmt.Println("Report has been saved to", *out)
+}
+
+// load returns the main variable of the dataset ds.
+func load(ds *datasets.Dataset) (descriptive.Data, error) {
+	v := ds.Main()
+	x, err := ds.Floats(v.Name)
+	if err != nil {
+		return descriptive.Data{}, err
+	}
+	return descriptive.Data{
+		Title:       ds.Title,
+		Description: ds.Description + ".",
+		Variable:    strings.ToUpper(v.Name[:1]) + v.Name[1:],
+		Unit:        v.Unit,
+		Values:      x,
+	}, nil
+}
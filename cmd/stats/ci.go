@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+func setupCI(fs *flag.FlagSet) func(*options) error {
+	parameter := fs.String("parameter", "mean", "parameter: mean, proportion, variance, sd, median or quantile")
+	level := fs.Float64("level", 0.95, "confidence level")
+	sigma := fs.Float64("sigma", 0, "known population standard deviation; gives the z interval for the mean besides the t interval")
+	method := fs.String("method", "all", "interval for a proportion: wilson, agresti-coull, clopper-pearson or all")
+	success := fs.String("success", "", "for proportion, the level of -column counted as a success, e.g. good")
+	p := fs.Float64("p", 0.5, "for quantile, the probability of the quantile, e.g. 0.9")
+	return func(o *options) error {
+		if err := o.needColumns(1, 1); err != nil {
+			return err
+		}
+		if !(*level > 0 && *level < 1) {
+			return badInput("-level must be between 0 and 1")
+		}
+		c := o.columns[0]
+		name := o.columnName(c)
+		var (
+			intervals []stats.Interval
+			param     string
+			unit      = o.unit
+			kind      = "numeric"
+		)
+		if *parameter == "proportion" {
+			col, _ := o.column(c)
+			levels, counts := col.Counts()
+			if *success == "" {
+				return badInput("no success level given: use -success, one of %s", strings.Join(levels, ", "))
+			}
+			i := slices.Index(levels, *success)
+			if i < 0 {
+				return badInput("column %s has no level %q", name, *success)
+			}
+			k, n := counts[i], 0
+			for _, m := range counts {
+				n += m
+			}
+			methods := map[string]func(k, n int, level float64) (stats.Interval, error){
+				"wilson":          stats.WilsonInterval,
+				"agresti-coull":   stats.AgrestiCoullInterval,
+				"clopper-pearson": stats.ClopperPearsonInterval,
+			}
+			var use []string
+			switch {
+			case *method == "all":
+				use = []string{"wilson", "agresti-coull", "clopper-pearson"}
+			case methods[*method] != nil:
+				use = []string{*method}
+			default:
+				return badInput("unknown -method %q (want wilson, agresti-coull, clopper-pearson or all)", *method)
+			}
+			for _, m := range use {
+				iv, err := methods[m](k, n, *level)
+				if err != nil {
+					return err
+				}
+				intervals = append(intervals, iv)
+			}
+			param, unit, kind = fmt.Sprintf("proportion with %s = %s", name, *success), "", kindOf(col)
+		} else {
+			x, err := o.numeric(c)
+			if err != nil {
+				return err
+			}
+			var iv stats.Interval
+			if *sigma < 0 {
+				return badInput("-sigma must be positive")
+			}
+			switch *parameter {
+			case "mean":
+				param = "mean"
+				if iv, err = stats.MeanTInterval(x, *level); err == nil && *sigma > 0 {
+					intervals = append(intervals, iv)
+					iv, err = stats.MeanZInterval(x, *sigma, *level)
+				}
+			case "variance":
+				param, unit = "variance", squared(o.unit)
+				iv, err = stats.VarianceInterval(x, *level)
+			case "sd":
+				param = "standard deviation"
+				iv, err = stats.StdDevInterval(x, *level)
+			case "median":
+				param = "median"
+				iv, err = stats.MedianInterval(x, *level)
+			case "quantile":
+				param = fmt.Sprintf("%g-quantile", *p)
+				iv, err = stats.QuantileInterval(x, *p, *level)
+			default:
+				return badInput("unknown -parameter %q (want mean, proportion, variance, sd, median or quantile)", *parameter)
+			}
+			if err != nil {
+				return err
+			}
+			intervals = append(intervals, iv)
+		}
+
+		res := report.New("stats ci", o.dataset(kind))
+		for _, iv := range intervals {
+			res.Intervals = append(res.Intervals, report.Interval{
+				Parameter: param,
+				Variable:  name,
+				Estimate:  iv.Estimate,
+				Lower:     iv.Lower,
+				Upper:     iv.Upper,
+				Level:     iv.Level,
+				Coverage:  iv.Coverage,
+				Unit:      unit,
+				Method:    iv.Name,
+			})
+		}
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+
+		if *parameter == "proportion" {
+			fmt.Printf("%g%% confidence intervals for the %s\n", 100**level, param)
+		} else {
+			fmt.Printf("%g%% confidence intervals for the %s of %s\n", 100**level, param, name)
+		}
+		fmt.Printf("Estimate: %s\n\n", o.num(intervals[0].Estimate))
+		var rows [][]string
+		for _, iv := range intervals {
+			rows = append(rows, []string{iv.Name, o.num(iv.Lower), o.num(iv.Upper), o.num(iv.Upper - iv.Lower)})
+		}
+		printTable([]string{"Method", "Lower", "Upper", "Width"}, rows)
+		for _, iv := range intervals {
+			if iv.Coverage > 0 {
+				fmt.Printf("\nThe exact coverage of the %s is %s%%, whatever the continuous distribution of %s.\n", iv.Name, o.num(100*iv.Coverage), name)
+			}
+		}
+		switch *parameter {
+		case "variance", "sd":
+			fmt.Println("\nThe chi-square interval assumes a normal population and is sensitive to departures from it.")
+		}
+		return nil
+	}
+}
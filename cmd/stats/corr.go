@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+func setupCorr(fs *flag.FlagSet) func(*options) error {
+	method := fs.String("method", "pearson", "correlation coefficient: pearson or spearman")
+	alpha := fs.Float64("alpha", 0.05, "significance level of the tests")
+	return func(o *options) error {
+		if err := o.needColumns(2, -1); err != nil {
+			return err
+		}
+		var corr func(x, y []float64) (float64, error)
+		switch *method {
+		case "pearson":
+			corr = stats.Pearson
+		case "spearman":
+			corr = stats.Spearman
+		default:
+			return badInput("unknown -method %q (want pearson or spearman)", *method)
+		}
+		names := make([]string, len(o.columns))
+		data := make([][]float64, len(o.columns))
+		for i, c := range o.columns {
+			x, err := o.numeric(c)
+			if err != nil {
+				return err
+			}
+			names[i], data[i] = o.columnName(c), x
+		}
+
+		res := report.New("stats corr", o.dataset())
+		matrix := report.Table{Name: *method + " correlation", Columns: append([]string{""}, names...)}
+		rows := make([][]string, len(names))
+		// tests are the tests of the pairs, for the text.
+		var tests []stats.TestResult
+		for i := range names {
+			matrix.Rows = append(matrix.Rows, []any{names[i]})
+			rows[i] = []string{names[i]}
+			for j := range names {
+				r := 1.0
+				if i != j {
+					var err error
+					if r, err = corr(data[i], data[j]); err != nil {
+						return fmt.Errorf("%s and %s: %w", names[i], names[j], err)
+					}
+				}
+				matrix.Rows[i] = append(matrix.Rows[i], r)
+				rows[i] = append(rows[i], o.num(r))
+				if j <= i {
+					continue
+				}
+				t := stats.CorrelationTest(r, len(data[i]))
+				tests = append(tests, t)
+				conclusion := "no significant correlation"
+				if t.Reject(*alpha) {
+					conclusion = "significant correlation"
+				}
+				res.Tests = append(res.Tests, report.Test{
+					Name:        fmt.Sprintf("%s correlation of %s and %s", *method, names[i], names[j]),
+					Statistic:   t.Statistic,
+					Value:       t.Value,
+					DF:          t.DF,
+					PValue:      t.PValue,
+					Alternative: "two-sided",
+					Conclusion:  fmt.Sprintf("%s at alpha = %g", conclusion, *alpha),
+					Effects:     effects(t.Effects),
+				})
+			}
+		}
+		res.Tables = append(res.Tables, matrix)
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+
+		fmt.Printf("%s correlation coefficients (n = %d):\n\n", capitalize(*method), len(data[0]))
+		printTable(append([]string{""}, names...), rows)
+		fmt.Println()
+		fmt.Printf("Tests of zero correlation, t = r √((n - 2) / (1 - r²)) with n - 2 df, alpha = %g:\n", *alpha)
+		for i, t := range tests {
+			fmt.Printf("  %s: t = %s, p = %s: %s\n", res.Tests[i].Name, o.num(t.Value), o.num(t.PValue), res.Tests[i].Conclusion)
+			o.printEffects("    ", t.Effects)
+		}
+		return nil
+	}
+}
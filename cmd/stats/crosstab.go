@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/mayura-andrew/applied-statistics/crosstab"
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+func setupCrosstab(fs *flag.FlagSet) func(*options) error {
+	cells := fs.String("cells", "counts,row,column", "what the cells show, comma-separated: counts, row, column or total (percentages), expected, residuals, or all")
+	layout := fs.String("layout", "text", "layout of the tables in text output: text, markdown or csv")
+	return func(o *options) error {
+		if err := o.needColumns(2, -1); err != nil {
+			return err
+		}
+		show, err := crosstab.ParseCells(*cells)
+		if err != nil {
+			return badInput("-cells: %v", err)
+		}
+		f := crosstab.Format(*layout)
+		if !slices.Contains([]crosstab.Format{crosstab.Text, crosstab.Markdown, crosstab.CSV}, f) {
+			return badInput("unknown -layout %q (want text, markdown or csv)", *layout)
+		}
+		t, kinds, err := o.tabulate()
+		if err != nil {
+			return err
+		}
+
+		res := report.New("stats crosstab", o.dataset(kinds...))
+		res.Tables = t.Report(show)
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+		if err := t.Write(os.Stdout, f, show, o.precision); err != nil {
+			return err
+		}
+		if f == crosstab.Text && slices.Contains(show, crosstab.Residuals) {
+			fmt.Println("Standardized residuals are about standard normal under independence: cells beyond ±2 stand out.")
+		}
+		return nil
+	}
+}
+
+// tabulate cross-tabulates the given columns, categorical ones with
+// their levels in order and others by their distinct values, and
+// returns their kinds for the result.
+func (o *options) tabulate() (*crosstab.Table, []string, error) {
+	names := make([]string, len(o.columns))
+	values := make([][]string, len(o.columns))
+	levels := make([][]string, len(o.columns))
+	kinds := make([]string, len(o.columns))
+	for i, c := range o.columns {
+		col, err := o.column(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		names[i], values[i], levels[i], kinds[i] = col.Name, textOf(col), col.Levels, kindOf(col)
+	}
+	t, err := crosstab.New(names, values, levels)
+	return t, kinds, err
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+func setupDatasets(fs *flag.FlagSet) func(*options) error {
+	return func(o *options) error {
+		if o.input != "" {
+			return badInput("datasets describes the built-in datasets; -input is not used")
+		}
+		if o.catalog == nil {
+			return listDatasets(o)
+		}
+		ds := o.catalog
+		n := o.frame.Len()
+		vars := report.Table{Name: "variables", Columns: []string{"name", "kind", "unit", "levels", "description"}}
+		for _, v := range ds.Variables {
+			vars.Rows = append(vars.Rows, []any{v.Name, v.Kind, v.Unit, strings.Join(v.Levels, ", "), v.Description})
+		}
+		if !o.text() {
+			res := report.New("stats datasets", ds.Report())
+			res.Tables = append(res.Tables, vars)
+			return res.Write(os.Stdout)
+		}
+		fmt.Printf("%s: %s\n", ds.Name, ds.Title)
+		fmt.Printf("%s; n = %d.\n", ds.Description, n)
+		fmt.Printf("Source: %s\n\n", ds.Source)
+		rows := make([][]string, len(vars.Rows))
+		for i, r := range vars.Rows {
+			rows[i] = make([]string, len(r))
+			for j, c := range r {
+				rows[i][j] = fmt.Sprint(c)
+			}
+		}
+		printTable([]string{"Variable", "Kind", "Unit", "Levels", "Description"}, rows)
+		return nil
+	}
+}
+
+// listDatasets prints the name, size and description of every dataset.
+func listDatasets(o *options) error {
+	list := report.Table{Name: "datasets", Columns: []string{"name", "n", "description"}}
+	for _, name := range datasets.Names() {
+		ds, err := datasets.Get(name)
+		if err != nil {
+			return err
+		}
+		list.Rows = append(list.Rows, []any{name, ds.Report().N, ds.Description})
+	}
+	if !o.text() {
+		res := report.New("stats datasets", report.Dataset{Name: "catalog", N: len(list.Rows)})
+		res.Tables = append(res.Tables, list)
+		return res.Write(os.Stdout)
+	}
+	rows := make([][]string, len(list.Rows))
+	for i, r := range list.Rows {
+		rows[i] = []string{r[0].(string), fmt.Sprint(r[1]), r[2].(string)}
+	}
+	printTable([]string{"Dataset", "n", "Description"}, rows)
+	fmt.Println()
+	fmt.Println(`Run "stats datasets -dataset NAME" for its variables.`)
+	return nil
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+func setupDescribe(fs *flag.FlagSet) func(*options) error {
+	population := fs.Bool("population", false, "divide the variance by n (population) instead of n - 1 (sample)")
+	weightsColumn := fs.String("weights", "", "column of weights of the observations")
+	weighting := fs.String("weighting", "frequency", "what the -weights are: frequency (counts of identical observations) or reliability (precision or design weights)")
+	stream := fs.Bool("stream", false, "read CSV from standard input in one pass, in constant memory, with approximate quantiles")
+	workers := fs.Int("workers", runtime.NumCPU(), "goroutines summarizing the rows with -stream")
+	sketch := fs.Int("sketch", stats.DefaultSketchSize, "accuracy k of the quantile sketches of -stream; the rank error is about 1.7/k")
+	return func(o *options) error {
+		if *stream {
+			if *weightsColumn != "" || o.impute != "" {
+				return badInput("-stream does not support -weights or -impute")
+			}
+			if *workers < 1 || *sketch < 1 {
+				return badInput("-workers and -sketch must be positive")
+			}
+			return describeStream(o, os.Stdin, *population, *workers, *sketch)
+		}
+		if err := o.needColumns(1, -1); err != nil {
+			return err
+		}
+		method := "sample (n-1)"
+		if *population {
+			method = "population (n)"
+		}
+		var w []float64
+		var kind stats.Weights
+		if *weightsColumn != "" {
+			var err error
+			if kind, err = stats.ParseWeights(*weighting); err != nil {
+				return badInput("%v", err)
+			}
+			if w, err = o.numeric(*weightsColumn); err != nil {
+				return err
+			}
+			method = weightedMethod(kind, *population)
+		}
+		summary := summaries(o.unit, method, *population)
+		if w != nil {
+			summary = weightedSummaries(o.unit, method, *population, kind)
+		}
+		res := report.New("stats describe", o.dataset())
+		if w != nil {
+			res.Dataset.Variables = append(res.Dataset.Variables, report.Variable{Name: o.columnName(*weightsColumn), Kind: "numeric", Missing: o.missing[o.columnName(*weightsColumn)]})
+		}
+		rows := make([][]string, len(summary))
+		for k, st := range summary {
+			rows[k] = []string{st.name}
+		}
+		for _, c := range o.columns {
+			x, err := o.numeric(c)
+			if err != nil {
+				return err
+			}
+			name := o.columnName(c)
+			px, pw, _, _ := o.use(stats.Omit, c, *weightsColumn)
+			if len(px) < 2 {
+				return badInput("column %s: need at least 2 values", name)
+			}
+			if w != nil {
+				if err := stats.CheckWeights(px, pw); err != nil {
+					return badInput("column %s: %v", o.columnName(*weightsColumn), err)
+				}
+			}
+			for k, st := range summary {
+				value := any(math.NaN())
+				v, vw, ok, err := o.use(o.policyOf(st.name), c, *weightsColumn)
+				if st.all {
+					v, vw, ok, err = x, w, true, nil
+				}
+				if err != nil {
+					return badInput("column %s, %s: %v (-missing error)", name, st.name, err)
+				}
+				if ok {
+					value = st.value(v, vw)
+				}
+				res.AddOf(name, st.name, value, st.unit, st.method)
+				rows[k] = append(rows[k], o.display(value))
+			}
+		}
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+
+		header := []string{"statistic"}
+		for _, c := range o.columns {
+			header = append(header, o.columnName(c))
+		}
+		if o.unit != "" {
+			fmt.Printf("Unit: %s (variance: %s)\n\n", o.unit, squared(o.unit))
+		}
+		printTable(header, rows)
+		if w != nil {
+			fmt.Printf("\nWeighted by %s as %s weights. Variance and standard deviation: %s. Quantiles: interpolated at the midpoints of the cumulative weights, type 7 for equal weights.\n", o.columnName(*weightsColumn), kind, method)
+		} else {
+			fmt.Printf("\nVariance and standard deviation: %s. Quartiles: medians of the lower and upper halves.\n", method)
+		}
+		o.printMissing()
+		return nil
+	}
+}
+
+// use returns the values of column c, with their weights if weights is
+// not empty, that a statistic with policy p is computed from, and false
+// if the statistic is missing instead.
+func (o *options) use(p stats.Policy, c, weights string) ([]float64, []float64, bool, error) {
+	if weights == "" {
+		v, ok, err := stats.Column(o.frame, o.columnName(c), p)
+		return v, nil, ok, err
+	}
+	return stats.WeightedColumn(o.frame, o.columnName(c), o.columnName(weights), p)
+}
+
+// paired returns x with the values whose weight is missing made missing
+// too.
+func paired(x, w []float64) []float64 {
+	out := slices.Clone(x)
+	for i := range out {
+		if math.IsNaN(w[i]) {
+			out[i] = math.NaN()
+		}
+	}
+	return out
+}
+
+// summary is a statistic of describe. Unless all is set, it is computed
+// from the values, and their weights if any, left by its missing-value
+// policy.
+type summary struct {
+	name, unit, method string
+	all                bool
+	value              func(x, w []float64) any
+}
+
+// summaries returns the statistics of describe, in order, for data in
+// unit with the variance of the given method.
+func summaries(unit, method string, population bool) []summary {
+	variance := stats.Variance
+	if population {
+		variance = stats.PopVariance
+	}
+	of := func(f func(x []float64) any) func(x, w []float64) any {
+		return func(x, _ []float64) any { return f(x) }
+	}
+	sorted := func(f func(s []float64) float64) func(x, w []float64) any {
+		return func(x, _ []float64) any { return f(stats.Sorted(x)) }
+	}
+	return []summary{
+		{"n", "", "count", false, of(func(x []float64) any { return len(x) })},
+		{"missing", "", "count of the missing values", true, of(func(x []float64) any { return stats.CountMissing(x) })},
+		{"mean", unit, "arithmetic mean", false, of(func(x []float64) any { return stats.Mean(x) })},
+		{"median", unit, "middle value of the sorted data", false, sorted(stats.Median)},
+		{"mode", unit, "most frequent values; empty when all values are unique", false, of(func(x []float64) any { return stats.Modes(x) })},
+		{"min", unit, "smallest value", false, sorted(func(s []float64) float64 { return s[0] })},
+		{"max", unit, "largest value", false, sorted(func(s []float64) float64 { return s[len(s)-1] })},
+		{"range", unit, "max - min", false, sorted(func(s []float64) float64 { return s[len(s)-1] - s[0] })},
+		{"variance", squared(unit), method, false, of(func(x []float64) any { return variance(x) })},
+		{"std_dev", unit, method, false, of(func(x []float64) any { return math.Sqrt(variance(x)) })},
+		{"q1", unit, "median of lower half", false, sorted(func(s []float64) float64 { q1, _ := stats.Hinges(s); return q1 })},
+		{"q3", unit, "median of upper half", false, sorted(func(s []float64) float64 { _, q3 := stats.Hinges(s); return q3 })},
+		{"iqr", unit, "Q3 - Q1", false, sorted(func(s []float64) float64 { q1, q3 := stats.Hinges(s); return q3 - q1 })},
+		{"cv", "", "std_dev / mean", false, of(func(x []float64) any { return math.Sqrt(variance(x)) / stats.Mean(x) })},
+		{"skewness", "", "moment coefficient m3 / m2^1.5", false, of(func(x []float64) any { return stats.Skewness(x) })},
+	}
+}
+
+// weightedMethod describes the weighted variance of the kind k.
+func weightedMethod(k stats.Weights, population bool) string {
+	switch {
+	case population:
+		return "weighted population (V1)"
+	case k == stats.ReliabilityWeights:
+		return "reliability weights (V1 - V2/V1)"
+	}
+	return "frequency weights (V1 - 1)"
+}
+
+// weightedSummaries is summaries for values with weights of kind k.
+func weightedSummaries(unit, method string, population bool, k stats.Weights) []summary {
+	variance := func(x, w []float64) float64 { return stats.WeightedVariance(x, w, k) }
+	if population {
+		variance = func(x, w []float64) float64 { return stats.WeightedPopVariance(x, w) }
+	}
+	quantile := func(p float64) func(x, w []float64) any {
+		return func(x, w []float64) any { return stats.WeightedQuantile(x, w, p) }
+	}
+	sum := func(w []float64) float64 {
+		var s float64
+		for _, v := range w {
+			s += v
+		}
+		return s
+	}
+	return []summary{
+		{"n", "", "count", false, func(x, _ []float64) any { return len(x) }},
+		{"missing", "", "count of the missing values or weights", true, func(x, w []float64) any { return stats.CountMissing(paired(x, w)) }},
+		{"weight_sum", "", "V1, sum of the weights", false, func(_, w []float64) any { return sum(w) }},
+		{"mean", unit, "weighted mean", false, func(x, w []float64) any { return stats.WeightedMean(x, w) }},
+		{"median", unit, "weighted median", false, quantile(0.5)},
+		{"min", unit, "smallest value", false, func(x, _ []float64) any { return slices.Min(x) }},
+		{"max", unit, "largest value", false, func(x, _ []float64) any { return slices.Max(x) }},
+		{"range", unit, "max - min", false, func(x, _ []float64) any { return slices.Max(x) - slices.Min(x) }},
+		{"variance", squared(unit), method, false, func(x, w []float64) any { return variance(x, w) }},
+		{"std_dev", unit, method, false, func(x, w []float64) any { return math.Sqrt(variance(x, w)) }},
+		{"q1", unit, "weighted 0.25-quantile", false, quantile(0.25)},
+		{"q3", unit, "weighted 0.75-quantile", false, quantile(0.75)},
+		{"iqr", unit, "Q3 - Q1", false, func(x, w []float64) any {
+			return stats.WeightedQuantile(x, w, 0.75) - stats.WeightedQuantile(x, w, 0.25)
+		}},
+		{"cv", "", "std_dev / mean", false, func(x, w []float64) any { return math.Sqrt(variance(x, w)) / stats.WeightedMean(x, w) }},
+	}
+}
+
+// display formats a statistic for a text table: NA if it is missing,
+// "none" for no modes.
+func (o *options) display(v any) string {
+	switch v := v.(type) {
+	case float64:
+		if math.IsNaN(v) {
+			return "NA"
+		}
+		return o.num(v)
+	case []float64:
+		if len(v) == 0 {
+			return "none"
+		}
+		ms := make([]string, len(v))
+		for j, m := range v {
+			ms[j] = o.num(m)
+		}
+		return strings.Join(ms, ", ")
+	}
+	return fmt.Sprint(v)
+}
+
+// squared returns the unit of a variance.
+func squared(unit string) string {
+	if unit == "" {
+		return ""
+	}
+	return unit + "²"
+}
+
+// printTable prints a table with the first column aligned left and the
+// others right.
+func printTable(header []string, rows [][]string) {
+	writeTable(os.Stdout, header, rows)
+}
+
+// writeTable is printTable writing to w.
+func writeTable(w io.Writer, header []string, rows [][]string) {
+	widths := make([]int, len(header))
+	for _, r := range append([][]string{header}, rows...) {
+		for i, c := range r {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	line := func(cells []string) {
+		var b strings.Builder
+		for i, c := range cells {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c))
+			if i == 0 {
+				b.WriteString(c + pad)
+			} else {
+				b.WriteString("  " + pad + c)
+			}
+		}
+		fmt.Fprintln(w, b.String())
+	}
+	line(header)
+	rules := make([]string, len(header))
+	for i, w := range widths {
+		rules[i] = strings.Repeat("-", w)
+	}
+	line(rules)
+	for _, r := range rows {
+		line(r)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/explain"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+// derivations are the statistics that explain derives, by name.
+var derivations = []struct {
+	name string
+	// squared is set for statistics in the square of the data's unit.
+	squared bool
+	derive  func(x []float64, population bool, o explain.Options) *explain.Derivation
+}{
+	{"mean", false, func(x []float64, _ bool, o explain.Options) *explain.Derivation { return explain.Mean(x, o) }},
+	{"median", false, func(x []float64, _ bool, o explain.Options) *explain.Derivation { return explain.Median(x, o) }},
+	{"mode", false, func(x []float64, _ bool, o explain.Options) *explain.Derivation { return explain.Mode(x, o) }},
+	{"range", false, func(x []float64, _ bool, o explain.Options) *explain.Derivation { return explain.Range(x, o) }},
+	{"variance", true, explain.Variance},
+	{"std_dev", false, explain.StdDev},
+	{"iqr", false, func(x []float64, _ bool, o explain.Options) *explain.Derivation { return explain.IQR(x, o) }},
+}
+
+// groupedDerivations are the statistics that explain derives from the
+// frequency table of the data, by name.
+var groupedDerivations = []struct {
+	name    string
+	squared bool
+	derive  func(classes []stats.Class, population bool, o explain.Options) *explain.Derivation
+}{
+	{"grouped_mean", false, func(c []stats.Class, _ bool, o explain.Options) *explain.Derivation { return explain.GroupedMean(c, o) }},
+	{"grouped_median", false, func(c []stats.Class, _ bool, o explain.Options) *explain.Derivation {
+		return explain.GroupedMedian(c, o)
+	}},
+	{"grouped_variance", true, explain.GroupedVariance},
+}
+
+func setupExplain(fs *flag.FlagSet) func(*options) error {
+	statistic := fs.String("statistic", "mean,median,mode,variance,std_dev", "statistics to derive, separated by commas: mean, median, mode, range, variance, std_dev, iqr, grouped_mean, grouped_median or grouped_variance")
+	level := explain.Key
+	fs.Var(&level, "level", "detail of the derivations: answer, key or full")
+	markup := explain.Text
+	fs.Var(&markup, "markup", "markup of the derivations: text, markdown, latex or html")
+	population := fs.Bool("population", false, "divide the variance by n (population) instead of n - 1 (sample)")
+	exact := fs.Bool("exact", false, "compute with exact fractions, flagging the step where rounding first occurs")
+	sig := fs.Int("sig", 6, "significant figures of the rounded decimals with -exact")
+	classes := fs.Int("classes", 0, "number of classes of the grouped statistics (default: square-root rule)")
+	return func(o *options) error {
+		if *sig < 1 {
+			return badInput("-sig must be positive")
+		}
+		if *classes < 0 {
+			return badInput("-classes must be positive")
+		}
+		if err := o.needColumns(1, 1); err != nil {
+			return err
+		}
+		x, err := o.numeric(o.columns[0])
+		if err != nil {
+			return err
+		}
+		eo := explain.Options{Unit: o.unit, Precision: o.precision, Exact: *exact, Sig: *sig}
+		k := *classes
+		if k == 0 {
+			k = stats.SqrtClasses(len(x))
+		}
+		table := stats.FrequencyTable(x, k)
+		res := report.New("stats explain", o.dataset())
+		for i, name := range strings.Split(*statistic, ",") {
+			var d *explain.Derivation
+			var isSquared bool
+			for _, s := range derivations {
+				if s.name == name {
+					d, isSquared = s.derive(x, *population, eo), s.squared
+				}
+			}
+			for _, s := range groupedDerivations {
+				if s.name == name {
+					d, isSquared = s.derive(table, *population, eo), s.squared
+				}
+			}
+			if d == nil {
+				return badInput("unknown statistic %q", name)
+			}
+			if !o.text() {
+				unit := o.unit
+				if isSquared {
+					unit = squared(unit)
+				}
+				method := d.Formula
+				if d.Exact != nil {
+					method += "; exactly " + d.Exact.RatString()
+				}
+				res.Add(name, d.Value, unit, method)
+				continue
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			if err := d.Write(os.Stdout, markup, level); err != nil {
+				return err
+			}
+		}
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+		return nil
+	}
+}
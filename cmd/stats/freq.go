@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+func setupFreq(fs *flag.FlagSet) func(*options) error {
+	classes := fs.Int("classes", 0, "number of classes of a numeric column (default: square-root rule)")
+	categorical := fs.Bool("categorical", false, "count each distinct value, even of a numeric column")
+	weights := fs.String("weights", "", "column of weights; the frequencies are then sums of weights")
+	return func(o *options) error {
+		if err := o.needColumns(1, 1); err != nil {
+			return err
+		}
+		c := o.columns[0]
+		var w []float64
+		if *weights != "" {
+			var err error
+			if w, err = o.numeric(*weights); err != nil {
+				return err
+			}
+			if err := stats.CheckWeights(w, w); err != nil {
+				return badInput("column %s: %v", o.columnName(*weights), err)
+			}
+		}
+		col, _ := o.column(c)
+		if *categorical || col.Kind != frame.Numeric {
+			return freqCategorical(o, col, w)
+		}
+		x := col.Floats()
+		k, method := *classes, "given by -classes"
+		if k == 0 {
+			k, method = stats.SqrtClasses(len(x)), "square-root rule, round(sqrt(n))"
+		}
+		if k < 1 {
+			return badInput("-classes must be positive")
+		}
+		table := stats.FrequencyTable(x, k)
+		total := float64(len(x))
+		if w != nil {
+			table = stats.WeightedFrequencyTable(x, w, k)
+			total = 0
+			for _, v := range w {
+				total += v
+			}
+		}
+
+		name := o.columnName(c)
+		res := report.New("stats freq", o.dataset())
+		res.Add("classes", len(table), "", method)
+		res.Add("class_width", table[0].Upper-table[0].Lower, o.unit, "range / classes, rounded up to the data's resolution")
+		t := report.Table{Name: "grouped frequency", Columns: []string{"lower", "upper", "midpoint", "frequency", "relative", "cumulative"}}
+		if w != nil {
+			t.Name = "weighted grouped frequency"
+			t.Columns = []string{"lower", "upper", "midpoint", "count", "weight", "relative", "cumulative"}
+		}
+		rows := [][]string{}
+		cum := 0.0
+		for i, cl := range table {
+			cum += cl.Weight
+			rel := cl.Weight / total
+			bracket := ")"
+			if i == len(table)-1 {
+				bracket = "]"
+			}
+			row := []string{"[" + o.num(cl.Lower) + ", " + o.num(cl.Upper) + bracket, o.num(cl.Midpoint())}
+			if w != nil {
+				t.Rows = append(t.Rows, []any{cl.Lower, cl.Upper, cl.Midpoint(), cl.Count, cl.Weight, rel, cum})
+				row = append(row, strconv.Itoa(cl.Count), o.num(cl.Weight), o.num(rel), o.num(cum))
+			} else {
+				t.Rows = append(t.Rows, []any{cl.Lower, cl.Upper, cl.Midpoint(), cl.Count, rel, int(cum)})
+				row = append(row, strconv.Itoa(cl.Count), o.num(rel), strconv.Itoa(int(cum)))
+			}
+			rows = append(rows, row)
+		}
+		res.Tables = append(res.Tables, t)
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+		label := name
+		if o.unit != "" {
+			label += " (" + o.unit + ")"
+		}
+		if w != nil {
+			rows = append(rows, []string{"Total", "", strconv.Itoa(len(x)), o.num(total), o.num(1), ""})
+			printTable([]string{label, "midpoint", "count", "weight", "relative", "cumulative"}, rows)
+		} else {
+			rows = append(rows, []string{"Total", "", strconv.Itoa(len(x)), o.num(1), ""})
+			printTable([]string{label, "midpoint", "frequency", "relative", "cumulative"}, rows)
+		}
+		fmt.Printf("\n%d classes of width %s; the last class includes its upper limit.\n", len(table), o.withUnit(table[0].Upper-table[0].Lower))
+		return nil
+	}
+}
+
+// freqCategorical prints the count of each level of column c, in
+// order, or the sum of their weights w if not nil.
+func freqCategorical(o *options, c *frame.Column, w []float64) error {
+	levels, counts := c.Counts()
+	n := 0
+	for _, m := range counts {
+		n += m
+	}
+	res := report.New("stats freq", o.dataset(kindOf(c)))
+	t := report.Table{Name: "frequency", Columns: []string{"value", "frequency", "relative", "cumulative"}}
+	rows := [][]string{}
+	if w != nil {
+		sums := make(map[string]float64)
+		var total float64
+		for i := range w {
+			if !c.IsNA(i) {
+				sums[c.String(i)] += w[i]
+				total += w[i]
+			}
+		}
+		t.Name = "weighted frequency"
+		t.Columns = []string{"value", "count", "weight", "relative", "cumulative"}
+		cum := 0.0
+		for i, l := range levels {
+			cum += sums[l]
+			rel := sums[l] / total
+			t.Rows = append(t.Rows, []any{l, counts[i], sums[l], rel, cum})
+			rows = append(rows, []string{l, strconv.Itoa(counts[i]), o.num(sums[l]), o.num(rel), o.num(cum)})
+		}
+		res.Tables = append(res.Tables, t)
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+		rows = append(rows, []string{"Total", strconv.Itoa(n), o.num(total), o.num(1), ""})
+		printTable([]string{c.Name, "count", "weight", "relative", "cumulative"}, rows)
+		return nil
+	}
+	cum := 0
+	for i, l := range levels {
+		cum += counts[i]
+		rel := float64(counts[i]) / float64(n)
+		t.Rows = append(t.Rows, []any{l, counts[i], rel, cum})
+		rows = append(rows, []string{l, strconv.Itoa(counts[i]), o.num(rel), strconv.Itoa(cum)})
+	}
+	res.Tables = append(res.Tables, t)
+	if !o.text() {
+		return res.Write(os.Stdout)
+	}
+	rows = append(rows, []string{"Total", strconv.Itoa(n), o.num(1), ""})
+	printTable([]string{c.Name, "frequency", "relative", "cumulative"}, rows)
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+// readFrame reads the CSV file path, or standard input if path is "-",
+// with the sentinels na read as missing values, and returns it with its
+// name.
+func readFrame(path string, na frame.NA) (*frame.Frame, string, error) {
+	var r io.Reader = os.Stdin
+	name := "stdin"
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, "", badInput("%v", err)
+		}
+		defer f.Close()
+		r = f
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	f, err := frame.ReadNA(r, na)
+	if err != nil {
+		return nil, "", badInput("%s: %v", path, err)
+	}
+	if f.Len() == 0 {
+		return nil, "", badInput("%s: no data: need a header row and at least one record", path)
+	}
+	return f, name, nil
+}
+
+// commonUnit returns the unit shared by the numeric columns among
+// columns, or "" if they have none or differ.
+func (o *options) commonUnit(columns []string) string {
+	unit, seen := "", false
+	for _, c := range columns {
+		col, err := o.column(c)
+		if err != nil || col.Kind != frame.Numeric {
+			continue
+		}
+		if seen && col.Unit != unit {
+			return ""
+		}
+		unit, seen = col.Unit, true
+	}
+	return unit
+}
+
+// headerIndex returns the index in header of column c, given by name or
+// by 1-based number.
+func headerIndex(header []string, c string) (int, error) {
+	if i := slices.Index(header, c); i >= 0 {
+		return i, nil
+	}
+	if i, err := strconv.Atoi(c); err == nil && i >= 1 && i <= len(header) {
+		return i - 1, nil
+	}
+	return 0, badInput("no column %q; the columns are %s", c, strings.Join(header, ", "))
+}
+
+// column returns the column c of the input, given by name or by 1-based
+// number.
+func (o *options) column(c string) (*frame.Column, error) {
+	i, err := headerIndex(o.frame.Names(), c)
+	if err != nil {
+		return nil, err
+	}
+	return o.frame.Columns()[i], nil
+}
+
+// columnName returns the name of column c, which has been checked to
+// exist.
+func (o *options) columnName(c string) string {
+	col, err := o.column(c)
+	if err != nil {
+		panic(err)
+	}
+	return col.Name
+}
+
+// numeric returns the values of the numeric column c, NaN if missing.
+func (o *options) numeric(c string) ([]float64, error) {
+	col, err := o.column(c)
+	if err != nil {
+		return nil, err
+	}
+	if col.Kind != frame.Numeric {
+		return nil, badInput("column %s is %s, not numeric", col.Name, col.Kind)
+	}
+	return col.Floats(), nil
+}
+
+// groups splits the numeric column c by the values of column by, in the
+// order of its levels if it is categorical and of first appearance
+// otherwise, leaving out missing values.
+func (o *options) groups(c, by string) (levels []string, values [][]float64, err error) {
+	if _, err := o.numeric(c); err != nil {
+		return nil, nil, err
+	}
+	return stats.Groups(o.frame, o.columnName(c), o.columnName(by))
+}
+
+// imputeColumn fills in the missing values of column c by m and returns how
+// many it filled in. A categorical column is imputed through the
+// indices of its levels, so only by mode, LOCF or hot-deck.
+func (o *options) imputeColumn(c string, m stats.Imputation, r *rand.Rand) (int, error) {
+	col, err := o.column(c)
+	if err != nil {
+		return 0, err
+	}
+	if col.Missing() == 0 {
+		return 0, nil
+	}
+	x := col.Floats()
+	switch {
+	case col.Kind == frame.ID:
+		return 0, badInput("column %s identifies the rows, so cannot be imputed", col.Name)
+	case col.Kind == frame.Numeric:
+	case m == stats.ImputeMean || m == stats.ImputeMedian:
+		return 0, badInput("column %s is not numeric, so cannot be imputed by its %s", col.Name, m)
+	default:
+		for i := range x {
+			x[i] = math.NaN()
+			if k := col.Code(i); k >= 0 {
+				x[i] = float64(k)
+			}
+		}
+	}
+	y, n, err := stats.Impute(x, m, r)
+	if err != nil {
+		return 0, badInput("column %s: %v", col.Name, err)
+	}
+	// Rebuild the column from the filled-in values as text, so that it
+	// keeps its kind, unit and levels.
+	records := make([][]string, len(y))
+	for i, v := range y {
+		switch {
+		case math.IsNaN(v):
+			records[i] = []string{""}
+		case col.Kind == frame.Numeric:
+			records[i] = []string{strconv.FormatFloat(v, 'g', -1, 64)}
+		default:
+			records[i] = []string{col.Levels[int(v)]}
+		}
+	}
+	spec := frame.Spec{Kind: col.Kind, Unit: col.Unit, Levels: col.Levels, NA: o.na}
+	filled, err := frame.FromRecords([]string{col.Name}, records, map[string]frame.Spec{col.Name: spec})
+	if err != nil {
+		return 0, err
+	}
+	cols := o.frame.Columns()
+	cols[slices.Index(o.frame.Names(), col.Name)] = filled.Columns()[0]
+	if o.frame, err = frame.New(cols...); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// textOf returns the values of column c as text, empty if missing.
+func textOf(c *frame.Column) []string {
+	out := c.Strings()
+	for i := range out {
+		if c.IsNA(i) {
+			out[i] = ""
+		}
+	}
+	return out
+}
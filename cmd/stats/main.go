@@ -0,0 +1,485 @@
+// Command stats runs the analyses of this repository on the columns of
+// a CSV file:
+//
+//	stats describe -input wheat.csv -column yield -unit kg
+//	stats box -input fertilizer.csv -column usage -by plot -out usage.svg
+//	stats test -kind chisq -input apples.csv -column crunchiness,quality
+//	stats crosstab -dataset apple_quality -column ripeness,quality -cells counts,residuals -layout markdown
+//	stats describe -dataset wheat_yield
+//	stats prob -dist 'binomial(10, 0.3)' -x 3
+//	stats power -test t2 -dataset fertilizer -diff 5 -curve
+//	stats ci -dataset apple_quality -column quality -parameter proportion -success good
+//	stats tables -table t,chisq -format latex -o tables.tex
+//
+// Every command takes the global flags -input or -dataset, -column,
+// -format, -precision and -unit; "stats help <command>" lists its own
+// flags. With -dataset, the data come from the built-in catalog, which
+// "stats datasets" lists; -column then defaults to the dataset's main
+// variable and -unit to the unit of its columns. The columns of a
+// dataset have the kinds and the order of levels of its variables; those
+// of -input are numeric if every value is a number, boolean if every
+// value is true or false, and nominal otherwise.
+//
+// Empty cells and the sentinels of -na are missing values. They are
+// filled in first if -impute is given; -missing then says what happens
+// to the rest. Under the default, omit, the commands leave out the rows
+// missing a value of the columns they use, while describe omits the
+// missing values of each column on its own; propagate makes the
+// statistics of describe missing and is an error elsewhere; error
+// refuses data with missing values. "stats missing" summarizes them.
+//
+// The exit status is 0 on success, 1 when a computation fails (for
+// example a correlation of a constant column) and 2 for bad usage or
+// input data (an unknown flag, a missing file, a column that is not
+// numeric).
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+// The exit statuses.
+const (
+	exitFailure = 1
+	exitInput   = 2
+)
+
+// command is a subcommand of stats.
+type command struct {
+	name    string
+	args    string
+	summary string
+
+	// setup registers the command's own flags on fs and returns the
+	// function that runs it once the flags are parsed.
+	setup func(fs *flag.FlagSet) func(o *options) error
+
+	// perColumn is set for commands that handle the missing values of
+	// each column themselves, rather than using only complete rows.
+	perColumn bool
+
+	// document is set for commands that write a document, which -format
+	// md, latex and pdf also select.
+	document bool
+}
+
+var commands = []command{
+	{"describe", "-column a[,b...]", "summary statistics of numeric columns", setupDescribe, true, false},
+	{"freq", "-column a", "frequency table of a column, grouped into classes if numeric", setupFreq, false, false},
+	{"hist", "-column a", "histogram of a numeric column", setupHist, false, false},
+	{"box", "-column a[,b...] [-by g]", "box plots of numeric columns, or of one column by group", setupBox, false, false},
+	{"bar", "-column a [-by g]", "bar chart of the counts of a categorical column", setupBar, false, false},
+	{"scatter", "-column x,y [-by g]", "scatter plot of two numeric columns", setupScatter, false, false},
+	{"corr", "-column a,b[,c...]", "correlation matrix and tests of numeric columns", setupCorr, false, false},
+	{"crosstab", "-column a,b[,c...] [-cells counts,row,...]", "cross-tabulation of categorical columns", setupCrosstab, false, false},
+	{"test", "-kind t|welch|chisq -column ...", "hypothesis tests", setupTest, false, false},
+	{"explain", "-column a [-statistic s,...]", "step-by-step derivations of statistics of a numeric column", setupExplain, false, false},
+	{"ci", "-column a [-parameter mean|proportion|variance|sd|median|quantile]", "confidence intervals for a parameter of a column", setupCI, false, false},
+	{"power", "-test t1|t2|prop1|prop2|anova|corr", "sample size or power of a planned test, with power curves", setupPower, false, false},
+	{"prob", "-dist name(params) [-x x,...] [-p p,...]", "probabilities, quantiles and random draws of a distribution", setupProb, false, false},
+	{"datasets", "[-dataset name]", "list the built-in datasets, or describe one", setupDatasets, false, false},
+	{"missing", "", "missing values of every column", setupMissing, true, false},
+	{"report", "-column a", "HTML or LaTeX report of a numeric column", setupReport, false, false},
+	{"tables", "[-table z,t,...]", "statistical tables of the normal, t, chi-square, F, binomial and Poisson distributions", setupTables, false, true},
+}
+
+// options are the global flags, shared by every command.
+type options struct {
+	input     string
+	name      string
+	columns   []string
+	format    *report.Format
+	precision int
+	unit      string
+	na        frame.NA
+	policy    stats.Policy
+	policies  map[string]stats.Policy // of single statistics, by name
+	impute    stats.Imputation
+	seed      uint64
+
+	// frame is the input, read once the flags are parsed, source its
+	// name and catalog its dataset when it is built in.
+	frame   *frame.Frame
+	source  string
+	catalog *datasets.Dataset
+
+	// missing and imputed count the missing values of the columns, by
+	// name, before and by imputation; dropped is the number of rows
+	// left out for missing values.
+	missing map[string]int
+	imputed map[string]int
+	dropped int
+
+	// stream is set when the command reads standard input itself, row
+	// by row, rather than through frame.
+	stream bool
+}
+
+func (o *options) register(fs *flag.FlagSet, document bool) {
+	fs.StringVar(&o.input, "input", "", "CSV file with a header row, or - for standard input")
+	fs.StringVar(&o.name, "dataset", "", "built-in dataset to use instead of -input: "+strings.Join(datasets.Names(), ", "))
+	fs.Func("column", "column name or 1-based number; several are separated by commas", func(s string) error {
+		o.columns = append(o.columns, strings.Split(s, ",")...)
+		return nil
+	})
+	if document {
+		o.format = report.DocumentFormatFlagSet(fs)
+	} else {
+		o.format = report.FormatFlagSet(fs)
+	}
+	fs.IntVar(&o.precision, "precision", 4, "decimal places of the numbers printed in text output")
+	fs.StringVar(&o.unit, "unit", "", "unit of the numeric columns, e.g. kg")
+	o.na = frame.DefaultNA
+	fs.Func("na", "values read as missing besides empty cells, separated by commas (default NA,.)", func(s string) error {
+		o.na = strings.Split(s, ",")
+		return nil
+	})
+	fs.Func("missing", "missing-value policy: omit, propagate or error, then optionally statistic=policy overrides for describe, e.g. omit,mean=propagate", o.setPolicies)
+	fs.Func("impute", "fill in missing values first, by mean, median, mode, locf or hotdeck", func(s string) error {
+		var err error
+		o.impute, err = stats.ParseImputation(s)
+		return err
+	})
+	fs.Uint64Var(&o.seed, "seed", 1, "seed of the random draws of -impute hotdeck and prob -sample")
+}
+
+// setPolicies parses the -missing flag.
+func (o *options) setPolicies(s string) error {
+	for _, f := range strings.Split(s, ",") {
+		name, policy, ok := strings.Cut(f, "=")
+		if !ok {
+			name, policy = "", f
+		}
+		p, err := stats.ParsePolicy(policy)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			o.policy = p
+			continue
+		}
+		if o.policies == nil {
+			o.policies = make(map[string]stats.Policy)
+		}
+		o.policies[name] = p
+	}
+	return nil
+}
+
+// policyOf returns the missing-value policy of the statistic name.
+func (o *options) policyOf(name string) stats.Policy {
+	if p, ok := o.policies[name]; ok {
+		return p
+	}
+	return o.policy
+}
+
+// text reports whether the output is for people rather than programs.
+func (o *options) text() bool {
+	return *o.format == report.Text
+}
+
+// num formats v with the precision set by -precision.
+func (o *options) num(v float64) string {
+	return strconv.FormatFloat(v, 'f', o.precision, 64)
+}
+
+// withUnit formats v followed by the unit, if any.
+func (o *options) withUnit(v float64) string {
+	if o.unit == "" {
+		return o.num(v)
+	}
+	return o.num(v) + " " + o.unit
+}
+
+// needColumns checks that between min and max columns were given,
+// max < 0 meaning any number.
+func (o *options) needColumns(min, max int) error {
+	n := len(o.columns)
+	switch {
+	case o.frame == nil:
+		return badInput("no input: use -input FILE, -input - for standard input, or -dataset NAME")
+	case n == 0:
+		return badInput("no column given: use -column")
+	case n < min:
+		return badInput("%d columns given, need at least %d", n, min)
+	case max >= 0 && n > max:
+		if max == 1 {
+			return badInput("%d columns given, need one", n)
+		}
+		return badInput("%d columns given, need at most %d", n, max)
+	}
+	return nil
+}
+
+// dataset describes the input and the given columns for a result, of
+// their kinds in the input unless given by kinds.
+func (o *options) dataset(kinds ...string) report.Dataset {
+	ds := report.Dataset{Name: o.source, N: o.frame.Len(), Dropped: o.dropped}
+	if o.catalog != nil {
+		ds.Description = o.catalog.Description
+	}
+	for i, c := range o.columns {
+		col, _ := o.column(c)
+		name := col.Name
+		v := report.Variable{Name: name, Kind: col.Kind.String(), Unit: o.unit, Missing: o.missing[name], Imputed: o.imputed[name]}
+		if v.Imputed > 0 {
+			v.Imputation = string(o.impute)
+		}
+		if i < len(kinds) {
+			v.Kind = kinds[i]
+		}
+		if v.Kind != "numeric" {
+			v.Unit = ""
+		}
+		ds.Variables = append(ds.Variables, v)
+	}
+	return ds
+}
+
+// inputError is an error in the command line or the input data, as
+// opposed to a failure of the computation.
+type inputError struct{ msg string }
+
+func (e *inputError) Error() string { return e.msg }
+
+func badInput(format string, args ...any) error {
+	return &inputError{fmt.Sprintf(format, args...)}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitInput
+		}
+		return 0
+	}
+	name := args[0]
+	if name == "help" {
+		if len(args) < 2 {
+			usage(stderr)
+			return 0
+		}
+		name, args = args[1], []string{args[1], "-h"}
+	}
+	cmd, ok := lookup(name)
+	if !ok {
+		fmt.Fprintf(stderr, "stats: unknown command %q\n\n", name)
+		usage(stderr)
+		return exitInput
+	}
+
+	fs := flag.NewFlagSet("stats "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	o := &options{}
+	o.register(fs, cmd.document)
+	runCmd := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: stats %s %s [flags]\n\n%s.\n\nflags:\n", cmd.name, cmd.args, capitalize(cmd.summary))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitInput
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "stats %s: unexpected arguments %q\n", cmd.name, fs.Args())
+		return exitInput
+	}
+
+	var extra []string
+	for _, name := range []string{"by", "weights"} {
+		if f := fs.Lookup(name); f != nil && f.Value.String() != "" {
+			extra = append(extra, f.Value.String())
+		}
+	}
+	if f := fs.Lookup("stream"); f != nil {
+		o.stream = f.Value.String() == "true"
+	}
+	err := o.load(cmd.perColumn, extra)
+	if err == nil {
+		err = runCmd(o)
+	}
+	if err == nil && o.text() && !cmd.perColumn {
+		o.printMissing()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "stats %s: %v\n", cmd.name, err)
+		var ie *inputError
+		if errors.As(err, &ie) {
+			return exitInput
+		}
+		return exitFailure
+	}
+	return 0
+}
+
+// load reads the input, if any, checks that the columns exist and
+// handles their missing values: it imputes them if asked to and, unless
+// perColumn, applies the missing-value policy to the rows, by the given
+// columns and the extra ones of -by and -weights. Commands that need
+// data report its absence in needColumns.
+func (o *options) load(perColumn bool, extra []string) error {
+	var err error
+	switch {
+	case o.input != "" && o.name != "":
+		return badInput("use either -input or -dataset, not both")
+	case o.stream:
+		if o.name != "" || (o.input != "" && o.input != "-") {
+			return badInput("-stream reads standard input; -input and -dataset are not used")
+		}
+		return nil
+	case o.name != "":
+		if o.catalog, err = datasets.Get(o.name); err != nil {
+			return badInput("%v", err)
+		}
+		if o.frame, err = o.catalog.FrameNA(o.na); err != nil {
+			return err
+		}
+		o.source = o.catalog.Name
+		if len(o.columns) == 0 {
+			o.columns = []string{o.catalog.Main().Name}
+		}
+	case o.input != "":
+		if o.frame, o.source, err = readFrame(o.input, o.na); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	used := append(slices.Clone(o.columns), extra...)
+	for _, c := range used {
+		if _, err := o.column(c); err != nil {
+			return err
+		}
+	}
+	if o.unit == "" {
+		o.unit = o.commonUnit(o.columns)
+	}
+	o.missing = make(map[string]int)
+	o.imputed = make(map[string]int)
+	r := rand.New(rand.NewPCG(o.seed, 0))
+	names := make([]string, len(used))
+	for i, c := range used {
+		col, _ := o.column(c)
+		names[i] = col.Name
+		o.missing[col.Name] = col.Missing()
+		if o.impute != "" {
+			if o.imputed[col.Name], err = o.imputeColumn(c, o.impute, r); err != nil {
+				return err
+			}
+		}
+	}
+	if perColumn {
+		return nil
+	}
+	for _, c := range used {
+		col, _ := o.column(c)
+		n := col.Missing()
+		if n == 0 {
+			continue
+		}
+		switch o.policy {
+		case stats.Refuse:
+			return badInput("column %s has %d missing values (-missing error); use -missing omit or -impute", col.Name, n)
+		case stats.Propagate:
+			return badInput("column %s has %d missing values, and -missing propagate applies to describe only; use -missing omit or -impute", col.Name, n)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	complete, err := o.frame.DropNA(names...)
+	if err != nil {
+		return err
+	}
+	if complete.Len() == 0 {
+		for _, name := range names {
+			if col, _ := o.frame.Column(name); col.Missing() == col.Len() {
+				return badInput("column %s has no values left: every one is missing", name)
+			}
+		}
+		return badInput("no rows are left: every row misses a value of %s", strings.Join(names, ", "))
+	}
+	o.dropped = o.frame.Len() - complete.Len()
+	o.frame = complete
+	return nil
+}
+
+// printMissing notes the missing values of the columns after the text
+// output of a command.
+func (o *options) printMissing() {
+	if o.frame == nil {
+		return
+	}
+	var notes []string
+	for _, name := range slices.Sorted(maps.Keys(o.missing)) {
+		switch {
+		case o.imputed[name] > 0:
+			notes = append(notes, fmt.Sprintf("%s: %d imputed by %s", name, o.imputed[name], o.impute))
+		case o.missing[name] > 0:
+			notes = append(notes, fmt.Sprintf("%s: %d", name, o.missing[name]))
+		}
+	}
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Printf("\nMissing values: %s.", strings.Join(notes, "; "))
+	if o.dropped > 0 {
+		fmt.Printf(" %d rows with missing values left out.", o.dropped)
+	}
+	fmt.Println()
+}
+
+func lookup(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: stats <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "global flags, accepted by every command:")
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(w)
+	(&options{}).register(fs, false)
+	fs.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "stats help <command>" for the flags of a command.`)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
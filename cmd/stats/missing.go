@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+func setupMissing(fs *flag.FlagSet) func(*options) error {
+	return func(o *options) error {
+		if o.frame == nil {
+			return badInput("no input: use -input FILE, -input - for standard input, or -dataset NAME")
+		}
+		n := o.frame.Len()
+		res := report.New("stats missing", report.Dataset{Name: o.source, N: n})
+		counts := report.Table{Name: "missing values", Columns: []string{"column", "kind", "missing", "percent", "imputed"}}
+		rows := [][]string{}
+		for _, c := range o.frame.Columns() {
+			missing, ok := o.missing[c.Name]
+			if !ok {
+				missing = c.Missing()
+			}
+			kind := c.Kind.String()
+			res.Dataset.Variables = append(res.Dataset.Variables, report.Variable{Name: c.Name, Kind: kind, Missing: missing, Imputed: o.imputed[c.Name]})
+			pct := 100 * float64(missing) / float64(n)
+			counts.Rows = append(counts.Rows, []any{c.Name, kind, missing, pct, o.imputed[c.Name]})
+			rows = append(rows, []string{c.Name, kind, strconv.Itoa(missing), o.num(pct), strconv.Itoa(o.imputed[c.Name])})
+		}
+		full, err := o.frame.DropNA()
+		if err != nil {
+			return err
+		}
+		complete := full.Len()
+		res.Add("complete_rows", complete, "", "rows with no missing value")
+		res.Tables = append(res.Tables, counts)
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+		printTable([]string{"Column", "Kind", "Missing", "%", "Imputed"}, rows)
+		quoted := make([]string, len(o.na))
+		for i, s := range o.na {
+			quoted[i] = strconv.Quote(s)
+		}
+		fmt.Printf("\n%d of %d rows are complete. Missing values are empty cells and %s.\n", complete, n, strings.Join(quoted, ", "))
+		return nil
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"io"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/vgpdf"
+)
+
+// The PDF is set on A4 paper in a monospaced font, so that the columns
+// of the tables line up without a layout engine.
+var (
+	pageW, pageH = 210 * vg.Millimeter, 297 * vg.Millimeter
+	margin       = 15 * vg.Millimeter
+	bodySize     = vg.Points(9)
+)
+
+// pdfText replaces the characters outside the Windows-1252 code page
+// of the PDF fonts.
+var pdfText = strings.NewReplacer(
+	"Φ", "Phi",
+	"χ²", "chi-square",
+	"χ", "chi",
+	"α", "alpha",
+	"λ", "lambda",
+	"∞", "inf",
+	"≤", "<=",
+	"≥", ">=",
+	"−", "-",
+)
+
+// pdfWriter lays out a page top to bottom, starting a new sheet when
+// the current one is full.
+type pdfWriter struct {
+	c *vgpdf.Canvas
+	y vg.Length
+}
+
+// writePDF writes p to w as a PDF document: the headings and prose
+// wrapped to the page, each table shrunk if need be to fit the page
+// width and split across pages with its header repeated.
+func writePDF(w io.Writer, p *report.Page) error {
+	_ = plot.New() // registers the Liberation fonts
+	pw := &pdfWriter{c: vgpdf.New(pageW, pageH), y: pageH - margin}
+	pw.line(face(vg.Points(16)), p.Title)
+	pw.line(face(bodySize), p.Subtitle)
+	for _, s := range p.Sections {
+		pw.y -= bodySize
+		pw.need(4 * bodySize)
+		pw.line(face(vg.Points(12)), s.Title)
+		for _, b := range s.Blocks {
+			switch {
+			case b.Text != "":
+				pw.para(b.Text)
+			case b.Table != nil:
+				pw.table(b.Table)
+			}
+		}
+	}
+	_, err := pw.c.WriteTo(w)
+	return err
+}
+
+// face returns the monospaced font at size. vgpdf registers only the
+// regular style, so headings are set larger rather than bold.
+func face(size vg.Length) font.Face {
+	return font.DefaultCache.Lookup(font.Font{Typeface: "Liberation", Variant: "Mono", Size: size}, size)
+}
+
+// need starts a new sheet unless h of height is left on this one.
+func (pw *pdfWriter) need(h vg.Length) {
+	if pw.y-h < margin {
+		pw.c.NextPage()
+		pw.y = pageH - margin
+	}
+}
+
+// line writes s on a line of its own.
+func (pw *pdfWriter) line(f font.Face, s string) {
+	h := f.Font.Size * 1.3
+	pw.need(h)
+	pw.y -= h
+	pw.c.FillString(f, vg.Point{X: margin, Y: pw.y}, pdfText.Replace(s))
+}
+
+// para writes s wrapped at word boundaries to the text width.
+func (pw *pdfWriter) para(s string) {
+	f := face(bodySize)
+	width := int((pageW - 2*margin) / f.Width("0"))
+	var l string
+	for _, word := range strings.Fields(pdfText.Replace(s)) {
+		if l != "" && len(l)+1+len(word) > width {
+			pw.line(f, l)
+			l = ""
+		}
+		if l != "" {
+			l += " "
+		}
+		l += word
+	}
+	pw.line(f, l)
+	pw.y -= bodySize / 2
+}
+
+// table writes t with its columns padded to a common width.
+func (pw *pdfWriter) table(t *report.Table) {
+	rows := [][]string{t.Columns}
+	for _, r := range t.Rows {
+		cells := make([]string, len(r))
+		for j, v := range r {
+			cells[j] = report.Cell(v)
+		}
+		rows = append(rows, cells)
+	}
+	widths := make([]int, len(t.Columns))
+	for _, r := range rows {
+		for j, c := range r {
+			widths[j] = max(widths[j], len([]rune(pdfText.Replace(c))))
+		}
+	}
+	lines := make([]string, len(rows))
+	total := 0
+	for i, r := range rows {
+		var b strings.Builder
+		for j, c := range r {
+			c = pdfText.Replace(c)
+			pad := strings.Repeat(" ", widths[j]-len([]rune(c)))
+			if j == 0 {
+				b.WriteString(c + pad)
+			} else {
+				b.WriteString("  " + pad + c)
+			}
+		}
+		lines[i] = b.String()
+		total = max(total, len([]rune(lines[i])))
+	}
+
+	// Shrink the font until the widest line fits the text width.
+	size := bodySize
+	f := face(size)
+	if w := f.Width(strings.Repeat("0", total)); w > pageW-2*margin {
+		size = size * (pageW - 2*margin) / w
+	}
+	body := face(size)
+	rule := strings.Repeat("-", total)
+	pw.need(4 * size * 1.3)
+	pw.line(face(vg.Points(10)), t.Name)
+	pw.line(body, lines[0])
+	pw.line(body, rule)
+	for _, l := range lines[1:] {
+		if pw.y-size*1.3 < margin {
+			pw.need(pageH)
+			pw.line(body, lines[0])
+			pw.line(body, rule)
+		}
+		pw.line(body, l)
+	}
+	pw.y -= bodySize
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"github.com/mayura-andrew/applied-statistics/textplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+var lightBlue = color.RGBA{R: 173, G: 216, B: 230, A: 255}
+
+// figureFile returns the default file of a figure of the given kind of
+// the named columns, e.g. yield_histogram.png.
+func figureFile(kind string, names ...string) string {
+	parts := make([]string, len(names))
+	for i, n := range names {
+		parts[i] = strings.ToLower(strings.Join(strings.Fields(n), "_"))
+	}
+	return strings.Join(parts, "_vs_") + "_" + kind + ".png"
+}
+
+// axisLabel returns the label of an axis showing the named column.
+func (o *options) axisLabel(name string) string {
+	if o.unit == "" {
+		return name
+	}
+	return name + " (" + o.unit + ")"
+}
+
+// save saves p to the file of -out, by default file, and reports it.
+func (o *options) save(out *plots.Output, file string, p *plot.Plot, w, h vg.Length, res *report.Result, what string) error {
+	if out.File == "" {
+		out.File = file
+	}
+	saved, err := out.Save(p, w, h)
+	if err != nil {
+		return err
+	}
+	if !o.text() {
+		res.Plots = append(res.Plots, saved)
+		return res.Write(os.Stdout)
+	}
+	fmt.Println(what, "has been saved to", saved)
+	return nil
+}
+
+func setupHist(fs *flag.FlagSet) func(*options) error {
+	classes := fs.Int("classes", 0, "number of bins (default: square-root rule)")
+	term := fs.Bool("term", false, "draw the histogram in the terminal instead of saving a figure")
+	out := plots.OutputFlagSet(fs, "")
+	return func(o *options) error {
+		if err := o.needColumns(1, 1); err != nil {
+			return err
+		}
+		x, err := o.numeric(o.columns[0])
+		if err != nil {
+			return err
+		}
+		k := *classes
+		if k == 0 {
+			k = stats.SqrtClasses(len(x))
+		}
+		if k < 1 {
+			return badInput("-classes must be positive")
+		}
+		name := o.columnName(o.columns[0])
+		p := plot.New()
+		p.Title.Text = "Histogram of " + name
+		p.X.Label.Text = o.axisLabel(name)
+		p.Y.Label.Text = "Frequency"
+		hist := &plotter.Histogram{FillColor: lightBlue, LineStyle: plotter.DefaultLineStyle}
+		res := report.New("stats hist", o.dataset())
+		t := report.Table{Name: "histogram", Columns: []string{"min", "max", "weight"}}
+		for _, c := range stats.FrequencyTable(x, k) {
+			hist.Bins = append(hist.Bins, plotter.HistogramBin{Min: c.Lower, Max: c.Upper, Weight: float64(c.Count)})
+			t.Rows = append(t.Rows, []any{c.Lower, c.Upper, c.Count})
+		}
+		hist.Width = hist.Bins[0].Max - hist.Bins[0].Min
+		p.Add(hist)
+		res.Tables = append(res.Tables, t)
+
+		if *term && o.text() {
+			chart := textplot.Chart{Title: p.Title.Text, XLabel: p.X.Label.Text, YLabel: p.Y.Label.Text}
+			fmt.Print(chart.Histogram(hist.Bins))
+			return nil
+		}
+		return o.save(out, figureFile("histogram", name), p, 8*vg.Inch, 6*vg.Inch, res, "Histogram")
+	}
+}
+
+func setupBox(fs *flag.FlagSet) func(*options) error {
+	by := fs.String("by", "", "draw one box per level of this column")
+	notch := fs.Bool("notch", false, "draw a notch showing the 95% CI of the median")
+	horizontal := fs.Bool("horizontal", false, "draw the boxes horizontally")
+	term := fs.Bool("term", false, "draw the box plots in the terminal instead of saving a figure")
+	out := plots.OutputFlagSet(fs, "")
+	return func(o *options) error {
+		if err := o.needColumns(1, -1); err != nil {
+			return err
+		}
+		var names []string
+		var groups [][]float64
+		res := report.New("stats box", o.dataset())
+		if *by != "" {
+			if err := o.needColumns(1, 1); err != nil {
+				return err
+			}
+			byCol, err := o.column(*by)
+			if err != nil {
+				return err
+			}
+			names, groups, err = o.groups(o.columns[0], *by)
+			if err != nil {
+				return err
+			}
+			res.Dataset.Variables = append(res.Dataset.Variables, report.Variable{Name: byCol.Name, Kind: kindOf(byCol)})
+		} else {
+			for _, c := range o.columns {
+				x, err := o.numeric(c)
+				if err != nil {
+					return err
+				}
+				names = append(names, o.columnName(c))
+				groups = append(groups, x)
+			}
+		}
+
+		p := plot.New()
+		label := o.axisLabel(o.columnName(o.columns[0]))
+		p.Title.Text = "Box Plot of " + strings.Join(names, ", ")
+		if *by != "" {
+			p.Title.Text = fmt.Sprintf("Box Plot of %s by %s", o.columnName(o.columns[0]), o.columnName(*by))
+		} else if len(names) > 1 {
+			label = o.axisLabel("Value")
+		}
+		var boxes []*plotter.BoxPlot
+		var means []float64
+		for i, g := range groups {
+			box, err := plots.NewBox(vg.Points(40), float64(i), plotter.Values(g))
+			if err != nil {
+				return err
+			}
+			box.FillColor = lightBlue
+			box.ShowMean = true
+			box.Notch = *notch
+			box.Horizontal = *horizontal
+			p.Add(box)
+			labels, err := box.OutlierLabels()
+			if err != nil {
+				return err
+			}
+			p.Add(labels)
+			if i == 0 {
+				p.Legend.Add("mean", plots.LegendGlyph(box.MeanStyle))
+				p.Legend.Top = true
+			}
+			boxes = append(boxes, box.BoxPlot)
+			means = append(means, box.Mean)
+
+			start := len(res.Statistics)
+			if *by != "" {
+				plots.AddBoxStatistics(res, o.columnName(o.columns[0]), box, o.unit)
+				for j := start; j < len(res.Statistics); j++ {
+					res.Statistics[j].Group = names[i]
+				}
+			} else {
+				plots.AddBoxStatistics(res, names[i], box, o.unit)
+			}
+			if o.text() && !*term {
+				fmt.Printf("%s: %s   Mean = %s\n", names[i], box.Summary(), o.num(box.Mean))
+				if out := box.Outliers(); len(out) > 0 {
+					fmt.Printf("    outliers (outside 1.5 × IQR fences): %v\n", out)
+				}
+			}
+		}
+		if *horizontal {
+			p.X.Label.Text = label
+			p.NominalY(names...)
+		} else {
+			p.Y.Label.Text = label
+			p.NominalX(names...)
+		}
+
+		if *term && o.text() {
+			chart := textplot.Chart{Title: p.Title.Text, XLabel: label}
+			fmt.Print(chart.BoxPlots(names, means, boxes...))
+			return nil
+		}
+		file := figureFile("boxplot", names...)
+		if *by != "" {
+			file = figureFile("boxplot", o.columnName(o.columns[0])+" by "+o.columnName(*by))
+		}
+		return o.save(out, file, p, 8*vg.Inch, 6*vg.Inch, res, "Box plot")
+	}
+}
+
+func setupBar(fs *flag.FlagSet) func(*options) error {
+	by := fs.String("by", "", "split each bar by the levels of this column")
+	term := fs.Bool("term", false, "draw the bar chart in the terminal instead of saving a figure")
+	out := plots.OutputFlagSet(fs, "")
+	return func(o *options) error {
+		if err := o.needColumns(1, 1); err != nil {
+			return err
+		}
+		col, _ := o.column(o.columns[0])
+		name := col.Name
+		levels, counts := col.Counts()
+		res := report.New("stats bar", o.dataset(kindOf(col)))
+		p := plot.New()
+		p.Title.Text = "Counts of " + name
+		p.X.Label.Text = name
+		p.Y.Label.Text = "Count"
+
+		var series []textplot.Series
+		t := report.Table{Name: "counts", Columns: []string{name}}
+		if *by == "" {
+			series = []textplot.Series{{Values: toFloats(counts)}}
+			t.Columns = append(t.Columns, "count")
+		} else {
+			byCol, err := o.column(*by)
+			if err != nil {
+				return err
+			}
+			res.Dataset.Variables = append(res.Dataset.Variables, report.Variable{Name: byCol.Name, Kind: kindOf(byCol)})
+			p.Title.Text += " by " + byCol.Name
+			groupLevels, _ := byCol.Counts()
+			for _, g := range groupLevels {
+				series = append(series, textplot.Series{Name: g, Values: make([]float64, len(levels))})
+				t.Columns = append(t.Columns, g)
+			}
+			for i := 0; i < col.Len(); i++ {
+				if !col.IsNA(i) && !byCol.IsNA(i) {
+					j := slices.Index(groupLevels, byCol.String(i))
+					series[j].Values[slices.Index(levels, col.String(i))]++
+				}
+			}
+		}
+		for i, l := range levels {
+			row := []any{l}
+			for _, s := range series {
+				row = append(row, int(s.Values[i]))
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		res.Tables = append(res.Tables, t)
+
+		if *term && o.text() {
+			chart := textplot.Chart{Title: p.Title.Text, XLabel: name, YLabel: "Count"}
+			fmt.Print(chart.Bars(levels, series...))
+			return nil
+		}
+		w := vg.Points(20)
+		if len(series) > 1 {
+			w = vg.Points(40) / vg.Length(len(series))
+		}
+		for i, s := range series {
+			bar, err := plotter.NewBarChart(plotter.Values(s.Values), w)
+			if err != nil {
+				return err
+			}
+			bar.Color = lightBlue
+			if len(series) > 1 {
+				bar.Color = plotutil.Color(i)
+				bar.Offset = w * vg.Length(2*i-len(series)+1) / 2
+				p.Legend.Add(s.Name, bar)
+			}
+			bar.LineStyle.Width = vg.Points(0.5)
+			p.Add(bar)
+		}
+		p.Legend.Top = true
+		p.NominalX(levels...)
+		p.Add(plotter.NewGrid())
+		if *by != "" {
+			name += " by " + o.columnName(*by)
+		}
+		return o.save(out, figureFile("bar", name), p, 6*vg.Inch, 4*vg.Inch, res, "Bar chart")
+	}
+}
+
+// kindOf returns the kind of column c when its values are used as
+// categories: nominal unless it is categorical.
+func kindOf(c *frame.Column) string {
+	if !c.Kind.Categorical() {
+		return "nominal"
+	}
+	return c.Kind.String()
+}
+
+func toFloats(counts []int) []float64 {
+	out := make([]float64, len(counts))
+	for i, c := range counts {
+		out[i] = float64(c)
+	}
+	return out
+}
+
+func setupScatter(fs *flag.FlagSet) func(*options) error {
+	by := fs.String("by", "", "color the points by the levels of this column")
+	term := fs.Bool("term", false, "draw the scatter plot in the terminal instead of saving a figure")
+	out := plots.OutputFlagSet(fs, "")
+	return func(o *options) error {
+		if err := o.needColumns(2, 2); err != nil {
+			return err
+		}
+		x, err := o.numeric(o.columns[0])
+		if err != nil {
+			return err
+		}
+		y, err := o.numeric(o.columns[1])
+		if err != nil {
+			return err
+		}
+		xName, yName := o.columnName(o.columns[0]), o.columnName(o.columns[1])
+		res := report.New("stats scatter", o.dataset())
+
+		var series []textplot.XYSeries
+		if *by == "" {
+			series = []textplot.XYSeries{{}}
+			for i := range x {
+				series[0].XYs = append(series[0].XYs, plotter.XY{X: x[i], Y: y[i]})
+			}
+		} else {
+			byCol, err := o.column(*by)
+			if err != nil {
+				return err
+			}
+			res.Dataset.Variables = append(res.Dataset.Variables, report.Variable{Name: byCol.Name, Kind: kindOf(byCol)})
+			gs, err := o.frame.GroupBy(byCol.Name)
+			if err != nil {
+				return err
+			}
+			for _, g := range gs {
+				gx, _ := g.Floats(xName)
+				gy, _ := g.Floats(yName)
+				s := textplot.XYSeries{Name: g.Key[0]}
+				for i := range gx {
+					s.XYs = append(s.XYs, plotter.XY{X: gx[i], Y: gy[i]})
+				}
+				series = append(series, s)
+			}
+		}
+		r, rErr := stats.Pearson(x, y)
+		if rErr == nil {
+			res.Add("pearson_r", r, "", "product-moment correlation of "+xName+" and "+yName)
+		}
+
+		p := plot.New()
+		p.Title.Text = yName + " vs " + xName
+		if *by != "" {
+			p.Title.Text += " (by " + o.columnName(*by) + ")"
+		}
+		p.X.Label.Text = o.axisLabel(xName)
+		p.Y.Label.Text = o.axisLabel(yName)
+		if *term && o.text() {
+			chart := textplot.Chart{Title: p.Title.Text, XLabel: p.X.Label.Text, YLabel: p.Y.Label.Text}
+			fmt.Print(chart.Scatter(series...))
+		} else {
+			for i, s := range series {
+				sc, err := plotter.NewScatter(s.XYs)
+				if err != nil {
+					return err
+				}
+				sc.GlyphStyle = draw.GlyphStyle{Color: plotutil.Color(i), Radius: vg.Points(3), Shape: plotutil.Shape(i)}
+				p.Add(sc)
+				if s.Name != "" {
+					p.Legend.Add(s.Name, sc)
+				}
+			}
+			p.Legend.Top = true
+			p.Add(plotter.NewGrid())
+			err := o.save(out, figureFile("scatter", xName, yName), p, 8*vg.Inch, 6*vg.Inch, res, "Scatter plot")
+			if err != nil || !o.text() {
+				return err
+			}
+		}
+		if rErr == nil {
+			fmt.Printf("Pearson's r = %s\n", o.num(r))
+		}
+		return nil
+	}
+}
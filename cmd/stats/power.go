@@ -0,0 +1,303 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// design is a planned test whose power depends on the sample size.
+type design struct {
+	name   string
+	symbol string  // of the standardized effect, e.g. d
+	effect float64 // standardized
+	source string  // where the effect comes from
+	groups int     // 1 unless n counts each of several groups
+	from   int     // the smallest usable n
+
+	// power returns the power at sample size n for the effect e.
+	power func(e float64, n int) float64
+}
+
+func setupPower(fs *flag.FlagSet) func(*options) error {
+	test := fs.String("test", "", "planned test: t1 (one-sample or paired t-test), t2 (two-sample t-test), prop1 (one proportion), prop2 (two proportions), anova (one-way ANOVA) or corr (correlation)")
+	effect := fs.Float64("effect", 0, "standardized effect size: Cohen's d for t1 and t2, f for anova, r for corr; otherwise estimated from the data")
+	diff := fs.Float64("diff", 0, "for t1 and t2, the difference of means to detect, in the unit of -column; d is diff / sd")
+	sd := fs.Float64("sd", 0, "for t1 and t2, the standard deviation (default: that of -column)")
+	p0 := fs.Float64("p0", 0.5, "for prop1, the hypothesised proportion; for prop2, that of the first group")
+	p1 := fs.Float64("p1", 0, "for prop1, the true proportion to detect; for prop2, that of the second group")
+	groups := fs.Int("groups", 0, "for anova, the number of groups (default: the levels of -by)")
+	by := fs.String("by", "", "for anova, the grouping column of -column from which f is estimated")
+	alpha := fs.Float64("alpha", 0.05, "significance level of the two-sided test")
+	target := fs.Float64("power", 0.8, "power to reach; the sample size is computed unless -n is given")
+	n := fs.Int("n", 0, "sample size (per group for t2, prop2 and anova) at which to give the power instead")
+	curve := fs.Bool("curve", false, "plot the power against the sample size, saved to -out")
+	out := plots.OutputFlagSet(fs, "")
+	return func(o *options) error {
+		if !(*alpha > 0 && *alpha < 1) {
+			return badInput("-alpha must be between 0 and 1")
+		}
+		if !(*target > 0 && *target < 1) {
+			return badInput("-power must be between 0 and 1")
+		}
+		if *n < 0 {
+			return badInput("-n must not be negative")
+		}
+		a := *alpha
+		var d design
+		switch *test {
+		case "t1", "t2":
+			d = design{name: "one-sample t-test", symbol: "d", groups: 1, from: 2, power: func(e float64, n int) float64 {
+				return stats.PowerOneSampleT(e, n, a)
+			}}
+			if *test == "t2" {
+				d.name, d.groups = "two-sample t-test", 2
+				d.power = func(e float64, n int) float64 { return stats.PowerTwoSampleT(e, n, a) }
+			}
+			switch {
+			case *effect != 0:
+				d.effect, d.source = *effect, "given"
+			case *diff == 0:
+				return badInput("no effect given: use -effect d, or -diff with -sd or the data of -column")
+			case *sd > 0:
+				d.effect, d.source = *diff / *sd, fmt.Sprintf("difference %s / SD %s", o.withUnit(*diff), o.withUnit(*sd))
+			case *sd < 0:
+				return badInput("-sd must be positive")
+			default:
+				if err := o.needColumns(1, 1); err != nil {
+					return err
+				}
+				x, err := o.numeric(o.columns[0])
+				if err != nil {
+					return err
+				}
+				s := stats.StdDev(x)
+				if !(s > 0) {
+					return fmt.Errorf("column %s has no spread to estimate the SD from", o.columnName(o.columns[0]))
+				}
+				d.effect = *diff / s
+				d.source = fmt.Sprintf("difference %s / SD %s of %s, n = %d", o.withUnit(*diff), o.withUnit(s), o.columnName(o.columns[0]), len(x))
+			}
+		case "prop1", "prop2":
+			if !(*p0 > 0 && *p0 < 1) || !(*p1 > 0 && *p1 < 1) {
+				return badInput("-p0 and -p1 must be proportions between 0 and 1")
+			}
+			d = design{name: "test of one proportion", symbol: "h", groups: 1, from: 1, power: func(e float64, n int) float64 {
+				return stats.PowerOneProportion(e, n, a)
+			}}
+			if *test == "prop2" {
+				d.name, d.groups = "test of two proportions", 2
+				d.power = func(e float64, n int) float64 { return stats.PowerTwoProportions(e, n, a) }
+			}
+			d.effect = stats.CohenH(*p1, *p0)
+			d.source = fmt.Sprintf("2 asin √%g − 2 asin √%g", *p1, *p0)
+		case "anova":
+			k := *groups
+			switch {
+			case *effect != 0:
+				d.effect, d.source = *effect, "given"
+			case *by == "":
+				return badInput("no effect given: use -effect f, or -by to estimate it from the groups of -column")
+			default:
+				if err := o.needColumns(1, 1); err != nil {
+					return err
+				}
+				levels, samples, err := o.groups(o.columns[0], *by)
+				if err != nil {
+					return err
+				}
+				f, err := cohenF(samples)
+				if err != nil {
+					return err
+				}
+				d.effect = f
+				d.source = fmt.Sprintf("SD of the %d means of %s by %s / pooled SD", len(levels), o.columnName(o.columns[0]), o.columnName(*by))
+				if k == 0 {
+					k = len(levels)
+				}
+			}
+			if k < 2 {
+				return badInput("anova needs -groups of at least 2")
+			}
+			d.name, d.symbol, d.groups, d.from = fmt.Sprintf("one-way ANOVA of %d groups", k), "f", k, 2
+			d.power = func(e float64, n int) float64 { return stats.PowerANOVA(e, k, n, a) }
+		case "corr":
+			d = design{name: "test of a correlation", symbol: "r", groups: 1, from: 4, power: func(e float64, n int) float64 {
+				return stats.PowerCorrelation(e, n, a)
+			}}
+			if *effect != 0 {
+				if !(math.Abs(*effect) < 1) {
+					return badInput("-effect r must be between -1 and 1, exclusive")
+				}
+				d.effect, d.source = *effect, "given"
+				break
+			}
+			if err := o.needColumns(2, 2); err != nil {
+				return badInput("no effect given: use -effect r, or two columns to estimate it from")
+			}
+			x, err := o.numeric(o.columns[0])
+			if err != nil {
+				return err
+			}
+			y, err := o.numeric(o.columns[1])
+			if err != nil {
+				return err
+			}
+			if d.effect, err = stats.Pearson(x, y); err != nil {
+				return err
+			}
+			d.source = fmt.Sprintf("Pearson r of %s and %s, n = %d", o.columnName(o.columns[0]), o.columnName(o.columns[1]), len(x))
+			if math.Abs(d.effect) >= 1 {
+				return fmt.Errorf("the correlation is %v; a perfect correlation needs no sample size", d.effect)
+			}
+		case "":
+			return badInput("no test given: use -test t1, t2, prop1, prop2, anova or corr")
+		default:
+			return badInput("unknown -test %q (want t1, t2, prop1, prop2, anova or corr)", *test)
+		}
+		if d.effect == 0 || math.IsNaN(d.effect) {
+			return fmt.Errorf("the effect size is zero: there is nothing to detect")
+		}
+
+		ds := report.Dataset{Name: "design", Description: "planned " + d.name}
+		if o.frame != nil {
+			ds = o.dataset()
+		}
+		res := report.New("stats power", ds)
+		res.Add("effect_size", d.effect, "", d.symbol+": "+d.source)
+		sided := "two-sided"
+		if d.symbol == "f" {
+			sided = "upper tail of F"
+		}
+		res.Add("alpha", a, "", "significance level, "+sided)
+		size := *n
+		var power float64
+		if size > 0 {
+			if size < d.from {
+				return badInput("-n must be at least %d", d.from)
+			}
+			power = d.power(d.effect, size)
+			res.Add("power", power, "", fmt.Sprintf("at n = %d", size))
+		} else {
+			var err error
+			size, err = stats.SampleSize(func(n int) float64 { return d.power(d.effect, n) }, *target, d.from)
+			if err != nil {
+				return err
+			}
+			power = d.power(d.effect, size)
+			res.Add("sample_size", size, "", fmt.Sprintf("smallest n with power ≥ %g", *target))
+			res.Add("power", power, "", fmt.Sprintf("at n = %d", size))
+		}
+		if d.groups > 1 {
+			res.Add("total_size", size*d.groups, "", fmt.Sprintf("n per group × %d groups", d.groups))
+		}
+
+		if *curve {
+			p := powerCurve(d, size, *target)
+			if out.File == "" {
+				out.File = figureFile("power_curve", *test)
+			}
+			saved, err := out.Save(p, 8*vg.Inch, 6*vg.Inch)
+			if err != nil {
+				return err
+			}
+			res.Plots = append(res.Plots, saved)
+			defer fmt.Println("Power curve has been saved to", saved)
+		}
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+
+		fmt.Printf("%s, alpha = %g (%s)\n", capitalize(d.name), a, sided)
+		fmt.Printf("Effect size %s = %s (%s)\n", d.symbol, o.num(d.effect), d.source)
+		per := ""
+		if d.groups > 1 {
+			per = fmt.Sprintf(" per group (%d in all)", size*d.groups)
+		}
+		if *n > 0 {
+			fmt.Printf("Power at n = %d%s: %s\n", size, per, o.num(power))
+		} else {
+			fmt.Printf("Sample size for %g%% power: %d%s, with power %s\n", 100**target, size, per, o.num(power))
+		}
+		return nil
+	}
+}
+
+// cohenF estimates Cohen's f from samples: the standard deviation of
+// their means, weighting each group equally, divided by the pooled
+// within-group standard deviation.
+func cohenF(samples [][]float64) (float64, error) {
+	k := len(samples)
+	if k < 2 {
+		return 0, fmt.Errorf("anova needs at least 2 groups, got %d", k)
+	}
+	means := make([]float64, k)
+	var ss, df float64
+	for i, s := range samples {
+		if len(s) < 2 {
+			return 0, fmt.Errorf("each group needs at least 2 observations to estimate f")
+		}
+		means[i] = stats.Mean(s)
+		ss += stats.Variance(s) * float64(len(s)-1)
+		df += float64(len(s) - 1)
+	}
+	within := math.Sqrt(ss / df)
+	if within == 0 {
+		return 0, fmt.Errorf("the groups have no spread to estimate f from")
+	}
+	return math.Sqrt(stats.PopVariance(means)) / within, nil
+}
+
+// powerCurve plots the power of d against the sample size, for its
+// effect and for half and one and a half times it, with the target
+// power and the sample size n marked.
+func powerCurve(d design, n int, target float64) *plot.Plot {
+	p := plot.New()
+	p.Title.Text = "Power of the " + d.name
+	p.X.Label.Text = "Sample size n"
+	if d.groups > 1 {
+		p.X.Label.Text += " per group"
+	}
+	p.Y.Label.Text = "Power"
+	p.Y.Min, p.Y.Max = 0, 1
+	hi := max(2*n, d.from+10)
+	for i, m := range []float64{0.5, 1, 1.5} {
+		e := m * d.effect
+		var xys plotter.XYs
+		for k := d.from; k <= hi; k += max(1, (hi-d.from)/200) {
+			xys = append(xys, plotter.XY{X: float64(k), Y: d.power(e, k)})
+		}
+		l, err := plotter.NewLine(xys)
+		if err != nil {
+			continue
+		}
+		l.Color = plotutil.Color(i)
+		l.Width = vg.Points(1.5)
+		if m != 1 {
+			l.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+		}
+		p.Add(l)
+		p.Legend.Add(fmt.Sprintf("%s = %.3g", d.symbol, e), l)
+	}
+	t, _ := plotter.NewLine(plotter.XYs{{X: float64(d.from), Y: target}, {X: float64(hi), Y: target}})
+	t.Color = plotutil.Color(3)
+	t.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	m, _ := plotter.NewLine(plotter.XYs{{X: float64(n), Y: 0}, {X: float64(n), Y: 1}})
+	m.Color = plotutil.Color(3)
+	m.Dashes = t.Dashes
+	p.Add(t, m, plotter.NewGrid())
+	p.Legend.Add(fmt.Sprintf("power %g, n = %d", target, n), t)
+	p.Legend.Top = false
+	p.Legend.Left = false
+	p.X.Min = float64(d.from)
+	return p
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/dist"
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+func setupProb(fs *flag.FlagSet) func(*options) error {
+	spec := fs.String("dist", "", "distribution, e.g. binomial(10,0.3), normal(0,1) or t(12); one of "+strings.Join(dist.Families(), ", "))
+	xs := fs.String("x", "", "values x, separated by commas, at which to give P(X ≤ x), P(X > x) and the density or P(X = x)")
+	between := fs.String("between", "", "a,b: give P(a ≤ X ≤ b)")
+	ps := fs.String("p", "", "probabilities p, separated by commas, whose quantiles to give")
+	sample := fs.Int("sample", 0, "number of random values to draw, seeded by -seed")
+	return func(o *options) error {
+		if o.input != "" || o.name != "" || len(o.columns) > 0 {
+			return badInput("prob computes from -dist; -input, -dataset and -column are not used")
+		}
+		if *spec == "" {
+			return badInput("no distribution given: use -dist, e.g. -dist 'binomial(10,0.3)'")
+		}
+		d, err := dist.Parse(*spec)
+		if err != nil {
+			return badInput("%v", err)
+		}
+		x, err := floatList("-x", *xs)
+		if err != nil {
+			return err
+		}
+		p, err := floatList("-p", *ps)
+		if err != nil {
+			return err
+		}
+		for _, v := range p {
+			if !(v >= 0 && v <= 1) {
+				return badInput("-p %v is not a probability", v)
+			}
+		}
+		ab, err := floatList("-between", *between)
+		if err != nil {
+			return err
+		}
+		if len(ab) != 0 && (len(ab) != 2 || ab[0] > ab[1]) {
+			return badInput("-between takes two values a,b with a ≤ b")
+		}
+		if *sample < 0 {
+			return badInput("-sample must not be negative")
+		}
+		_, discrete := d.(dist.Discrete)
+
+		res := report.New("stats prob", report.Dataset{Name: d.String(), Description: "theoretical distribution"})
+		var lines [][]string
+		add := func(name, expr string, v float64) {
+			res.Add(name, v, "", expr)
+			lines = append(lines, []string{expr, o.prob(v)})
+		}
+		add("mean", "E(X)", d.Mean())
+		add("variance", "Var(X)", d.Variance())
+		add("std_dev", "SD(X)", math.Sqrt(d.Variance()))
+		for _, v := range x {
+			s := strconv.FormatFloat(v, 'g', -1, 64)
+			if dd, ok := d.(dist.Discrete); ok {
+				pmf := 0.0
+				if v == math.Trunc(v) {
+					pmf = dd.PMF(int(v))
+				}
+				add("pmf", "P(X = "+s+")", pmf)
+				add("cdf", "P(X ≤ "+s+")", d.CDF(v))
+				add("cdf_below", "P(X < "+s+")", d.CDF(v)-pmf)
+				add("survival_from", "P(X ≥ "+s+")", d.Survival(v)+pmf)
+				add("survival", "P(X > "+s+")", d.Survival(v))
+			} else {
+				add("pdf", "f("+s+")", d.(dist.Continuous).PDF(v))
+				add("cdf", "P(X ≤ "+s+")", d.CDF(v))
+				add("survival", "P(X > "+s+")", d.Survival(v))
+			}
+		}
+		if len(ab) == 2 {
+			// For a discrete X, P(a ≤ X) = P(X > a - 1) when a is whole.
+			lo := ab[0]
+			if discrete {
+				lo = math.Ceil(lo) - 1
+			}
+			v := d.CDF(ab[1]) - d.CDF(lo)
+			add("interval", fmt.Sprintf("P(%s ≤ X ≤ %s)", strconv.FormatFloat(ab[0], 'g', -1, 64), strconv.FormatFloat(ab[1], 'g', -1, 64)), max(v, 0))
+		}
+		for _, v := range p {
+			q := d.Quantile(v)
+			res.Add("quantile", q, "", fmt.Sprintf("smallest x with P(X ≤ x) ≥ %g", v))
+			lines = append(lines, []string{fmt.Sprintf("quantile(%g)", v), o.num(q)})
+		}
+		var draws []float64
+		if *sample > 0 {
+			draws = dist.Sample(d, *sample, rand.New(rand.NewPCG(o.seed, 0)))
+			res.Add("sample", draws, "", fmt.Sprintf("random draws, seed %d", o.seed))
+		}
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+
+		kind := "continuous"
+		if discrete {
+			kind = "discrete"
+		}
+		fmt.Printf("X ~ %s, %s\n\n", d, kind)
+		printTable([]string{"", "Value"}, lines)
+		if len(draws) > 0 {
+			s := make([]string, len(draws))
+			for i, v := range draws {
+				s[i] = strconv.FormatFloat(v, 'g', 6, 64)
+				if !discrete {
+					s[i] = o.num(v)
+				}
+			}
+			fmt.Printf("\n%d random values (seed %d):\n%s\n", len(draws), o.seed, strings.Join(s, " "))
+		}
+		return nil
+	}
+}
+
+// prob formats a probability with the precision set by -precision, in
+// scientific notation when it would otherwise print as zero.
+func (o *options) prob(v float64) string {
+	if v != 0 && math.Abs(v) < math.Pow(10, -float64(o.precision)) {
+		return strconv.FormatFloat(v, 'e', max(o.precision-1, 1), 64)
+	}
+	return o.num(v)
+}
+
+// floatList parses the comma-separated numbers of flag name.
+func floatList(name, s string) ([]float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var x []float64
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil, badInput("%s: %q is not a number", name, f)
+		}
+		x = append(x, v)
+	}
+	return x, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/report/descriptive"
+)
+
+func setupReport(fs *flag.FlagSet) func(*options) error {
+	out := fs.String("out", "", "file to write: .html for a web page, .tex for a LaTeX document (default <column>_report.html)")
+	title := fs.String("title", "", "title of the report (default: the column name)")
+	description := fs.String("description", "", "one-sentence description of the data")
+	return func(o *options) error {
+		if err := o.needColumns(1, 1); err != nil {
+			return err
+		}
+		x, err := o.numeric(o.columns[0])
+		if err != nil {
+			return err
+		}
+		name := o.columnName(o.columns[0])
+		d := descriptive.Data{Title: *title, Description: *description, Variable: name, Unit: o.unit, Values: x}
+		if d.Title == "" {
+			d.Title = name
+		}
+		if d.Description == "" {
+			d.Description = fmt.Sprintf("Column %s of %s.", name, o.source)
+		}
+		if *out == "" {
+			*out = strings.TrimSuffix(figureFile("report", name), ".png") + ".html"
+		}
+
+		latex := strings.EqualFold(filepath.Ext(*out), ".tex")
+		var figures string
+		if latex {
+			figures = strings.TrimSuffix(*out, filepath.Ext(*out))
+		}
+		page, files, err := descriptive.Page(d, figures)
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		if latex {
+			err = page.WriteLaTeX(f)
+		} else {
+			err = page.WriteHTML(f)
+		}
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		if !o.text() {
+			res := report.New("stats report", o.dataset())
+			res.Plots = files
+			return res.Write(os.Stdout)
+		}
+		for _, file := range files {
+			fmt.Println("Figure has been saved to", file)
+		}
+		fmt.Println("Report has been saved to", *out)
+		return nil
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+// batchSize is the number of rows the reader of describe -stream hands
+// to a worker at a time.
+const batchSize = 4096
+
+// streamSummary summarizes one column of a stream.
+type streamSummary struct {
+	acc     stats.Accumulator
+	sketch  *stats.Sketch
+	missing int
+}
+
+func (s *streamSummary) merge(t *streamSummary) {
+	s.acc.Merge(&t.acc)
+	s.sketch.Merge(t.sketch)
+	s.missing += t.missing
+}
+
+// describeStream is describe -stream: it reads CSV with a header row
+// from r and summarizes the columns, by name or number, of -column, the
+// first one if none is given. The rows are parsed by the reader and
+// summarized by workers goroutines, whose summaries are merged at the
+// end.
+func describeStream(o *options, r io.Reader, population bool, workers, k int) error {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return badInput("stdin: no data: need a header row")
+	}
+	if err != nil {
+		return badInput("stdin: %v", err)
+	}
+	if len(o.columns) == 0 {
+		o.columns = []string{header[0]}
+	}
+	idx := make([]int, len(o.columns))
+	for j, c := range o.columns {
+		if idx[j], err = headerIndex(header, c); err != nil {
+			return err
+		}
+	}
+
+	newSummaries := func() []*streamSummary {
+		s := make([]*streamSummary, len(idx))
+		for j := range s {
+			s[j] = &streamSummary{sketch: stats.NewSketch(k)}
+		}
+		return s
+	}
+	batches := make(chan [][]float64, workers)
+	results := make([][]*streamSummary, workers)
+	var wg sync.WaitGroup
+	for w := range results {
+		results[w] = newSummaries()
+		wg.Add(1)
+		go func(sum []*streamSummary) {
+			defer wg.Done()
+			for batch := range batches {
+				for _, row := range batch {
+					for j, v := range row {
+						if math.IsNaN(v) {
+							sum[j].missing++
+							continue
+						}
+						sum[j].acc.Add(v)
+						sum[j].sketch.Add(v)
+					}
+				}
+			}
+		}(results[w])
+	}
+
+	rows := 0
+	err = func() error {
+		defer close(batches)
+		batch := make([][]float64, 0, batchSize)
+		for {
+			rec, err := cr.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return badInput("stdin: %v", err)
+			}
+			rows++
+			row := make([]float64, len(idx))
+			for j, i := range idx {
+				s := ""
+				if i < len(rec) {
+					s = strings.TrimSpace(rec[i])
+				}
+				if o.na.Is(s) {
+					row[j] = math.NaN()
+					continue
+				}
+				if row[j], err = strconv.ParseFloat(s, 64); err != nil {
+					return badInput("column %s, row %d: %q is not a number", header[i], rows+1, s)
+				}
+			}
+			batch = append(batch, row)
+			if len(batch) == batchSize {
+				batches <- batch
+				batch = make([][]float64, 0, batchSize)
+			}
+		}
+		if len(batch) > 0 {
+			batches <- batch
+		}
+		return nil
+	}()
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	total := results[0]
+	for _, sum := range results[1:] {
+		for j := range total {
+			total[j].merge(sum[j])
+		}
+	}
+
+	method := "sample (n-1), one pass (Welford)"
+	if population {
+		method = "population (n), one pass (Welford)"
+	}
+	approx := fmt.Sprintf("approximate, KLL sketch with k = %d", k)
+	res := report.New("stats describe", report.Dataset{Name: "stdin", N: rows})
+	names := []string{"n", "missing", "mean", "min", "max", "range", "variance", "std_dev", "q1", "median", "q3", "iqr", "cv", "skewness", "kurtosis"}
+	table := make([][]string, len(names))
+	for i, name := range names {
+		table[i] = []string{name}
+	}
+	for j, sum := range total {
+		name := header[idx[j]]
+		res.Dataset.Variables = append(res.Dataset.Variables, report.Variable{Name: name, Kind: "numeric", Unit: o.unit, Missing: sum.missing})
+		a := &sum.acc
+		if a.N() < 2 {
+			return badInput("column %s: need at least 2 values", name)
+		}
+		if sum.missing > 0 && o.policy == stats.Refuse {
+			return badInput("column %s has %d missing values (-missing error)", name, sum.missing)
+		}
+		variance := a.Variance()
+		if population {
+			variance = a.PopVariance()
+		}
+		q1, med, q3 := sum.sketch.Quantile(0.25), sum.sketch.Quantile(0.5), sum.sketch.Quantile(0.75)
+		values := []struct {
+			value        any
+			unit, method string
+		}{
+			{a.N(), "", "count"},
+			{sum.missing, "", "count of the missing values"},
+			{a.Mean(), o.unit, "arithmetic mean, one pass (Welford)"},
+			{a.Min(), o.unit, "smallest value"},
+			{a.Max(), o.unit, "largest value"},
+			{a.Max() - a.Min(), o.unit, "max - min"},
+			{variance, squared(o.unit), method},
+			{math.Sqrt(variance), o.unit, method},
+			{q1, o.unit, "0.25-quantile, " + approx},
+			{med, o.unit, "0.5-quantile, " + approx},
+			{q3, o.unit, "0.75-quantile, " + approx},
+			{q3 - q1, o.unit, "Q3 - Q1, " + approx},
+			{math.Sqrt(variance) / a.Mean(), "", "std_dev / mean"},
+			{a.Skewness(), "", "moment coefficient m3 / m2^1.5"},
+			{a.Kurtosis(), "", "excess kurtosis m4 / m2^2 - 3"},
+		}
+		for i, v := range values {
+			value := v.value
+			if sum.missing > 0 && o.policyOf(names[i]) == stats.Propagate && names[i] != "missing" {
+				value = math.NaN()
+			}
+			res.AddOf(name, names[i], value, v.unit, v.method)
+			table[i] = append(table[i], o.display(value))
+		}
+	}
+	if !o.text() {
+		return res.Write(os.Stdout)
+	}
+	head := []string{"statistic"}
+	for _, j := range idx {
+		head = append(head, header[j])
+	}
+	if o.unit != "" {
+		fmt.Printf("Unit: %s (variance: %s)\n\n", o.unit, squared(o.unit))
+	}
+	printTable(head, table)
+	by := fmt.Sprintf("%d workers", workers)
+	if workers == 1 {
+		by = "one worker"
+	}
+	fmt.Printf("\n%d rows in one pass by %s. Variance and standard deviation: %s. Quartiles: %s, rank error about %.2g.\n",
+		rows, by, method, approx, 1.7/float64(k))
+	return nil
+}
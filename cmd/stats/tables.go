@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/report/tables"
+)
+
+func setupTables(fs *flag.FlagSet) func(*options) error {
+	which := fs.String("table", "all", "tables to write, separated by commas: "+strings.Join(tables.Names, ", ")+" or all")
+	alpha := fs.String("alpha", "0.05,0.01", "upper-tail probabilities of the F tables, separated by commas")
+	trials := fs.String("n", "5,10,15,20", "numbers of trials of the binomial tables, separated by commas")
+	decimals := fs.Int("decimals", 0, "decimal places of the entries (default 4 for probabilities, 3 for critical values)")
+	out := fs.String("o", "", "file to write instead of standard output")
+	return func(o *options) error {
+		if o.frame != nil {
+			return badInput("tables are computed from the distributions; -input and -dataset are not used")
+		}
+		names := tables.Names
+		if *which != "all" {
+			names = strings.Split(*which, ",")
+			for i := range names {
+				names[i] = strings.ToLower(strings.TrimSpace(names[i]))
+			}
+		}
+		if *decimals < 0 || *decimals > 10 {
+			return badInput("-decimals %d: want 0 to 10", *decimals)
+		}
+		to := tables.Options{Decimals: *decimals}
+		for _, s := range strings.Split(*alpha, ",") {
+			a, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil || !(a > 0 && a < 1) {
+				return badInput("-alpha %q: want probabilities between 0 and 1", s)
+			}
+			to.Alpha = append(to.Alpha, a)
+		}
+		for _, s := range strings.Split(*trials, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || n < 1 || n > 100 {
+				return badInput("-n %q: want whole numbers from 1 to 100", s)
+			}
+			to.N = append(to.N, n)
+		}
+		if *o.format == report.PDF && *out == "" {
+			return badInput("-format pdf needs a file: use -o")
+		}
+		page, err := tables.Page(names, to)
+		if err != nil {
+			return badInput("%v", err)
+		}
+
+		if *out == "" {
+			return writeTables(os.Stdout, page, *o.format)
+		}
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		err = writeTables(f, page, *o.format)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println("Tables have been saved to", *out)
+		return nil
+	}
+}
+
+// writeTables writes the tables of page to w in the format f.
+func writeTables(w io.Writer, page *report.Page, f report.Format) error {
+	switch f {
+	case report.JSON:
+		res := report.New("stats tables", report.Dataset{Name: "tables", Description: page.Subtitle})
+		for _, s := range page.Sections {
+			for _, b := range s.Blocks {
+				if b.Table != nil {
+					res.Tables = append(res.Tables, numericTable(b.Table))
+				}
+			}
+		}
+		return res.Write(w)
+	case report.Markdown:
+		return page.WriteMarkdown(w)
+	case report.LaTeX:
+		return page.WriteLaTeX(w)
+	case report.PDF:
+		return writePDF(w, page)
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s\n%s.\n", page.Title, page.Subtitle)
+	for _, s := range page.Sections {
+		fmt.Fprintf(bw, "\n%s\n%s\n", s.Title, strings.Repeat("=", len([]rune(s.Title))))
+		for _, b := range s.Blocks {
+			switch {
+			case b.Text != "":
+				fmt.Fprintf(bw, "\n%s\n", b.Text)
+			case b.Table != nil:
+				fmt.Fprintf(bw, "\n%s\n\n", b.Table.Name)
+				rows := make([][]string, len(b.Table.Rows))
+				for i, r := range b.Table.Rows {
+					rows[i] = make([]string, len(r))
+					for j, c := range r {
+						rows[i][j] = report.Cell(c)
+					}
+				}
+				writeTable(bw, b.Table.Columns, rows)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// numericTable returns t with the entries that are numbers as numbers,
+// the tables having them formatted to a fixed number of decimals.
+func numericTable(t *report.Table) report.Table {
+	n := report.Table{Name: t.Name, Columns: t.Columns}
+	for _, r := range t.Rows {
+		row := make([]any, len(r))
+		for j, c := range r {
+			row[j] = c
+			if s, ok := c.(string); ok {
+				if v, err := strconv.ParseFloat(s, 64); err == nil {
+					row[j] = v
+				}
+			}
+		}
+		n.Rows = append(n.Rows, row)
+	}
+	return n
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/crosstab"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+func setupTest(fs *flag.FlagSet) func(*options) error {
+	kind := fs.String("kind", "", "test: t (one-sample t-test of -column against -mu), welch (two-sample t-test of two columns, or of one column split -by a two-level column) or chisq (chi-square test of independence of two categorical columns)")
+	mu := fs.Float64("mu", 0, "hypothesised mean of the one-sample t-test")
+	by := fs.String("by", "", "for welch, the column whose two levels split -column into the samples")
+	alpha := fs.Float64("alpha", 0.05, "significance level")
+	return func(o *options) error {
+		if *alpha <= 0 || *alpha >= 1 {
+			return badInput("-alpha must be between 0 and 1")
+		}
+		var (
+			t     stats.TestResult
+			err   error
+			kinds []string
+			null  string
+			res   *report.Result
+
+			// alternative is the alternative hypothesis; the
+			// chi-square test is one-tailed.
+			alternative = "two-sided"
+		)
+		switch *kind {
+		case "t":
+			if err := o.needColumns(1, 1); err != nil {
+				return err
+			}
+			var x []float64
+			x, err = o.numeric(o.columns[0])
+			if err != nil {
+				return err
+			}
+			null = fmt.Sprintf("the mean of %s is %s", o.columnName(o.columns[0]), o.withUnit(*mu))
+			res = report.New("stats test", o.dataset())
+			res.Add("mean", stats.Mean(x), o.unit, "arithmetic mean")
+			res.Add("mu", *mu, o.unit, "hypothesised mean")
+			t, err = stats.OneSampleT(x, *mu)
+		case "welch":
+			var names []string
+			var samples [][]float64
+			if *by != "" {
+				if err := o.needColumns(1, 1); err != nil {
+					return err
+				}
+				byCol, err := o.column(*by)
+				if err != nil {
+					return err
+				}
+				names, samples, err = o.groups(o.columns[0], *by)
+				if err != nil {
+					return err
+				}
+				if len(names) != 2 {
+					return badInput("column %s has %d levels, need 2", o.columnName(*by), len(names))
+				}
+				kinds = []string{"numeric", kindOf(byCol)}
+				null = fmt.Sprintf("the mean %s is equal for %s %s and %s", o.columnName(o.columns[0]), o.columnName(*by), names[0], names[1])
+				o.columns = append(o.columns, *by)
+			} else {
+				if err := o.needColumns(2, 2); err != nil {
+					return err
+				}
+				for _, c := range o.columns {
+					x, err := o.numeric(c)
+					if err != nil {
+						return err
+					}
+					names = append(names, o.columnName(c))
+					samples = append(samples, x)
+				}
+			}
+			if null == "" {
+				null = fmt.Sprintf("the means of %s and %s are equal", names[0], names[1])
+			}
+			res = report.New("stats test", o.dataset(kinds...))
+			for i, name := range names {
+				res.AddGroup(name, "n", len(samples[i]), "", "count")
+				res.AddGroup(name, "mean", stats.Mean(samples[i]), o.unit, "arithmetic mean")
+				res.AddGroup(name, "std_dev", stats.StdDev(samples[i]), o.unit, "sample (n-1)")
+			}
+			t, err = stats.WelchT(samples[0], samples[1])
+		case "chisq":
+			if err := o.needColumns(2, 2); err != nil {
+				return err
+			}
+			var ct *crosstab.Table
+			ct, kinds, err = o.tabulate()
+			if err != nil {
+				return err
+			}
+			rowLevels, colLevels := ct.Levels[0], ct.Levels[1]
+			observed := ct.Layers()[0].Counts
+			null = fmt.Sprintf("%s and %s are independent", o.columnName(o.columns[0]), o.columnName(o.columns[1]))
+			alternative = "greater"
+			res = report.New("stats test", o.dataset(kinds...))
+			var expected [][]float64
+			t, expected, err = stats.ChiSquareIndependence(observed)
+			if err == nil {
+				res.Tables = append(res.Tables,
+					countTable("observed", o.columnName(o.columns[0]), rowLevels, colLevels, observed),
+					countTable("expected", o.columnName(o.columns[0]), rowLevels, colLevels, expected))
+				if o.text() {
+					printCounts("Observed counts", o.columnName(o.columns[0]), rowLevels, colLevels, observed, 0)
+					printCounts("Expected counts under independence", o.columnName(o.columns[0]), rowLevels, colLevels, expected, o.precision)
+				}
+			}
+		case "":
+			return badInput("no test given: use -kind t, welch or chisq")
+		default:
+			return badInput("unknown -kind %q (want t, welch or chisq)", *kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		conclusion := fmt.Sprintf("fail to reject H0 at alpha = %g", *alpha)
+		if t.Reject(*alpha) {
+			conclusion = fmt.Sprintf("reject H0 at alpha = %g", *alpha)
+		}
+		res.Tests = append(res.Tests, report.Test{
+			Name:        t.Name,
+			Statistic:   t.Statistic,
+			Value:       t.Value,
+			DF:          t.DF,
+			PValue:      t.PValue,
+			Alternative: alternative,
+			Conclusion:  conclusion,
+			Effects:     effects(t.Effects),
+		})
+		if !o.text() {
+			return res.Write(os.Stdout)
+		}
+
+		fmt.Println(capitalize(t.Name))
+		fmt.Println("H0:", null)
+		for _, s := range res.Statistics {
+			label := s.Name
+			if s.Group != "" {
+				label = s.Group + " " + s.Name
+			}
+			switch v := s.Value.(type) {
+			case float64:
+				fmt.Printf("  %s = %s\n", label, o.num(v))
+			default:
+				fmt.Printf("  %s = %v\n", label, v)
+			}
+		}
+		df := strconv.FormatFloat(t.DF[0], 'f', -1, 64)
+		if t.DF[0] != math.Trunc(t.DF[0]) {
+			df = o.num(t.DF[0])
+		}
+		fmt.Printf("%s = %s, df = %s, p = %s (%s)\n", t.Statistic, o.num(t.Value), df, o.num(t.PValue), alternative)
+		fmt.Println("Conclusion:", conclusion)
+		fmt.Println("Effect sizes:")
+		o.printEffects("  ", t.Effects)
+		return nil
+	}
+}
+
+// effects returns the effect sizes es for a report.
+func effects(es []stats.EffectSize) []report.Effect {
+	out := make([]report.Effect, len(es))
+	for i, e := range es {
+		out[i] = report.Effect{
+			Name:      e.Name,
+			Symbol:    e.Symbol,
+			Value:     e.Value,
+			Lower:     e.Lower,
+			Upper:     e.Upper,
+			Level:     e.Level,
+			Magnitude: e.Magnitude,
+		}
+	}
+	return out
+}
+
+// printEffects prints the effect sizes es one per line after indent,
+// e.g. "Cohen's d = 0.52 [95% CI 0.10, 0.94], medium".
+func (o *options) printEffects(indent string, es []stats.EffectSize) {
+	for _, e := range es {
+		name := e.Name
+		if !strings.HasSuffix(name, " "+e.Symbol) {
+			name += " " + e.Symbol
+		}
+		ci := ""
+		if !math.IsNaN(e.Lower) {
+			ci = fmt.Sprintf(" [%g%% CI %s, %s]", 100*e.Level, o.num(e.Lower), o.num(e.Upper))
+		}
+		fmt.Printf("%s%s = %s%s, %s\n", indent, name, o.num(e.Value), ci, e.Magnitude)
+	}
+}
+
+func countTable(name, rowName string, rowLevels, colLevels []string, counts [][]float64) report.Table {
+	t := report.Table{Name: name, Columns: append([]string{rowName}, colLevels...)}
+	for i, l := range rowLevels {
+		row := []any{l}
+		for _, v := range counts[i] {
+			row = append(row, v)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func printCounts(title, rowName string, rowLevels, colLevels []string, counts [][]float64, prec int) {
+	fmt.Println(title + ":")
+	rows := make([][]string, len(rowLevels))
+	for i, l := range rowLevels {
+		rows[i] = []string{l}
+		for _, v := range counts[i] {
+			rows[i] = append(rows[i], strconv.FormatFloat(v, 'f', prec, 64))
+		}
+	}
+	printTable(append([]string{rowName}, colLevels...), rows)
+	fmt.Println()
+}
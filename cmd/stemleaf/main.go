@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/textplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+)
+
+func main() {
+	dataset := flag.String("dataset", "student_marks", "dataset: "+strings.Join(datasets.Names(), ", "))
+	kind := flag.String("kind", "stem", "display: stem (stem-and-leaf), b2b (back-to-back, apple_quality only) or dot")
+	unit := flag.Float64("unit", 0, "leaf unit (stem) or column width (dot); 0 chooses automatically")
+	split := flag.Int("split", 0, "lines per stem: 1, 2 or 5; 0 chooses automatically")
+	out := plots.OutputFlags("")
+	format := report.FormatFlag()
+	flag.Parse()
+
+	ds, err := datasets.Get(*dataset)
+	if err != nil {
+		log.Fatal(err)
+	}
+	v := ds.Main()
+	data, err := ds.Floats(v.Name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	title := ds.Label(v)
+
+	// The groups of the display: the whole data, or for the back-to-back
+	// display the two groups of a two-level nominal variable, such as
+	// the quality of the apples.
+	names, groups := []string{*dataset}, [][]float64{data}
+	if *kind == "b2b" {
+		by := ""
+		for _, w := range ds.Variables {
+			if w.Kind == datasets.Nominal && len(w.Levels) == 2 {
+				by = w.Name
+				break
+			}
+		}
+		if by == "" {
+			log.Fatal("the back-to-back display needs two groups; use -dataset apple_quality")
+		}
+		if names, groups, err = ds.Split(v.Name, by); err != nil {
+			log.Fatal(err)
+		}
+		title = fmt.Sprintf("%s by %s", title, strings.ToUpper(by[:1])+by[1:])
+	}
+
+	if *format == report.JSON {
+		if err := writeResult(*dataset, title, *kind, data, names, groups, *unit, *split); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var text string
+	switch *kind {
+	case "stem":
+		s, err := textplot.NewStemLeaf(data, *unit, *split)
+		if err != nil {
+			log.Fatal(err)
+		}
+		text = s.String()
+		title = "Stem-and-leaf: " + title
+	case "b2b":
+		text, err = textplot.BackToBack(groups[0], groups[1], names[0], names[1], *unit, *split)
+		if err != nil {
+			log.Fatal(err)
+		}
+		title = "Back-to-back stem-and-leaf: " + title
+	case "dot":
+		d, err := textplot.NewDotPlot(data, *unit)
+		if err != nil {
+			log.Fatal(err)
+		}
+		text = d.String()
+		title = "Dot plot: " + title
+		if out.File != "" {
+			// Dot plots are drawn as a real chart rather than as text.
+			p := plot.New()
+			p.Title.Text = title
+			p.HideY()
+			dots, err := plots.NewDotPlot(d)
+			if err != nil {
+				log.Fatal(err)
+			}
+			p.Add(dots)
+			// Keep short stacks near the axis rather than stretched.
+			top := 0
+			for _, c := range d.Counts {
+				top = max(top, c)
+			}
+			p.Y.Min, p.Y.Max = 0.5, float64(max(top, 8))+0.5
+			file, err := out.Save(p, 8*vg.Inch, 3*vg.Inch)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(title)
+			fmt.Print(text)
+			fmt.Println("Saved", file)
+			return
+		}
+	default:
+		log.Fatalf("unknown display %q", *kind)
+	}
+
+	fmt.Println(title)
+	fmt.Println()
+	fmt.Print(text)
+	if out.File != "" {
+		file, err := out.SaveText(text, title)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("Saved", file)
+	}
+}
+
+// writeResult writes the rows of the display as JSON tables: stems and
+// leaves, one table per group for the back-to-back display, or the
+// column counts of the dot plot.
+func writeResult(dataset, title, kind string, data []float64, names []string, groups [][]float64, unit float64, split int) error {
+	res := report.New("stemleaf", report.Dataset{
+		Name:        dataset,
+		Description: title,
+		N:           len(data),
+		Variables:   []report.Variable{{Name: title, Kind: "numeric"}},
+	})
+	switch kind {
+	case "stem", "b2b":
+		// Groups share the leaf unit and split chosen for the pooled data.
+		pooled, err := textplot.NewStemLeaf(data, unit, split)
+		if err != nil {
+			return err
+		}
+		res.Add("leaf_unit", pooled.LeafUnit, "", "value of one leaf digit")
+		res.Add("split", pooled.Split, "", "lines per stem")
+		for i, name := range names {
+			s, err := textplot.NewStemLeaf(groups[i], pooled.LeafUnit, pooled.Split)
+			if err != nil {
+				return err
+			}
+			table := report.Table{Name: name, Columns: []string{"stem", "negative", "part", "leaves"}}
+			for _, l := range s.Lines {
+				leaves := l.Leaves
+				if leaves == nil {
+					leaves = []int{}
+				}
+				table.Rows = append(table.Rows, []any{l.Stem, l.Negative, l.Part, leaves})
+			}
+			res.Tables = append(res.Tables, table)
+		}
+	case "dot":
+		d, err := textplot.NewDotPlot(data, unit)
+		if err != nil {
+			return err
+		}
+		res.Add("column_width", d.Unit, "", "resolution of the number line")
+		table := report.Table{Name: dataset, Columns: []string{"value", "count"}}
+		for i, c := range d.Counts {
+			table.Rows = append(table.Rows, []any{d.Value(i), c})
+		}
+		res.Tables = append(res.Tables, table)
+	default:
+		return fmt.Errorf("unknown display %q", kind)
+	}
+	return res.Write(os.Stdout)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mayura-andrew/applied-statistics/explain"
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+// writeLaTeX writes the step-by-step analysis to file as a LaTeX
+// document, with the derivations as align environments.
+func writeLaTeX(file string, data []int) error {
+	n := len(data)
+	page := &report.Page{
+		Title:    "Analyzing Weekly Work Hours",
+		Subtitle: fmt.Sprintf("%d employees", n),
+	}
+
+	s := page.Section("Data")
+	s.Para("Weekly work hours of employees 1 to %d:", n)
+	t := report.Table{Name: "Work hours", Columns: []string{"Employees"}}
+	for j := 1; j <= 10; j++ {
+		t.Columns = append(t.Columns, fmt.Sprint(j))
+	}
+	for i := 0; i < n; i += 10 {
+		row := []any{fmt.Sprintf("%d–%d", i+1, min(i+10, n))}
+		for _, v := range data[i:min(i+10, n)] {
+			row = append(row, v)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	s.AddTable(t)
+
+	x := floats(data)
+	s = page.Section("1. Mean")
+	s.Para("The mean is the sum of all values divided by the number of values.")
+	explain.Mean(x, hoursOptions).AddTo(s, level)
+
+	s = page.Section("2. Median")
+	s.Para("The median is the middle value when the data are arranged in order.")
+	explain.Median(x, hoursOptions).AddTo(s, level)
+
+	s = page.Section("3. Mode")
+	s.Para("The mode is the value, or values, that appear most frequently.")
+	explain.Mode(x, hoursOptions).AddTo(s, level)
+
+	sd := explain.StdDev(x, true, hoursOptions)
+	mean, stdDev := getMean(data), sd.Value.(float64)
+	s = page.Section("4. Variance and Standard Deviation")
+	s.Para("The population variance is the average squared distance from the mean; the standard deviation is its square root.")
+	sd.AddTo(s, level)
+	k := withinOneSD(data)
+	s.Para("%d of the %d employees (%.0f%%) work between %.2f and %.2f hours, within one standard deviation of the mean, against about 68%% for a normal distribution.", k, len(data), 100*float64(k)/float64(len(data)), mean-stdDev, mean+stdDev)
+
+	ivs, err := intervals(data)
+	if err != nil {
+		return err
+	}
+	s = page.Section("5. Confidence Intervals")
+	s.Para("Taking the employees as a random sample of a larger workforce, these intervals cover the workforce's values with 95%% confidence. The interval for the median is distribution-free; the one for the standard deviation assumes normal hours.")
+	ci := report.Table{Name: "95% confidence intervals", Columns: []string{"Parameter", "Estimate", "Lower", "Upper", "Method"}}
+	for _, iv := range ivs {
+		ci.Rows = append(ci.Rows, []any{iv.Parameter, iv.Estimate, iv.Lower, iv.Upper, iv.Method})
+	}
+	s.AddTable(ci)
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := page.WriteLaTeX(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,16 +1,54 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
+	"math"
+	"os"
 	"sort"
+
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/explain"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
 )
 
+// level is how much of each derivation is shown.
+var level = explain.Full
+
 func main() {
+	format := report.FormatFlag()
+	tex := flag.String("tex", "", "also write the derivations to this file as a LaTeX document")
+	flag.Var(&level, "explain", "detail of the derivations: answer, key or full")
+	flag.BoolVar(&hoursOptions.Exact, "exact", false, "compute the derivations with exact fractions, flagging where rounding first occurs")
+	flag.IntVar(&hoursOptions.Sig, "sig", 6, "significant figures of the rounded decimals with -exact")
+	flag.Parse()
+
 	// Employee work hours data (30 employees)
-	workHours := []int{
-		38, 42, 35, 40, 44, 37, 41, 39, 45, 36,
-		43, 38, 40, 42, 35, 44, 39, 41, 37, 43,
-		36, 45, 38, 40, 42, 39, 41, 37, 44, 40,
+	ds, err := datasets.Get("work_hours")
+	if err != nil {
+		log.Fatal(err)
+	}
+	workHours, err := ds.Ints("hours")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *tex != "" {
+		if err := writeLaTeX(*tex, workHours); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *format == report.JSON {
+		if err := writeResult(ds, workHours); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *tex != "" {
+		fmt.Println("LaTeX document has been saved to", *tex)
+		fmt.Println()
 	}
 
 	fmt.Println("═══════════════════════════════════════════════════════════")
@@ -37,6 +75,14 @@ func main() {
 	// Calculate and display Variance and Standard Deviation
 	calculateVarianceAndStdDev(workHours)
 
+	// Check whether the tied modes point to several peaks
+	assessModality(workHours)
+
+	// What the sample says about the whole workforce
+	if err := printConfidenceIntervals(workHours); err != nil {
+		log.Fatal(err)
+	}
+
 	// Summary
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println("                    SUMMARY")
@@ -63,44 +109,30 @@ func main() {
 	fmt.Println("═══════════════════════════════════════════════════════════")
 }
 
+// hoursOptions shows the derivations in hours.
+var hoursOptions = explain.Options{Unit: "hours"}
+
+// floats returns data as float64s.
+func floats(data []int) []float64 {
+	x := make([]float64, len(data))
+	for i, v := range data {
+		x[i] = float64(v)
+	}
+	return x
+}
+
 func calculateMean(data []int) {
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println("                    1. MEAN (Average)")
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println()
 	fmt.Println("The mean is the average of all values.")
-	fmt.Println("Formula: Mean = (Sum of all values) / (Number of values)")
-	fmt.Println()
-
-	n := len(data)
-	var sum int
-
-	fmt.Println("Step 1: Add all the work hours together")
-	fmt.Print("        Sum = ")
-	for i, hours := range data {
-		sum += hours
-		if i < len(data)-1 {
-			fmt.Printf("%d + ", hours)
-		} else {
-			fmt.Printf("%d", hours)
-		}
-		if (i+1)%10 == 0 && i < len(data)-1 {
-			fmt.Println()
-			fmt.Print("              ")
-		}
-	}
-	fmt.Println()
-	fmt.Printf("        Sum = %d hours\n", sum)
 	fmt.Println()
 
-	mean := float64(sum) / float64(n)
-
-	fmt.Printf("Step 2: Divide the sum by the number of employees\n")
-	fmt.Printf("        Mean = %d / %d = %.4f hours\n", sum, n, mean)
-	fmt.Println()
-	fmt.Printf("✓ Mean = %.2f hours per week\n", mean)
+	d := explain.Mean(floats(data), hoursOptions)
+	d.Write(os.Stdout, explain.Text, level)
 	fmt.Println()
-	fmt.Printf("Interpretation: On average, employees work %.2f hours per week.\n", mean)
+	fmt.Printf("Interpretation: On average, employees work %.2f hours per week.\n", d.Value)
 	fmt.Println()
 }
 
@@ -113,51 +145,9 @@ func calculateMedian(data []int) {
 	fmt.Println("If there's an even number of values, it's the average of the two middle values.")
 	fmt.Println()
 
-	n := len(data)
-	sorted := make([]int, n)
-	copy(sorted, data)
-	sort.Ints(sorted)
-
-	fmt.Println("Step 1: Arrange all values in ascending order")
-	fmt.Println("Sorted work hours:")
-	for i, hours := range sorted {
-		if i > 0 && i%10 == 0 {
-			fmt.Println()
-		}
-		fmt.Printf("%d ", hours)
-	}
-	fmt.Println()
-	fmt.Println()
-
-	var median float64
-
-	fmt.Printf("Step 2: Find the middle position(s)\n")
-	fmt.Printf("        Number of values (n) = %d (even number)\n", n)
-	fmt.Printf("        Middle positions: %d and %d\n", n/2, (n/2)+1)
-	fmt.Println()
-
-	if n%2 == 0 {
-		// Even number of values
-		mid1Idx := (n / 2) - 1
-		mid2Idx := n / 2
-		mid1 := sorted[mid1Idx]
-		mid2 := sorted[mid2Idx]
-		median = float64(mid1+mid2) / 2.0
-
-		fmt.Printf("Step 3: Since we have an even number of values, average the two middle values\n")
-		fmt.Printf("        Value at position %d: %d hours\n", mid1Idx+1, mid1)
-		fmt.Printf("        Value at position %d: %d hours\n", mid2Idx+1, mid2)
-		fmt.Printf("        Median = (%d + %d) / 2 = %.1f hours\n", mid1, mid2, median)
-	} else {
-		// Odd number of values
-		midIdx := n / 2
-		median = float64(sorted[midIdx])
-		fmt.Printf("Step 3: The middle value is at position %d\n", midIdx+1)
-		fmt.Printf("        Median = %.1f hours\n", median)
-	}
-
-	fmt.Println()
-	fmt.Printf("✓ Median = %.1f hours per week\n", median)
+	d := explain.Median(floats(data), hoursOptions)
+	d.Write(os.Stdout, explain.Text, level)
+	median := d.Value.(float64)
 	fmt.Println()
 	fmt.Println("Interpretation: Half of the employees work less than or equal to")
 	fmt.Printf("%.1f hours, and half work more than or equal to %.1f hours.\n", median, median)
@@ -172,82 +162,25 @@ func calculateMode(data []int) {
 	fmt.Println("The mode is the value(s) that appear most frequently in the dataset.")
 	fmt.Println()
 
-	// Create frequency map
-	frequency := make(map[int]int)
-	for _, hours := range data {
-		frequency[hours]++
-	}
-
-	// Sort keys for organized display
-	keys := make([]int, 0, len(frequency))
-	for k := range frequency {
-		keys = append(keys, k)
-	}
-	sort.Ints(keys)
-
-	fmt.Println("Step 1: Count how many times each value appears")
+	d := explain.Mode(floats(data), hoursOptions)
+	d.Write(os.Stdout, explain.Text, level)
 	fmt.Println()
-	fmt.Println("        Work Hours | Frequency (Count)")
-	fmt.Println("        -----------|------------------")
-
-	maxFreq := 0
-	for _, hours := range keys {
-		count := frequency[hours]
-		fmt.Printf("             %2d    |        %d\n", hours, count)
-		if count > maxFreq {
-			maxFreq = count
-		}
-	}
-	fmt.Println()
-
-	fmt.Printf("Step 2: Identify the highest frequency\n")
-	fmt.Printf("        Highest frequency = %d\n", maxFreq)
-	fmt.Println()
-
-	// Find all modes (values with highest frequency)
-	modes := []int{}
-	for _, hours := range keys {
-		if frequency[hours] == maxFreq {
-			modes = append(modes, hours)
+	modes := d.Value.([]float64)
+	count := 0
+	for _, v := range data {
+		if len(modes) > 0 && float64(v) == modes[0] {
+			count++
 		}
 	}
-
-	fmt.Printf("Step 3: Find all values with the highest frequency\n")
-
-	if maxFreq == 1 {
-		fmt.Println("        All values appear exactly once.")
-		fmt.Println("        There is NO MODE (no value repeats).")
-		fmt.Println()
-		fmt.Println("✓ Mode: None (all values are unique)")
-	} else if len(modes) == 1 {
-		fmt.Printf("        The value %d appears %d times (most frequent)\n", modes[0], maxFreq)
-		fmt.Println()
-		fmt.Printf("✓ Mode = %d hours per week\n", modes[0])
-		fmt.Println()
-		fmt.Printf("Interpretation: %d hours is the most common weekly work time,\n", modes[0])
-		fmt.Printf("appearing %d times in the dataset.\n", maxFreq)
-	} else {
-		fmt.Printf("        Multiple values appear %d times (tied for most frequent):\n", maxFreq)
-		fmt.Print("        ")
-		for i, mode := range modes {
-			if i > 0 {
-				fmt.Print(", ")
-			}
-			fmt.Printf("%d hours", mode)
-		}
-		fmt.Println()
-		fmt.Println()
-		fmt.Print("✓ Modes (multimodal) = ")
-		for i, mode := range modes {
-			if i > 0 {
-				fmt.Print(", ")
-			}
-			fmt.Printf("%d hours", mode)
-		}
-		fmt.Println()
-		fmt.Println()
+	switch len(modes) {
+	case 0:
+		fmt.Println("Interpretation: No work time is more common than any other.")
+	case 1:
+		fmt.Printf("Interpretation: %.0f hours is the most common weekly work time,\n", modes[0])
+		fmt.Printf("appearing %d times in the dataset.\n", count)
+	default:
 		fmt.Printf("Interpretation: The dataset has multiple modes. These values\n")
-		fmt.Printf("each appear %d times, which is the highest frequency.\n", maxFreq)
+		fmt.Printf("each appear %d times, which is the highest frequency.\n", count)
 	}
 	fmt.Println()
 }
@@ -261,137 +194,220 @@ func calculateVarianceAndStdDev(data []int) {
 	fmt.Println("They tell us how much the work hours vary from the average.")
 	fmt.Println()
 
-	n := len(data)
+	x := floats(data)
+	mean := explain.Mean(x, hoursOptions).Value.(float64)
+	variance := explain.Variance(x, true, hoursOptions).Value.(float64)
 
-	// Calculate mean first
-	var sum int
-	for _, hours := range data {
-		sum += hours
-	}
-	mean := float64(sum) / float64(n)
+	// With all 30 employees as the population, the variance divides by n.
+	sd := explain.StdDev(x, true, hoursOptions)
+	sd.Write(os.Stdout, explain.Text, level)
+	stdDev := sd.Value.(float64)
+	fmt.Println()
 
-	// --- VARIANCE ---
-	fmt.Println("--- VARIANCE (σ²) ---")
+	// Interpretation
+	fmt.Println("--- INTERPRETATION ---")
 	fmt.Println()
-	fmt.Println("Variance measures the average squared distance from the mean.")
-	fmt.Println("Formula: σ² = Σ(xi - μ)² / n")
-	fmt.Println("         where xi = each value, μ = mean, n = number of values")
+	fmt.Printf("Variance (σ²) = %.4f hours²\n", variance)
+	fmt.Printf("  - This measures the average squared deviation from the mean.\n")
+	fmt.Printf("  - The squared units make it less intuitive to interpret directly.\n")
 	fmt.Println()
-
-	fmt.Printf("Step 1: We already know the mean (μ) = %.4f hours\n", mean)
+	fmt.Printf("Standard Deviation (σ) = %.4f hours\n", stdDev)
+	fmt.Printf("  - On average, work hours deviate from the mean by about %.2f hours.\n", stdDev)
+	fmt.Printf("  - This tells us the 'typical' distance from the average of %.2f hours.\n", mean)
 	fmt.Println()
-
-	fmt.Println("Step 2: Calculate (xi - μ)² for each employee's work hours:")
+	// Context
+	fmt.Println("What does this mean?")
+	if stdDev < 2.0 {
+		fmt.Println("  - LOW variability: Work hours are very consistent across employees.")
+		fmt.Println("  - Most employees work very close to the average.")
+	} else if stdDev < 4.0 {
+		fmt.Println("  - MODERATE variability: There is some variation in work hours.")
+		fmt.Println("  - Most employees work within a reasonable range of the average.")
+	} else {
+		fmt.Println("  - HIGH variability: Work hours vary significantly across employees.")
+		fmt.Println("  - Employees' work schedules differ substantially.")
+	}
 	fmt.Println()
-	fmt.Println("        Employee | Hours | (Hours - Mean) | (Hours - Mean)²")
-	fmt.Println("        ---------|-------|----------------|----------------")
 
-	var sumSquaredDiff float64
-	for i, hours := range data {
-		diff := float64(hours) - mean
-		sqDiff := diff * diff
-		sumSquaredDiff += sqDiff
+	// Range interpretation: the 68% of the normal distribution, checked
+	// against the data.
+	k := withinOneSD(data)
+	fmt.Printf("%d of the %d employees (%.0f%%) work between %.2f and %.2f hours\n",
+		k, len(data), 100*float64(k)/float64(len(data)), mean-stdDev, mean+stdDev)
+	fmt.Printf("(within 1 standard deviation of the mean), against about 68%% for a\n")
+	fmt.Printf("normal distribution. This describes these employees; for what they\n")
+	fmt.Printf("tell us about the workforce they come from, see the confidence intervals.\n")
+	fmt.Println()
+}
 
-		// Show first 10 and last 5 to keep output manageable
-		if i < 10 || i >= n-5 {
-			fmt.Printf("           %2d    |  %2d   |   %8.4f     |   %10.4f\n", i+1, hours, diff, sqDiff)
-		} else if i == 10 {
-			fmt.Println("          ...    |  ...  |      ...       |       ...")
+// withinOneSD counts the values within one population standard
+// deviation of the mean.
+func withinOneSD(data []int) int {
+	mean, sd := getMean(data), getStdDev(data)
+	k := 0
+	for _, v := range data {
+		if math.Abs(float64(v)-mean) <= sd {
+			k++
 		}
 	}
+	return k
+}
 
+func printConfidenceIntervals(data []int) error {
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	fmt.Println("     6. CONFIDENCE INTERVALS (95%)")
+	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println()
-	fmt.Printf("Step 3: Sum all the squared differences\n")
-	fmt.Printf("        Σ(xi - μ)² = %.4f\n", sumSquaredDiff)
+	fmt.Println("Taking the 30 employees as a random sample of a larger workforce,")
+	fmt.Println("these intervals cover the workforce's values with 95% confidence.")
 	fmt.Println()
-
-	variance := sumSquaredDiff / float64(n)
-
-	fmt.Printf("Step 4: Divide by n to get the variance\n")
-	fmt.Printf("        Variance (σ²) = %.4f / %d = %.4f hours²\n", sumSquaredDiff, n, variance)
+	ivs, err := intervals(data)
+	if err != nil {
+		return err
+	}
+	fmt.Println("        Parameter           |  Estimate |  95% interval     | Method")
+	fmt.Println("        --------------------|-----------|-------------------|--------------------")
+	for _, iv := range ivs {
+		fmt.Printf("        %-19s |   %6.2f  | %6.2f to %6.2f  | %s\n", iv.Parameter, iv.Estimate, iv.Lower, iv.Upper, iv.Method)
+	}
 	fmt.Println()
-	fmt.Printf("✓ Variance = %.4f hours²\n", variance)
+	fmt.Println("The t interval for the mean relies on the mean being near normal,")
+	fmt.Println("which 30 values of a unimodal distribution make reasonable. The")
+	fmt.Println("chi-square interval for the SD assumes normal hours, while the one")
+	fmt.Printf("for the median makes no assumption about their shape and covers it\nwith probability %.1f%%.\n", 100*ivs[2].Coverage.(float64))
 	fmt.Println()
+	return nil
+}
 
-	// --- STANDARD DEVIATION ---
-	fmt.Println("--- STANDARD DEVIATION (σ) ---")
-	fmt.Println()
-	fmt.Println("Standard deviation is the square root of variance.")
-	fmt.Println("It's easier to interpret because it's in the same units as the data (hours).")
-	fmt.Println("Formula: σ = √(σ²)")
-	fmt.Println()
+// intervals returns 95% confidence intervals for the mean, standard
+// deviation and median hours of the workforce the employees are a
+// sample of.
+func intervals(data []int) ([]report.Interval, error) {
+	x := floats(data)
+	mean, err := stats.MeanTInterval(x, 0.95)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := stats.StdDevInterval(x, 0.95)
+	if err != nil {
+		return nil, err
+	}
+	median, err := stats.MedianInterval(x, 0.95)
+	if err != nil {
+		return nil, err
+	}
+	var ivs []report.Interval
+	for i, iv := range []stats.Interval{mean, sd, median} {
+		ivs = append(ivs, report.Interval{
+			Parameter: []string{"mean", "standard deviation", "median"}[i],
+			Variable:  "Hours",
+			Estimate:  iv.Estimate,
+			Lower:     iv.Lower,
+			Upper:     iv.Upper,
+			Level:     iv.Level,
+			Coverage:  iv.Coverage,
+			Unit:      "hours",
+			Method:    iv.Name,
+		})
+	}
+	return ivs, nil
+}
 
-	stdDev := 0.0
-	// Manual square root calculation visualization
-	fmt.Printf("Step 1: Take the square root of the variance\n")
-	fmt.Printf("        σ = √(%.4f)\n", variance)
+func assessModality(data []int) {
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	fmt.Println("     5. MODALITY (Kernel Density Estimate)")
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	fmt.Println()
+	if len(getModes(data)) > 1 {
+		fmt.Println("Several values tie for the highest frequency, so the counts alone")
+		fmt.Println("cannot tell us whether the distribution has one peak or several.")
+	}
+	fmt.Println("A kernel density estimate smooths the counts into a curve whose")
+	fmt.Println("peaks (modes) show the underlying shape.")
 	fmt.Println()
 
-	// Show the calculation
-	stdDev = 1.0
-	for i := 0; i < 10; i++ {
-		stdDev = (stdDev + variance/stdDev) / 2.0
+	x := make([]float64, len(data))
+	for i, v := range data {
+		x[i] = float64(v)
 	}
 
-	// Use actual square root for accuracy
-	import_math := func(x float64) float64 {
-		if x <= 0 {
-			return 0
+	fmt.Println("        Bandwidth rule   |  h (hours) | Density mode(s)")
+	fmt.Println("        -----------------|------------|----------------")
+	peaks := 0
+	for _, rule := range []string{"silverman", "scott", "sj"} {
+		h, err := stats.SelectBandwidth(rule, x)
+		if err != nil {
+			fmt.Printf("        %-16s |     –      | %v\n", rule, err)
+			continue
+		}
+		kde := stats.NewKDE(x)
+		kde.Bandwidth = h
+		modes := kde.Modes()
+		fmt.Printf("        %-16s |   %6.4f   | ", rule, h)
+		for i, m := range modes {
+			if i > 0 {
+				fmt.Print(", ")
+			}
+			fmt.Printf("%.2f", m)
 		}
-		// Newton's method for square root
-		guess := x / 2.0
-		for i := 0; i < 20; i++ {
-			guess = (guess + x/guess) / 2.0
+		fmt.Println()
+		if rule == "sj" {
+			peaks = len(modes)
 		}
-		return guess
 	}
-	stdDev = import_math(variance)
-
-	fmt.Printf("        σ = %.4f hours\n", stdDev)
-	fmt.Println()
-	fmt.Printf("✓ Standard Deviation = %.4f hours\n", stdDev)
 	fmt.Println()
 
-	// Interpretation
-	fmt.Println("--- INTERPRETATION ---")
-	fmt.Println()
-	fmt.Printf("Variance (σ²) = %.4f hours²\n", variance)
-	fmt.Printf("  - This measures the average squared deviation from the mean.\n")
-	fmt.Printf("  - The squared units make it less intuitive to interpret directly.\n")
-	fmt.Println()
-	fmt.Printf("Standard Deviation (σ) = %.4f hours\n", stdDev)
-	fmt.Printf("  - On average, work hours deviate from the mean by about %.2f hours.\n", stdDev)
-	fmt.Printf("  - This tells us the 'typical' distance from the average of %.2f hours.\n", mean)
-	fmt.Println()
-
-	// Context
-	fmt.Println("What does this mean?")
-	if stdDev < 2.0 {
-		fmt.Println("  - LOW variability: Work hours are very consistent across employees.")
-		fmt.Println("  - Most employees work very close to the average.")
-	} else if stdDev < 4.0 {
-		fmt.Println("  - MODERATE variability: There is some variation in work hours.")
-		fmt.Println("  - Most employees work within a reasonable range of the average.")
+	if peaks > 1 {
+		fmt.Printf("✓ The Sheather–Jones estimate has %d peaks: the distribution is MULTIMODAL.\n", peaks)
 	} else {
-		fmt.Println("  - HIGH variability: Work hours vary significantly across employees.")
-		fmt.Println("  - Employees' work schedules differ substantially.")
+		fmt.Println("✓ The Sheather–Jones estimate has a single peak: the distribution is UNIMODAL.")
+		if len(getModes(data)) > 1 {
+			fmt.Println()
+			fmt.Println("Interpretation: The tied modes in the frequency table are an artefact")
+			fmt.Println("of the small sample; the smoothed distribution has one peak.")
+		}
 	}
 	fmt.Println()
+}
 
-	// Range interpretation
-	fmt.Printf("Approximately 68%% of employees work between %.2f and %.2f hours\n",
-		mean-stdDev, mean+stdDev)
-	fmt.Printf("(within 1 standard deviation of the mean).\n")
-	fmt.Println()
+// writeResult writes the summary statistics and the density modes for
+// each bandwidth rule as JSON.
+func writeResult(ds *datasets.Dataset, data []int) error {
+	res := report.New("workhours", report.Dataset{
+		Name:        ds.Name,
+		Description: ds.Description,
+		N:           len(data),
+		Variables:   []report.Variable{{Name: "Hours", Kind: "numeric", Unit: "hours"}},
+	})
+	res.Add("mean", getMean(data), "hours", "arithmetic mean")
+	res.Add("median", getMedian(data), "hours", "middle value of the sorted data")
+	res.Add("mode", getModes(data), "hours", "most frequent values; empty when all values are unique")
+	res.Add("variance", getVariance(data), "hours²", "population (n)")
+	res.Add("std_dev", getStdDev(data), "hours", "population (n)")
+
+	x := floats(data)
+	for _, rule := range []string{"silverman", "scott", "sj"} {
+		h, err := stats.SelectBandwidth(rule, x)
+		if err != nil {
+			return err
+		}
+		kde := stats.NewKDE(x)
+		kde.Bandwidth = h
+		res.Add("kde_bandwidth", h, "hours", "gaussian kernel, "+rule+" selector")
+		res.Add("kde_modes", kde.Modes(), "hours", "local maxima of the density estimate, "+rule+" bandwidth")
+	}
+	res.Add("within_one_sd", withinOneSD(data), "", "employees within one standard deviation of the mean")
+	ivs, err := intervals(data)
+	if err != nil {
+		return err
+	}
+	res.Intervals = ivs
+	return res.Write(os.Stdout)
 }
 
 // Helper functions for summary
 func getMean(data []int) float64 {
-	var sum int
-	for _, v := range data {
-		sum += v
-	}
-	return float64(sum) / float64(len(data))
+	return stats.Mean(floats(data))
 }
 
 func getMedian(data []int) float64 {
@@ -434,33 +450,9 @@ func getModes(data []int) []int {
 }
 
 func getVariance(data []int) float64 {
-	n := len(data)
-	if n == 0 {
-		return 0
-	}
-
-	mean := getMean(data)
-	var sumSquaredDiff float64
-
-	for _, v := range data {
-		diff := float64(v) - mean
-		sumSquaredDiff += diff * diff
-	}
-
-	return sumSquaredDiff / float64(n)
+	return stats.PopVariance(floats(data))
 }
 
 func getStdDev(data []int) float64 {
-	variance := getVariance(data)
-
-	// Newton's method for square root
-	if variance <= 0 {
-		return 0
-	}
-
-	guess := variance / 2.0
-	for i := 0; i < 20; i++ {
-		guess = (guess + variance/guess) / 2.0
-	}
-	return guess
+	return math.Sqrt(getVariance(data))
 }
@@ -1,21 +1,36 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
+	"log"
+	"os"
 	"sort"
 
+	"github.com/mayura-andrew/applied-statistics/datasets"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/textplot"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
 func main() {
+	term := flag.Bool("term", false, "draw the bar chart in the terminal instead of saving a figure")
+	out := plots.OutputFlags("work_hours_distribution_annotated.png")
+	format := report.FormatFlag()
+	flag.Parse()
+
 	// Work hours data (30 employees)
-	workHours := []int{
-		38, 42, 35, 40, 44, 37, 41, 39, 45, 36,
-		43, 38, 40, 42, 35, 44, 39, 41, 37, 43,
-		36, 45, 38, 40, 42, 39, 41, 37, 44, 40,
+	ds, err := datasets.Get("work_hours")
+	if err != nil {
+		log.Fatal(err)
+	}
+	workHours, err := ds.Ints("hours")
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Compute frequency per hour
@@ -137,10 +152,37 @@ func main() {
 	p.Legend.Add("Mean", meanLine)
 	p.Legend.Add("Median", medianLine)
 
-	// Save annotated plot
-	if err := p.Save(8*vg.Inch, 4*vg.Inch, "work_hours_distribution_annotated.png"); err != nil {
+	// Print the chart with the mean and median in the terminal, or save the annotated figure
+	if *term && *format == report.Text {
+		chart := textplot.Chart{Title: p.Title.Text, XLabel: p.X.Label.Text, YLabel: "Employees"}
+		fmt.Print(chart.Bars(labels, textplot.Series{Values: values}))
+		fmt.Printf("Mean = %.2f   Median = %.1f\n", mean, median)
+		return
+	}
+	file, err := out.Save(p, 8*vg.Inch, 4*vg.Inch)
+	if err != nil {
 		panic(err)
 	}
 
-	fmt.Println("Saved work_hours_distribution_annotated.png")
+	if *format == report.JSON {
+		res := report.New("workhours_plot", report.Dataset{
+			Name:        ds.Name,
+			Description: ds.Description,
+			N:           len(workHours),
+			Variables:   []report.Variable{{Name: "Hours", Kind: "numeric", Unit: "hours"}},
+		})
+		res.Add("mean", mean, "hours", "arithmetic mean")
+		res.Add("median", median, "hours", "middle value of the sorted data")
+		table := report.Table{Name: "frequency", Columns: []string{"hours", "frequency"}}
+		for i, k := range keys {
+			table.Rows = append(table.Rows, []any{k, int(values[i])})
+		}
+		res.Tables = append(res.Tables, table)
+		res.Plots = append(res.Plots, file)
+		if err := res.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	fmt.Println("Saved", file)
 }
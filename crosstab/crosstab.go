@@ -0,0 +1,194 @@
+// Package crosstab cross-tabulates categorical variables: counts,
+// row, column and total percentages with marginal totals, the counts
+// expected under independence and standardized residuals, written as
+// text, Markdown or CSV.
+//
+// A table of more than two variables is a stack of two-way layers, one
+// for each combination of the levels of all but the last two
+// variables, which are the rows and columns of every layer.
+package crosstab
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mayura-andrew/applied-statistics/frame"
+)
+
+// Table is the cross-tabulation of the variables Names, with the
+// Levels of each. Dropped counts the observations left out because a
+// value was missing or not one of the levels.
+type Table struct {
+	Names   []string
+	Levels  [][]string
+	Dropped int
+
+	// counts holds the count of every combination of levels, the last
+	// variable varying fastest.
+	counts []float64
+}
+
+// New cross-tabulates observations: values[k][i] is the value of the
+// variable names[k] for observation i. levels[k] lists the levels of
+// variable k in order; if levels or levels[k] is nil they are its
+// distinct values, sorted as numbers if they all are and alphabetically
+// otherwise. Empty values are missing.
+func New(names []string, values [][]string, levels [][]string) (*Table, error) {
+	if len(names) < 2 {
+		return nil, errors.New("need at least 2 variables")
+	}
+	if len(values) != len(names) {
+		return nil, fmt.Errorf("%d variables but %d columns of values", len(names), len(values))
+	}
+	t := &Table{Names: names, Levels: make([][]string, len(names))}
+	index := make([]map[string]int, len(names))
+	for k, v := range values {
+		if len(v) != len(values[0]) {
+			return nil, fmt.Errorf("variable %s has %d values, want %d", names[k], len(v), len(values[0]))
+		}
+		if k < len(levels) && levels[k] != nil {
+			t.Levels[k] = levels[k]
+		} else {
+			c, err := frame.NewNominal(names[k], nil, v)
+			if err != nil {
+				return nil, err
+			}
+			t.Levels[k] = c.Levels
+		}
+		if len(t.Levels[k]) == 0 {
+			return nil, fmt.Errorf("variable %s has no values", names[k])
+		}
+		index[k] = make(map[string]int, len(t.Levels[k]))
+		for i, l := range t.Levels[k] {
+			index[k][l] = i
+		}
+	}
+	t.counts = make([]float64, t.cells())
+obs:
+	for i := range values[0] {
+		cell := 0
+		for k := range names {
+			j, ok := index[k][values[k][i]]
+			if !ok {
+				t.Dropped++
+				continue obs
+			}
+			cell = cell*len(t.Levels[k]) + j
+		}
+		t.counts[cell]++
+	}
+	return t, nil
+}
+
+// FromFrame cross-tabulates the categorical columns of f called names,
+// with the levels of each column in their order. Rows missing a value
+// are left out.
+func FromFrame(f *frame.Frame, names ...string) (*Table, error) {
+	values := make([][]string, len(names))
+	levels := make([][]string, len(names))
+	for k, name := range names {
+		c, err := f.Column(name)
+		if err != nil {
+			return nil, err
+		}
+		if !c.Kind.Categorical() {
+			return nil, fmt.Errorf("column %s is %s, not categorical", name, c.Kind)
+		}
+		values[k], levels[k] = make([]string, c.Len()), c.Levels
+		for i := range values[k] {
+			if !c.IsNA(i) {
+				values[k][i] = c.String(i)
+			}
+		}
+	}
+	return New(names, values, levels)
+}
+
+// cells returns the number of combinations of levels.
+func (t *Table) cells() int {
+	n := 1
+	for _, l := range t.Levels {
+		n *= len(l)
+	}
+	return n
+}
+
+// Layer is one two-way table of a cross-tabulation: the counts of its
+// rows by its columns among the observations with the levels Key of
+// the layer variables. Key is empty for a two-way table.
+type Layer struct {
+	Key    []string
+	Counts [][]float64
+}
+
+// Layers returns the two-way layers of t, the first layer variable
+// varying slowest.
+func (t *Table) Layers() []Layer {
+	k := len(t.Names)
+	rows, cols := len(t.Levels[k-2]), len(t.Levels[k-1])
+	var out []Layer
+	for start := 0; start < len(t.counts); start += rows * cols {
+		l := Layer{Key: make([]string, k-2), Counts: make([][]float64, rows)}
+		for i := range l.Counts {
+			l.Counts[i] = t.counts[start+i*cols : start+(i+1)*cols]
+		}
+		// The index of the layer, in the mixed radix of the levels.
+		n := start / (rows * cols)
+		for j := k - 3; j >= 0; j-- {
+			l.Key[j] = t.Levels[j][n%len(t.Levels[j])]
+			n /= len(t.Levels[j])
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+// Totals returns the row, column and grand totals of l.
+func (l Layer) Totals() (rows, cols []float64, n float64) {
+	rows = make([]float64, len(l.Counts))
+	cols = make([]float64, len(l.Counts[0]))
+	for i, row := range l.Counts {
+		for j, v := range row {
+			rows[i] += v
+			cols[j] += v
+			n += v
+		}
+	}
+	return rows, cols, n
+}
+
+// Expected returns the counts expected if the rows and columns of l
+// were independent, row total × column total / n.
+func (l Layer) Expected() [][]float64 {
+	rows, cols, n := l.Totals()
+	e := make([][]float64, len(rows))
+	for i := range e {
+		e[i] = make([]float64, len(cols))
+		for j := range cols {
+			e[i][j] = rows[i] * cols[j] / n
+		}
+	}
+	return e
+}
+
+// Residuals returns the standardized residuals of l,
+// (o − e)/√(e (1 − row total/n)(1 − column total/n)), which are about
+// standard normal under independence: cells beyond ±2 stand out. They
+// are NaN where the expected count is zero.
+func (l Layer) Residuals() [][]float64 {
+	rows, cols, n := l.Totals()
+	e := l.Expected()
+	r := make([][]float64, len(rows))
+	for i := range r {
+		r[i] = make([]float64, len(cols))
+		for j, o := range l.Counts[i] {
+			v := e[i][j] * (1 - rows[i]/n) * (1 - cols[j]/n)
+			r[i][j] = math.NaN()
+			if v > 0 {
+				r[i][j] = (o - e[i][j]) / math.Sqrt(v)
+			}
+		}
+	}
+	return r
+}
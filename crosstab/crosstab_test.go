@@ -0,0 +1,150 @@
+package crosstab
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// ucb are the 1973 graduate admissions of the six largest departments
+// of the University of California, Berkeley, from Bickel, Hammel and
+// O'Connell (1975), as in R's UCBAdmissions: by department, the
+// admitted and rejected men and women.
+var ucb = []struct {
+	dept   string
+	counts [2][2]int
+}{
+	{"A", [2][2]int{{512, 89}, {313, 19}}},
+	{"B", [2][2]int{{353, 17}, {207, 8}}},
+	{"C", [2][2]int{{120, 202}, {205, 391}}},
+	{"D", [2][2]int{{138, 131}, {279, 244}}},
+	{"E", [2][2]int{{53, 94}, {138, 299}}},
+	{"F", [2][2]int{{22, 24}, {351, 317}}},
+}
+
+// ucbValues returns the applicants of ucb one by one, interleaving the
+// departments so that the order of the observations differs from that
+// of the cells, with the values of the variables dept, admit and
+// gender.
+func ucbValues() [][]string {
+	admit, gender := []string{"Admitted", "Rejected"}, []string{"Male", "Female"}
+	left := make([][2][2]int, len(ucb))
+	for d := range ucb {
+		left[d] = ucb[d].counts
+	}
+	values := make([][]string, 3)
+	for more := true; more; {
+		more = false
+		for d := range ucb {
+			for i := range 2 {
+				for j := range 2 {
+					if left[d][i][j] > 0 {
+						left[d][i][j]--
+						values[0] = append(values[0], ucb[d].dept)
+						values[1] = append(values[1], admit[i])
+						values[2] = append(values[2], gender[j])
+						more = true
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestThreeWayCounts(t *testing.T) {
+	values := ucbValues()
+	levels := [][]string{nil, {"Admitted", "Rejected"}, {"Male", "Female"}}
+	tab, err := New([]string{"dept", "admit", "gender"}, values, levels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"A", "B", "C", "D", "E", "F"}; !slices.Equal(tab.Levels[0], want) {
+		t.Errorf("departments %v, want %v", tab.Levels[0], want)
+	}
+	layers := tab.Layers()
+	if len(layers) != len(ucb) {
+		t.Fatalf("%d layers, want %d", len(layers), len(ucb))
+	}
+	var total float64
+	for d, l := range layers {
+		if !slices.Equal(l.Key, []string{ucb[d].dept}) {
+			t.Errorf("layer %d has key %v, want [%s]", d, l.Key, ucb[d].dept)
+		}
+		for i := range 2 {
+			for j := range 2 {
+				if got, want := l.Counts[i][j], float64(ucb[d].counts[i][j]); got != want {
+					t.Errorf("department %s, cell (%d, %d) = %g, want %g", ucb[d].dept, i, j, got, want)
+				}
+			}
+		}
+		_, _, n := l.Totals()
+		total += n
+	}
+	if total != 4526 || tab.Dropped != 0 {
+		t.Errorf("%g applicants and %d dropped, want 4526 and 0", total, tab.Dropped)
+	}
+
+	// Summed over the departments, the admissions favour men, the
+	// reversal that made the data famous.
+	two, err := New([]string{"admit", "gender"}, values[1:], levels[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]float64{{1198, 557}, {1493, 1278}}
+	for i, row := range two.Layers()[0].Counts {
+		if !slices.Equal(row, want[i]) {
+			t.Errorf("admissions by gender, row %d = %v, want %v", i, row, want[i])
+		}
+	}
+}
+
+// TestLayerKeys checks that the first layer variable varies slowest in
+// a four-way table and that observations with a value that is missing
+// or not a level are dropped.
+func TestLayerKeys(t *testing.T) {
+	values := [][]string{
+		{"x", "x", "y", "y", "y", ""},
+		{"1", "2", "1", "2", "2", "1"},
+		{"a", "a", "b", "b", "a", "a"},
+		{"u", "v", "u", "v", "w", "u"},
+	}
+	levels := [][]string{nil, nil, nil, {"u", "v"}}
+	tab, err := New([]string{"p", "q", "r", "s"}, values, levels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tab.Dropped != 2 {
+		t.Errorf("%d observations dropped, want 2", tab.Dropped)
+	}
+	var keys [][]string
+	var counts []float64
+	for _, l := range tab.Layers() {
+		keys = append(keys, l.Key)
+		_, _, n := l.Totals()
+		counts = append(counts, n)
+	}
+	wantKeys := [][]string{{"x", "1"}, {"x", "2"}, {"y", "1"}, {"y", "2"}}
+	if !slices.EqualFunc(keys, wantKeys, slices.Equal) {
+		t.Errorf("layer keys %v, want %v", keys, wantKeys)
+	}
+	if want := []float64{1, 1, 1, 1}; !slices.Equal(counts, want) {
+		t.Errorf("layer totals %v, want %v", counts, want)
+	}
+}
+
+// TestResiduals checks the standardized residuals of party
+// identification by gender in the 2000 General Social Survey against
+// Table 2.5 of Agresti, An Introduction to Categorical Data Analysis
+// (2nd ed., 2007).
+func TestResiduals(t *testing.T) {
+	l := Layer{Counts: [][]float64{{762, 327, 468}, {484, 239, 477}}}
+	want := [][]float64{{4.50, 0.70, -5.32}, {-4.50, -0.70, 5.32}}
+	for i, row := range l.Residuals() {
+		for j, r := range row {
+			if math.Abs(r-want[i][j]) > 0.005 {
+				t.Errorf("residual (%d, %d) = %.3f, want %.2f", i, j, r, want[i][j])
+			}
+		}
+	}
+}
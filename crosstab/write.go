@@ -0,0 +1,294 @@
+package crosstab
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+// Cell is what the cells of a written table show.
+type Cell int
+
+// The cells a table can show. All but the residuals come with the
+// marginal totals.
+const (
+	Counts Cell = iota
+	RowPercent
+	ColumnPercent
+	TotalPercent
+	Expected
+	Residuals
+)
+
+var cellNames = [...]string{
+	Counts:        "counts",
+	RowPercent:    "row",
+	ColumnPercent: "column",
+	TotalPercent:  "total",
+	Expected:      "expected",
+	Residuals:     "residuals",
+}
+
+var cellTitles = [...]string{
+	Counts:        "Counts",
+	RowPercent:    "Row percentages",
+	ColumnPercent: "Column percentages",
+	TotalPercent:  "Percentages of the total",
+	Expected:      "Expected counts under independence",
+	Residuals:     "Standardized residuals",
+}
+
+func (c Cell) String() string {
+	return cellNames[c]
+}
+
+// ParseCells parses a comma-separated list of the names of cells:
+// counts, row, column, total, expected or residuals, or all.
+func ParseCells(s string) ([]Cell, error) {
+	var out []Cell
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "all" {
+			return []Cell{Counts, RowPercent, ColumnPercent, TotalPercent, Expected, Residuals}, nil
+		}
+		i := -1
+		for c, n := range cellNames {
+			if n == name {
+				i = c
+			}
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("unknown cells %q (want counts, row, column, total, expected, residuals or all)", name)
+		}
+		out = append(out, Cell(i))
+	}
+	return out, nil
+}
+
+// Format is the format tables are written in.
+type Format string
+
+// The formats.
+const (
+	Text     Format = "text"
+	Markdown Format = "markdown"
+	CSV      Format = "csv"
+)
+
+// values returns the rows of l showing c, each with the label of its
+// row first, and the marginal totals unless c is Residuals.
+func (t *Table) values(l Layer, c Cell) [][]any {
+	rows, cols, n := l.Totals()
+	grid := l.Counts
+	switch c {
+	case Expected:
+		grid = l.Expected()
+	case Residuals:
+		grid = l.Residuals()
+	}
+	// scale turns the count v in row i and column j, or in the margin
+	// if i or j is -1, into the percentage c shows.
+	scale := func(v float64, i, j int) float64 {
+		switch {
+		case c == RowPercent && i >= 0:
+			return 100 * v / rows[i]
+		case c == ColumnPercent && j >= 0:
+			return 100 * v / cols[j]
+		case c == RowPercent, c == ColumnPercent, c == TotalPercent:
+			return 100 * v / n
+		}
+		return v
+	}
+	levels := t.Levels[len(t.Levels)-2]
+	out := make([][]any, len(grid))
+	for i, row := range grid {
+		out[i] = []any{levels[i]}
+		for j, v := range row {
+			out[i] = append(out[i], scale(v, i, j))
+		}
+		if c != Residuals {
+			out[i] = append(out[i], scale(rows[i], i, -1))
+		}
+	}
+	if c != Residuals {
+		total := []any{"Total"}
+		for j, v := range cols {
+			total = append(total, scale(v, -1, j))
+		}
+		out = append(out, append(total, scale(n, -1, -1)))
+	}
+	return out
+}
+
+// title returns the title of layer l showing c.
+func (t *Table) title(l Layer, c Cell) string {
+	k := len(t.Names)
+	s := fmt.Sprintf("%s of %s by %s", cellTitles[c], t.Names[k-2], t.Names[k-1])
+	for j, key := range l.Key {
+		s += fmt.Sprintf(", %s = %s", t.Names[j], key)
+	}
+	return s
+}
+
+// header returns the column headings of a layer showing c.
+func (t *Table) header(c Cell) []string {
+	k := len(t.Names)
+	h := append([]string{t.Names[k-2]}, t.Levels[k-1]...)
+	if c != Residuals {
+		h = append(h, "Total")
+	}
+	return h
+}
+
+// format formats v, a count without decimals if it is whole and
+// anything else with decimals.
+func format(v any, c Cell, decimals int) string {
+	f, ok := v.(float64)
+	switch {
+	case !ok:
+		return fmt.Sprint(v)
+	case math.IsNaN(f):
+		return ""
+	case c == Counts && f == math.Trunc(f):
+		return strconv.FormatFloat(f, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(f, 'f', decimals, 64)
+}
+
+// Write writes the layers of t showing each of cells to w in format f,
+// numbers other than whole counts with decimals.
+func (t *Table) Write(w io.Writer, f Format, cells []Cell, decimals int) error {
+	if f == CSV {
+		return t.writeCSV(w, cells, decimals)
+	}
+	b := bufio.NewWriter(w)
+	for _, c := range cells {
+		for _, l := range t.Layers() {
+			header := t.header(c)
+			var rows [][]string
+			for _, row := range t.values(l, c) {
+				out := make([]string, len(row))
+				for j, v := range row {
+					out[j] = format(v, c, decimals)
+				}
+				rows = append(rows, out)
+			}
+			switch f {
+			case Text:
+				writeText(b, t.title(l, c), header, rows)
+			case Markdown:
+				writeMarkdown(b, t.title(l, c), header, rows)
+			default:
+				return fmt.Errorf("unknown format %q (want text, markdown or csv)", f)
+			}
+		}
+	}
+	return b.Flush()
+}
+
+// writeText writes a table with aligned columns under its title, the
+// first column left-aligned and the others right-aligned.
+func writeText(b *bufio.Writer, title string, header []string, rows [][]string) {
+	widths := make([]int, len(header))
+	for _, r := range append([][]string{header}, rows...) {
+		for i, c := range r {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	line := func(cells []string) {
+		for i, c := range cells {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c))
+			if i == 0 {
+				b.WriteString(c + pad)
+			} else {
+				b.WriteString("  " + pad + c)
+			}
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(b, "%s:\n", title)
+	line(header)
+	rules := make([]string, len(header))
+	for i, w := range widths {
+		rules[i] = strings.Repeat("-", w)
+	}
+	line(rules)
+	for _, r := range rows {
+		line(r)
+	}
+	b.WriteString("\n")
+}
+
+// writeMarkdown writes a table as a Markdown pipe table under its title
+// in bold.
+func writeMarkdown(b *bufio.Writer, title string, header []string, rows [][]string) {
+	escape := func(cells []string) string {
+		out := make([]string, len(cells))
+		for i, c := range cells {
+			out[i] = strings.ReplaceAll(c, "|", `\|`)
+		}
+		return strings.Join(out, " | ")
+	}
+	fmt.Fprintf(b, "**%s**\n\n| %s |\n|", title, escape(header))
+	for j := range header {
+		if j == 0 {
+			b.WriteString(" :--- |")
+		} else {
+			b.WriteString(" ---: |")
+		}
+	}
+	b.WriteString("\n")
+	for _, r := range rows {
+		fmt.Fprintf(b, "| %s |\n", escape(r))
+	}
+	b.WriteString("\n")
+}
+
+// writeCSV writes the layers as one CSV table: a column saying what
+// the cells show, one for each layer variable, then the rows and
+// columns of the layers, the residuals with an empty Total.
+func (t *Table) writeCSV(w io.Writer, cells []Cell, decimals int) error {
+	k := len(t.Names)
+	cw := csv.NewWriter(w)
+	header := append([]string{"cells"}, t.Names[:k-2]...)
+	if err := cw.Write(append(header, t.header(Counts)...)); err != nil {
+		return err
+	}
+	for _, c := range cells {
+		for _, l := range t.Layers() {
+			for _, row := range t.values(l, c) {
+				rec := append([]string{c.String()}, l.Key...)
+				for _, v := range row {
+					rec = append(rec, format(v, c, decimals))
+				}
+				if c == Residuals {
+					rec = append(rec, "")
+				}
+				if err := cw.Write(rec); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Report returns the layers of t showing each of cells as report
+// tables, named by what they show and by layer.
+func (t *Table) Report(cells []Cell) []report.Table {
+	var out []report.Table
+	for _, c := range cells {
+		for _, l := range t.Layers() {
+			out = append(out, report.Table{Name: t.title(l, c), Columns: t.header(c), Rows: t.values(l, c)})
+		}
+	}
+	return out
+}
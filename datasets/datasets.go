@@ -0,0 +1,339 @@
+// Package datasets is the catalog of the course datasets, embedded in
+// the binaries as CSV files with their metadata, so that every command
+// loads the same data by name:
+//
+//	ds, err := datasets.Get("wheat_yield")
+//	yield, err := ds.Floats("yield")
+package datasets
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/frame"
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+//go:embed data/*.csv
+var files embed.FS
+
+// The kinds of variables.
+const (
+	Numeric = "numeric"
+	Nominal = "nominal"
+	Ordinal = "ordinal"
+	Boolean = "boolean"
+	ID      = "id"
+)
+
+// Variable describes a column of a dataset.
+type Variable struct {
+	Name        string
+	Kind        string
+	Unit        string
+	Description string
+	// Levels are the categories of a nominal or ordinal variable, in
+	// order for ordinal ones.
+	Levels []string
+}
+
+// Dataset is a dataset of the catalog.
+type Dataset struct {
+	Name        string
+	Title       string
+	Description string
+	Source      string
+	Variables   []Variable
+}
+
+const course = "Applied statistics course exercises"
+
+var catalog = []*Dataset{
+	{
+		Name:        "wheat_yield",
+		Title:       "Wheat Yield",
+		Description: "Grain yield of 30 wheat plots",
+		Source:      course,
+		Variables:   []Variable{{Name: "yield", Kind: Numeric, Unit: "kg", Description: "grain yield of the plot"}},
+	},
+	{
+		Name:        "fertilizer",
+		Title:       "Fertilizer Usage",
+		Description: "Fertilizer used on 23 plots, in grams",
+		Source:      course,
+		Variables:   []Variable{{Name: "usage", Kind: Numeric, Unit: "g", Description: "fertilizer used on the plot"}},
+	},
+	{
+		Name:        "fertilizer_usage",
+		Title:       "Fertilizer Usage",
+		Description: "Fertilizer used on 60 plots, in kilograms",
+		Source:      course,
+		Variables:   []Variable{{Name: "usage", Kind: Numeric, Unit: "kg", Description: "fertilizer used on the plot"}},
+	},
+	{
+		Name:        "student_marks",
+		Title:       "Student Marks",
+		Description: "Marks of 25 students",
+		Source:      course,
+		Variables:   []Variable{{Name: "marks", Kind: Numeric, Unit: "marks", Description: "mark out of 50"}},
+	},
+	{
+		Name:        "work_hours",
+		Title:       "Weekly Work Hours",
+		Description: "Hours worked in one week by 30 employees",
+		Source:      course,
+		Variables: []Variable{
+			{Name: "employee", Kind: ID, Description: "employee number"},
+			{Name: "hours", Kind: Numeric, Unit: "hours", Description: "hours worked in the week"},
+		},
+	},
+	{
+		Name:        "apple_quality",
+		Title:       "Apple Quality",
+		Description: "Weight, crunchiness, sweetness, ripeness and quality of 20 apples",
+		Source:      course,
+		Variables: []Variable{
+			{Name: "id", Kind: ID, Description: "apple number"},
+			{Name: "weight", Kind: Numeric, Unit: "g", Description: "weight of the apple"},
+			{Name: "crunchiness", Kind: Ordinal, Levels: []string{"low", "medium", "high"}, Description: "crunchiness as judged by a taster"},
+			{Name: "sweetness", Kind: Numeric, Description: "sweetness score"},
+			{Name: "ripeness", Kind: Ordinal, Levels: []string{"1", "2", "3", "4"}, Description: "ripeness stage, 1 (unripe) to 4 (overripe)"},
+			{Name: "quality", Kind: Nominal, Levels: []string{"good", "bad"}, Description: "overall quality grade"},
+		},
+	},
+}
+
+// Names returns the names of the datasets, sorted.
+func Names() []string {
+	names := make([]string, len(catalog))
+	for i, d := range catalog {
+		names[i] = d.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the dataset called name.
+func Get(name string) (*Dataset, error) {
+	for _, d := range catalog {
+		if d.Name == name {
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown dataset %q; the datasets are %s", name, strings.Join(Names(), ", "))
+}
+
+// Floats returns the values of the numeric variable of the dataset
+// called name.
+func Floats(name, variable string) ([]float64, error) {
+	d, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.Floats(variable)
+}
+
+// Ints is Floats for variables of whole numbers.
+func Ints(name, variable string) ([]int, error) {
+	d, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.Ints(variable)
+}
+
+// Frame returns the dataset called name as a data frame.
+func Frame(name string) (*frame.Frame, error) {
+	d, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return d.Frame()
+}
+
+// Variable returns the variable called name.
+func (d *Dataset) Variable(name string) (Variable, error) {
+	for _, v := range d.Variables {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return Variable{}, fmt.Errorf("dataset %s has no variable %q", d.Name, name)
+}
+
+// Main returns the first numeric variable, the one commands analyse
+// when no other is named.
+func (d *Dataset) Main() Variable {
+	for _, v := range d.Variables {
+		if v.Kind == Numeric {
+			return v
+		}
+	}
+	return d.Variables[0]
+}
+
+// Label returns a label for the variable v of d, for chart titles and
+// axes: the title of the dataset if v is its only measured variable,
+// else the capitalized name of v, followed by the unit if any.
+func (d *Dataset) Label(v Variable) string {
+	measured := 0
+	for _, w := range d.Variables {
+		if w.Kind != ID {
+			measured++
+		}
+	}
+	label := d.Title
+	if measured > 1 {
+		label = strings.ToUpper(v.Name[:1]) + v.Name[1:]
+	}
+	if v.Unit != "" {
+		label += " (" + v.Unit + ")"
+	}
+	return label
+}
+
+// Split splits the values of the numeric variable called name by the
+// levels of the categorical variable called by, in the order of its
+// levels; levels that do not occur are left out.
+func (d *Dataset) Split(name, by string) (levels []string, groups [][]float64, err error) {
+	f, err := d.Frame()
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := f.Column(by)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !c.Kind.Categorical() {
+		return nil, nil, fmt.Errorf("dataset %s: variable %s is not categorical", d.Name, by)
+	}
+	gs, err := f.GroupBy(by)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, g := range gs {
+		x, err := g.Floats(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		levels = append(levels, g.Key[0])
+		groups = append(groups, x)
+	}
+	return levels, groups, nil
+}
+
+// Frame returns the dataset as a data frame, with the columns of the
+// kinds, units and levels of its variables.
+func (d *Dataset) Frame() (*frame.Frame, error) {
+	return d.FrameNA(nil)
+}
+
+// FrameNA is Frame with the sentinels na, frame.DefaultNA if nil, read
+// as missing values.
+func (d *Dataset) FrameNA(na frame.NA) (*frame.Frame, error) {
+	rows, err := d.Records()
+	if err != nil {
+		return nil, err
+	}
+	specs := make(map[string]frame.Spec)
+	for _, v := range d.Variables {
+		kind, err := frame.ParseKind(v.Kind)
+		if err != nil {
+			return nil, err
+		}
+		specs[v.Name] = frame.Spec{Kind: kind, Unit: v.Unit, Levels: v.Levels, NA: na}
+	}
+	return frame.FromRecords(rows[0], rows[1:], specs)
+}
+
+// Records returns the rows of the dataset, the first being the header
+// of the variable names.
+func (d *Dataset) Records() ([][]string, error) {
+	f, err := files.Open("data/" + d.Name + ".csv")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return csv.NewReader(f).ReadAll()
+}
+
+// CSV returns the dataset as the text of a CSV file.
+func (d *Dataset) CSV() []byte {
+	b, err := files.ReadFile("data/" + d.Name + ".csv")
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Strings returns the values of the variable called name.
+func (d *Dataset) Strings(name string) ([]string, error) {
+	rows, err := d.Records()
+	if err != nil {
+		return nil, err
+	}
+	j := -1
+	for i, h := range rows[0] {
+		if h == name {
+			j = i
+		}
+	}
+	if j < 0 {
+		return nil, fmt.Errorf("dataset %s has no variable %q", d.Name, name)
+	}
+	out := make([]string, len(rows)-1)
+	for i, row := range rows[1:] {
+		out[i] = row[j]
+	}
+	return out, nil
+}
+
+// Floats returns the values of the numeric variable called name.
+func (d *Dataset) Floats(name string) ([]float64, error) {
+	s, err := d.Strings(name)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]float64, len(s))
+	for i, v := range s {
+		if out[i], err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("dataset %s, variable %s, row %d: %q is not a number", d.Name, name, i+1, v)
+		}
+	}
+	return out, nil
+}
+
+// Ints is Floats for variables of whole numbers.
+func (d *Dataset) Ints(name string) ([]int, error) {
+	x, err := d.Floats(name)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]int, len(x))
+	for i, v := range x {
+		out[i] = int(v)
+		if float64(out[i]) != v {
+			return nil, fmt.Errorf("dataset %s, variable %s, row %d: %v is not a whole number", d.Name, name, i+1, v)
+		}
+	}
+	return out, nil
+}
+
+// Report describes the dataset and its variables called names, or all
+// of them if none are named, for a result document.
+func (d *Dataset) Report(names ...string) report.Dataset {
+	rows, _ := d.Records()
+	rd := report.Dataset{Name: d.Name, Description: d.Description, N: len(rows) - 1}
+	for _, v := range d.Variables {
+		if len(names) > 0 && !slices.Contains(names, v.Name) {
+			continue
+		}
+		rd.Variables = append(rd.Variables, report.Variable{Name: v.Name, Kind: v.Kind, Unit: v.Unit})
+	}
+	return rd
+}
@@ -0,0 +1,402 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Normal is the normal distribution of mean Mu and standard deviation
+// Sigma.
+type Normal struct {
+	Mu, Sigma float64
+}
+
+func (d Normal) PDF(x float64) float64 {
+	z := (x - d.Mu) / d.Sigma
+	return math.Exp(-z*z/2) / (d.Sigma * math.Sqrt(2*math.Pi))
+}
+
+func (d Normal) CDF(x float64) float64 {
+	return math.Erfc(-(x-d.Mu)/(d.Sigma*math.Sqrt2)) / 2
+}
+
+func (d Normal) Survival(x float64) float64 {
+	return math.Erfc((x-d.Mu)/(d.Sigma*math.Sqrt2)) / 2
+}
+
+func (d Normal) Quantile(p float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return d.Mu + d.Sigma*ndtri(p)
+}
+
+func (d Normal) Mean() float64     { return d.Mu }
+func (d Normal) Variance() float64 { return d.Sigma * d.Sigma }
+
+func (d Normal) Rand(r *rand.Rand) float64 {
+	return d.Mu + d.Sigma*r.NormFloat64()
+}
+
+func (d Normal) String() string {
+	return fmt.Sprintf("normal(%s, %s)", g(d.Mu), g(d.Sigma))
+}
+
+// StudentT is Student's t distribution with DF degrees of freedom.
+type StudentT struct {
+	DF float64
+}
+
+func (d StudentT) PDF(x float64) float64 {
+	n := d.DF
+	a, _ := math.Lgamma((n + 1) / 2)
+	b, _ := math.Lgamma(n / 2)
+	return math.Exp(a-b-(n+1)/2*math.Log1p(x*x/n)) / math.Sqrt(n*math.Pi)
+}
+
+// CDF uses P(|T| > |t|) = I_(n/(n+t²))(n/2, 1/2).
+func (d StudentT) CDF(x float64) float64 {
+	outside, inside := incBeta(d.DF/2, 0.5, d.DF/(d.DF+x*x), x*x/(d.DF+x*x))
+	if x < 0 {
+		return outside / 2
+	}
+	return 0.5 + inside/2
+}
+
+func (d StudentT) Survival(x float64) float64 {
+	return d.CDF(-x)
+}
+
+func (d StudentT) Quantile(p float64) float64 {
+	if p == 0.5 {
+		return 0
+	}
+	return solve(d, p, math.Inf(-1), math.Inf(1))
+}
+
+// Mean is 0 for more than one degree of freedom, otherwise undefined
+// (NaN).
+func (d StudentT) Mean() float64 {
+	if d.DF <= 1 {
+		return math.NaN()
+	}
+	return 0
+}
+
+// Variance is n / (n - 2) for n > 2 degrees of freedom, infinite for
+// 1 < n ≤ 2 and otherwise undefined.
+func (d StudentT) Variance() float64 {
+	switch {
+	case d.DF > 2:
+		return d.DF / (d.DF - 2)
+	case d.DF > 1:
+		return math.Inf(1)
+	}
+	return math.NaN()
+}
+
+func (d StudentT) Rand(r *rand.Rand) float64 {
+	return r.NormFloat64() / math.Sqrt(2*gammaRand(d.DF/2, r)/d.DF)
+}
+
+func (d StudentT) String() string {
+	return fmt.Sprintf("t(%s)", g(d.DF))
+}
+
+// ChiSquare is the chi-square distribution with DF degrees of freedom,
+// the gamma distribution of shape DF/2 and rate 1/2.
+type ChiSquare struct {
+	DF float64
+}
+
+func (d ChiSquare) gamma() Gamma {
+	return Gamma{Shape: d.DF / 2, Rate: 0.5}
+}
+
+func (d ChiSquare) PDF(x float64) float64      { return d.gamma().PDF(x) }
+func (d ChiSquare) CDF(x float64) float64      { return d.gamma().CDF(x) }
+func (d ChiSquare) Survival(x float64) float64 { return d.gamma().Survival(x) }
+func (d ChiSquare) Quantile(p float64) float64 { return solve(d, p, 0, math.Inf(1)) }
+func (d ChiSquare) Mean() float64              { return d.DF }
+func (d ChiSquare) Variance() float64          { return 2 * d.DF }
+func (d ChiSquare) Rand(r *rand.Rand) float64  { return d.gamma().Rand(r) }
+
+func (d ChiSquare) String() string {
+	return fmt.Sprintf("chisq(%s)", g(d.DF))
+}
+
+// F is Fisher's F distribution with D1 and D2 degrees of freedom.
+type F struct {
+	D1, D2 float64
+}
+
+func (d F) PDF(x float64) float64 {
+	switch {
+	case x < 0:
+		return 0
+	case x == 0:
+		switch {
+		case d.D1 < 2:
+			return math.Inf(1)
+		case d.D1 == 2:
+			return 1
+		}
+		return 0
+	}
+	a, b := d.D1, d.D2
+	return math.Exp((a*math.Log(a*x)+b*math.Log(b)-(a+b)*math.Log(a*x+b))/2 - math.Log(x) - lbeta(a/2, b/2))
+}
+
+// CDF uses P(X ≤ x) = I_(d₁x/(d₁x+d₂))(d₁/2, d₂/2).
+func (d F) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lower, _ := d.incBeta(x)
+	return lower
+}
+
+func (d F) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	_, upper := d.incBeta(x)
+	return upper
+}
+
+func (d F) incBeta(x float64) (lower, upper float64) {
+	s := d.D1*x + d.D2
+	return incBeta(d.D1/2, d.D2/2, d.D1*x/s, d.D2/s)
+}
+
+func (d F) Quantile(p float64) float64 {
+	return solve(d, p, 0, math.Inf(1))
+}
+
+// Mean is d₂ / (d₂ - 2) for d₂ > 2, otherwise undefined (NaN).
+func (d F) Mean() float64 {
+	if d.D2 <= 2 {
+		return math.NaN()
+	}
+	return d.D2 / (d.D2 - 2)
+}
+
+// Variance is defined for d₂ > 4 and infinite for 2 < d₂ ≤ 4.
+func (d F) Variance() float64 {
+	a, b := d.D1, d.D2
+	switch {
+	case b > 4:
+		return 2 * b * b * (a + b - 2) / (a * (b - 2) * (b - 2) * (b - 4))
+	case b > 2:
+		return math.Inf(1)
+	}
+	return math.NaN()
+}
+
+func (d F) Rand(r *rand.Rand) float64 {
+	return (gammaRand(d.D1/2, r) / d.D1) / (gammaRand(d.D2/2, r) / d.D2)
+}
+
+func (d F) String() string {
+	return fmt.Sprintf("f(%s, %s)", g(d.D1), g(d.D2))
+}
+
+// Exponential is the exponential distribution of rate Rate, mean
+// 1/Rate.
+type Exponential struct {
+	Rate float64
+}
+
+func (d Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return d.Rate * math.Exp(-d.Rate*x)
+}
+
+func (d Exponential) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-d.Rate * x)
+}
+
+func (d Exponential) Survival(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return math.Exp(-d.Rate * x)
+}
+
+func (d Exponential) Quantile(p float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / d.Rate
+}
+
+func (d Exponential) Mean() float64     { return 1 / d.Rate }
+func (d Exponential) Variance() float64 { return 1 / (d.Rate * d.Rate) }
+
+func (d Exponential) Rand(r *rand.Rand) float64 {
+	return r.ExpFloat64() / d.Rate
+}
+
+func (d Exponential) String() string {
+	return fmt.Sprintf("exponential(%s)", g(d.Rate))
+}
+
+// Uniform is the continuous uniform distribution on [Min, Max].
+type Uniform struct {
+	Min, Max float64
+}
+
+func (d Uniform) PDF(x float64) float64 {
+	if x < d.Min || x > d.Max {
+		return 0
+	}
+	return 1 / (d.Max - d.Min)
+}
+
+func (d Uniform) CDF(x float64) float64 {
+	return max(0, min(1, (x-d.Min)/(d.Max-d.Min)))
+}
+
+func (d Uniform) Survival(x float64) float64 {
+	return max(0, min(1, (d.Max-x)/(d.Max-d.Min)))
+}
+
+func (d Uniform) Quantile(p float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return d.Min + p*(d.Max-d.Min)
+}
+
+func (d Uniform) Mean() float64 { return (d.Min + d.Max) / 2 }
+
+func (d Uniform) Variance() float64 {
+	w := d.Max - d.Min
+	return w * w / 12
+}
+
+func (d Uniform) Rand(r *rand.Rand) float64 {
+	return d.Min + r.Float64()*(d.Max-d.Min)
+}
+
+func (d Uniform) String() string {
+	return fmt.Sprintf("uniform(%s, %s)", g(d.Min), g(d.Max))
+}
+
+// Beta is the beta distribution on [0, 1] with shapes Alpha and Beta.
+type Beta struct {
+	Alpha, Beta float64
+}
+
+func (d Beta) PDF(x float64) float64 {
+	if x < 0 || x > 1 {
+		return 0
+	}
+	return math.Exp(xlogy(d.Alpha-1, x) + xlogy(d.Beta-1, 1-x) - lbeta(d.Alpha, d.Beta))
+}
+
+func (d Beta) CDF(x float64) float64 {
+	lower, _ := incBeta(d.Alpha, d.Beta, x, 1-x)
+	return lower
+}
+
+func (d Beta) Survival(x float64) float64 {
+	_, upper := incBeta(d.Alpha, d.Beta, x, 1-x)
+	return upper
+}
+
+func (d Beta) Quantile(p float64) float64 {
+	return solve(d, p, 0, 1)
+}
+
+func (d Beta) Mean() float64 { return d.Alpha / (d.Alpha + d.Beta) }
+
+func (d Beta) Variance() float64 {
+	s := d.Alpha + d.Beta
+	return d.Alpha * d.Beta / (s * s * (s + 1))
+}
+
+func (d Beta) Rand(r *rand.Rand) float64 {
+	x := gammaRand(d.Alpha, r)
+	return x / (x + gammaRand(d.Beta, r))
+}
+
+func (d Beta) String() string {
+	return fmt.Sprintf("beta(%s, %s)", g(d.Alpha), g(d.Beta))
+}
+
+// Gamma is the gamma distribution of shape Shape and rate Rate, mean
+// Shape/Rate.
+type Gamma struct {
+	Shape, Rate float64
+}
+
+func (d Gamma) PDF(x float64) float64 {
+	switch {
+	case x < 0:
+		return 0
+	case x == 0:
+		switch {
+		case d.Shape < 1:
+			return math.Inf(1)
+		case d.Shape == 1:
+			return d.Rate
+		}
+		return 0
+	}
+	lg, _ := math.Lgamma(d.Shape)
+	return math.Exp(d.Shape*math.Log(d.Rate) + (d.Shape-1)*math.Log(x) - d.Rate*x - lg)
+}
+
+func (d Gamma) CDF(x float64) float64 {
+	lower, _ := incGamma(d.Shape, d.Rate*x)
+	return lower
+}
+
+func (d Gamma) Survival(x float64) float64 {
+	_, upper := incGamma(d.Shape, d.Rate*x)
+	return upper
+}
+
+func (d Gamma) Quantile(p float64) float64 {
+	return solve(d, p, 0, math.Inf(1))
+}
+
+func (d Gamma) Mean() float64     { return d.Shape / d.Rate }
+func (d Gamma) Variance() float64 { return d.Shape / (d.Rate * d.Rate) }
+
+func (d Gamma) Rand(r *rand.Rand) float64 {
+	return gammaRand(d.Shape, r) / d.Rate
+}
+
+func (d Gamma) String() string {
+	return fmt.Sprintf("gamma(%s, %s)", g(d.Shape), g(d.Rate))
+}
+
+// gammaRand draws from the gamma distribution of the given shape and
+// rate 1, by the method of Marsaglia and Tsang (2000); shapes below 1
+// are drawn as shape + 1 and scaled by U^(1/shape).
+func gammaRand(shape float64, r *rand.Rand) float64 {
+	if shape < 1 {
+		return gammaRand(shape+1, r) * math.Pow(r.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		if math.Log(r.Float64()) < x*x/2+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// The discrete distributions draw random values by inversion, as the
+// quantile of a uniform draw.
+
+// Binomial is the distribution of the number of successes in N
+// independent trials of success probability P.
+type Binomial struct {
+	N int
+	P float64
+}
+
+func (d Binomial) PMF(k int) float64 {
+	switch {
+	case k < 0 || k > d.N:
+		return 0
+	case d.P == 0 || d.P == 1:
+		if (d.P == 0 && k == 0) || (d.P == 1 && k == d.N) {
+			return 1
+		}
+		return 0
+	}
+	return math.Exp(lchoose(d.N, k) + float64(k)*math.Log(d.P) + float64(d.N-k)*math.Log1p(-d.P))
+}
+
+// CDF uses P(X ≤ k) = I_(1-p)(n - k, k + 1).
+func (d Binomial) CDF(x float64) float64 {
+	k := math.Floor(x)
+	switch {
+	case k < 0:
+		return 0
+	case k >= float64(d.N):
+		return 1
+	}
+	lower, _ := incBeta(float64(d.N)-k, k+1, 1-d.P, d.P)
+	return lower
+}
+
+func (d Binomial) Survival(x float64) float64 {
+	k := math.Floor(x)
+	switch {
+	case k < 0:
+		return 1
+	case k >= float64(d.N):
+		return 0
+	}
+	_, upper := incBeta(float64(d.N)-k, k+1, 1-d.P, d.P)
+	return upper
+}
+
+func (d Binomial) Quantile(p float64) float64 {
+	return search(d, p, guess(p, d.Mean(), d.Variance()), 0, float64(d.N))
+}
+
+func (d Binomial) Mean() float64     { return float64(d.N) * d.P }
+func (d Binomial) Variance() float64 { return float64(d.N) * d.P * (1 - d.P) }
+
+func (d Binomial) Rand(r *rand.Rand) float64 {
+	return d.Quantile(r.Float64())
+}
+
+func (d Binomial) String() string {
+	return fmt.Sprintf("binomial(%d, %s)", d.N, g(d.P))
+}
+
+// Poisson is the Poisson distribution of mean Lambda.
+type Poisson struct {
+	Lambda float64
+}
+
+func (d Poisson) PMF(k int) float64 {
+	if k < 0 {
+		return 0
+	}
+	lf, _ := math.Lgamma(float64(k + 1))
+	return math.Exp(xlogy(float64(k), d.Lambda) - d.Lambda - lf)
+}
+
+// CDF uses P(X ≤ k) = Q(k + 1, λ).
+func (d Poisson) CDF(x float64) float64 {
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	_, upper := incGamma(k+1, d.Lambda)
+	return upper
+}
+
+func (d Poisson) Survival(x float64) float64 {
+	k := math.Floor(x)
+	if k < 0 {
+		return 1
+	}
+	lower, _ := incGamma(k+1, d.Lambda)
+	return lower
+}
+
+func (d Poisson) Quantile(p float64) float64 {
+	return search(d, p, guess(p, d.Lambda, d.Lambda), 0, math.Inf(1))
+}
+
+func (d Poisson) Mean() float64     { return d.Lambda }
+func (d Poisson) Variance() float64 { return d.Lambda }
+
+func (d Poisson) Rand(r *rand.Rand) float64 {
+	return d.Quantile(r.Float64())
+}
+
+func (d Poisson) String() string {
+	return fmt.Sprintf("poisson(%s)", g(d.Lambda))
+}
+
+// Geometric is the distribution of the number of trials up to and
+// including the first success, of success probability P; its values
+// are 1, 2, ….
+type Geometric struct {
+	P float64
+}
+
+func (d Geometric) PMF(k int) float64 {
+	if k < 1 {
+		return 0
+	}
+	return d.P * math.Exp(xlogy(float64(k-1), 1-d.P))
+}
+
+func (d Geometric) CDF(x float64) float64 {
+	k := math.Floor(x)
+	if k < 1 {
+		return 0
+	}
+	return -math.Expm1(k * math.Log1p(-d.P))
+}
+
+func (d Geometric) Survival(x float64) float64 {
+	k := math.Floor(x)
+	if k < 1 {
+		return 1
+	}
+	return math.Exp(k * math.Log1p(-d.P))
+}
+
+// Quantile starts from the solution of 1 - (1-p)^k = q.
+func (d Geometric) Quantile(p float64) float64 {
+	return search(d, p, math.Ceil(math.Log1p(-p)/math.Log1p(-d.P)), 1, math.Inf(1))
+}
+
+func (d Geometric) Mean() float64     { return 1 / d.P }
+func (d Geometric) Variance() float64 { return (1 - d.P) / (d.P * d.P) }
+
+func (d Geometric) Rand(r *rand.Rand) float64 {
+	return d.Quantile(r.Float64())
+}
+
+func (d Geometric) String() string {
+	return fmt.Sprintf("geometric(%s)", g(d.P))
+}
+
+// Hypergeometric is the distribution of the number of successes in
+// Draws draws without replacement from a population of Population
+// items, Successes of which are successes.
+type Hypergeometric struct {
+	Population, Successes, Draws int
+}
+
+// support returns the smallest and largest possible values.
+func (d Hypergeometric) support() (lo, hi int) {
+	return max(0, d.Draws+d.Successes-d.Population), min(d.Draws, d.Successes)
+}
+
+func (d Hypergeometric) PMF(k int) float64 {
+	lo, hi := d.support()
+	if k < lo || k > hi {
+		return 0
+	}
+	return math.Exp(lchoose(d.Successes, k) + lchoose(d.Population-d.Successes, d.Draws-k) - lchoose(d.Population, d.Draws))
+}
+
+// CDF sums the probabilities up to x; Survival those above it.
+func (d Hypergeometric) CDF(x float64) float64 {
+	lo, hi := d.support()
+	var p float64
+	for k := lo; k <= hi && float64(k) <= x; k++ {
+		p += d.PMF(k)
+	}
+	return min(p, 1)
+}
+
+func (d Hypergeometric) Survival(x float64) float64 {
+	lo, hi := d.support()
+	var p float64
+	for k := hi; k >= lo && float64(k) > x; k-- {
+		p += d.PMF(k)
+	}
+	return min(p, 1)
+}
+
+func (d Hypergeometric) Quantile(p float64) float64 {
+	lo, hi := d.support()
+	return search(d, p, guess(p, d.Mean(), d.Variance()), float64(lo), float64(hi))
+}
+
+func (d Hypergeometric) Mean() float64 {
+	return float64(d.Draws) * float64(d.Successes) / float64(d.Population)
+}
+
+func (d Hypergeometric) Variance() float64 {
+	n, k, m := float64(d.Population), float64(d.Successes), float64(d.Draws)
+	if n <= 1 {
+		return 0
+	}
+	return m * k / n * (n - k) / n * (n - m) / (n - 1)
+}
+
+func (d Hypergeometric) Rand(r *rand.Rand) float64 {
+	return d.Quantile(r.Float64())
+}
+
+func (d Hypergeometric) String() string {
+	return fmt.Sprintf("hypergeometric(%d, %d, %d)", d.Population, d.Successes, d.Draws)
+}
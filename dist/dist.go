@@ -0,0 +1,139 @@
+// Package dist provides the probability distributions used for
+// p-values, critical values and simulation: their densities or
+// probability mass functions, cumulative distribution, survival and
+// quantile functions, means, variances and seeded random draws.
+//
+// Each distribution is a struct of its parameters, such as
+// Binomial{N: 10, P: 0.3}; the methods expect valid parameters, which
+// New and Parse check.
+package dist
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Distribution is the distribution of a real random variable X.
+type Distribution interface {
+	// CDF returns P(X ≤ x).
+	CDF(x float64) float64
+	// Survival returns P(X > x), computed directly rather than as
+	// 1 - CDF(x) so that it is accurate far into the upper tail.
+	Survival(x float64) float64
+	// Quantile returns the smallest x with P(X ≤ x) ≥ p, or NaN for p
+	// outside [0, 1].
+	Quantile(p float64) float64
+	Mean() float64
+	Variance() float64
+	// Rand returns a value drawn from the distribution with r.
+	Rand(r *rand.Rand) float64
+	// String returns the distribution as Parse reads it, e.g.
+	// "binomial(10, 0.3)".
+	String() string
+}
+
+// Continuous is a distribution with a density.
+type Continuous interface {
+	Distribution
+	PDF(x float64) float64
+}
+
+// Discrete is a distribution on the integers.
+type Discrete interface {
+	Distribution
+	// PMF returns P(X = k).
+	PMF(k int) float64
+}
+
+// Sample returns n values drawn from d with r.
+func Sample(d Distribution, n int, r *rand.Rand) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = d.Rand(r)
+	}
+	return x
+}
+
+// solve returns the p-quantile of the continuous distribution d whose
+// support runs from lo to hi, either of which may be infinite, by
+// bisection. Above the median it solves on the survival function,
+// which keeps the upper tail accurate.
+func solve(d Distribution, p, lo, hi float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return lo
+	case p == 1:
+		return hi
+	}
+	// below reports whether x is below the quantile.
+	below := func(x float64) bool {
+		if p <= 0.5 {
+			return d.CDF(x) < p
+		}
+		return d.Survival(x) > 1-p
+	}
+	a, b := lo, hi
+	if math.IsInf(b, 1) {
+		b = max(1, 2*a)
+		for below(b) {
+			a, b = b, 2*b
+		}
+	}
+	if math.IsInf(a, -1) {
+		a = min(-1, 2*b)
+		for !below(a) {
+			a, b = 2*a, a
+		}
+	}
+	for range 2000 {
+		m := a + (b-a)/2
+		if m <= a || m >= b {
+			break
+		}
+		if below(m) {
+			a = m
+		} else {
+			b = m
+		}
+	}
+	return b
+}
+
+// search returns the p-quantile of the discrete distribution d whose
+// support runs from lo to hi, either of which may be infinite, stepping
+// from guess. As in R, p is lowered by a relative 64ε, so that rounding
+// in the CDF does not push the quantile one step too far.
+func search(d Distribution, p, guess, lo, hi float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return lo
+	case p == 1:
+		return hi
+	}
+	p *= 1 - 64*epsilon
+	k := math.Floor(guess)
+	if math.IsNaN(k) {
+		k = lo
+	}
+	k = max(lo, min(hi, k))
+	for k > lo && d.CDF(k-1) >= p {
+		k--
+	}
+	for k < hi && d.CDF(k) < p {
+		k++
+	}
+	return k
+}
+
+// epsilon is the machine epsilon of float64.
+const epsilon = 0x1p-52
+
+// guess returns the normal approximation to the p-quantile of a
+// distribution of mean m and variance v, a start for search.
+func guess(p, m, v float64) float64 {
+	return m + math.Sqrt(v)*Normal{Mu: 0, Sigma: 1}.Quantile(p)
+}
@@ -0,0 +1,170 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// family is a kind of distribution, with its names and parameters.
+type family struct {
+	names  []string // the first is the one String writes
+	params []string
+	make   func(p []float64) (Distribution, error)
+}
+
+var families = []family{
+	{[]string{"normal", "norm", "n"}, []string{"mean", "sd"}, func(p []float64) (Distribution, error) {
+		return Normal{Mu: p[0], Sigma: p[1]}, positive("sd", p[1])
+	}},
+	{[]string{"t", "student"}, []string{"df"}, func(p []float64) (Distribution, error) {
+		return StudentT{DF: p[0]}, positive("df", p[0])
+	}},
+	{[]string{"chisq", "chi2", "chisquare"}, []string{"df"}, func(p []float64) (Distribution, error) {
+		return ChiSquare{DF: p[0]}, positive("df", p[0])
+	}},
+	{[]string{"f"}, []string{"df1", "df2"}, func(p []float64) (Distribution, error) {
+		return F{D1: p[0], D2: p[1]}, firstErr(positive("df1", p[0]), positive("df2", p[1]))
+	}},
+	{[]string{"binomial", "binom", "bin"}, []string{"n", "p"}, func(p []float64) (Distribution, error) {
+		n, err := count("n", p[0])
+		return Binomial{N: n, P: p[1]}, firstErr(err, probability("p", p[1], true))
+	}},
+	{[]string{"poisson", "pois"}, []string{"lambda"}, func(p []float64) (Distribution, error) {
+		var err error
+		if !(p[0] >= 0) || math.IsInf(p[0], 0) {
+			err = fmt.Errorf("lambda is %v, want a non-negative number", p[0])
+		}
+		return Poisson{Lambda: p[0]}, err
+	}},
+	{[]string{"geometric", "geom"}, []string{"p"}, func(p []float64) (Distribution, error) {
+		return Geometric{P: p[0]}, probability("p", p[0], false)
+	}},
+	{[]string{"hypergeometric", "hyper"}, []string{"population", "successes", "draws"}, func(p []float64) (Distribution, error) {
+		n, err1 := count("population", p[0])
+		k, err2 := count("successes", p[1])
+		m, err3 := count("draws", p[2])
+		err := firstErr(err1, err2, err3)
+		switch {
+		case err != nil:
+		case n == 0:
+			err = fmt.Errorf("the population is empty")
+		case k > n:
+			err = fmt.Errorf("%d successes in a population of %d", k, n)
+		case m > n:
+			err = fmt.Errorf("%d draws from a population of %d", m, n)
+		}
+		return Hypergeometric{Population: n, Successes: k, Draws: m}, err
+	}},
+	{[]string{"exponential", "exp"}, []string{"rate"}, func(p []float64) (Distribution, error) {
+		return Exponential{Rate: p[0]}, positive("rate", p[0])
+	}},
+	{[]string{"uniform", "unif"}, []string{"min", "max"}, func(p []float64) (Distribution, error) {
+		var err error
+		if !(p[0] < p[1]) || math.IsInf(p[0], 0) || math.IsInf(p[1], 0) {
+			err = fmt.Errorf("min %v and max %v, want finite min < max", p[0], p[1])
+		}
+		return Uniform{Min: p[0], Max: p[1]}, err
+	}},
+	{[]string{"beta"}, []string{"alpha", "beta"}, func(p []float64) (Distribution, error) {
+		return Beta{Alpha: p[0], Beta: p[1]}, firstErr(positive("alpha", p[0]), positive("beta", p[1]))
+	}},
+	{[]string{"gamma"}, []string{"shape", "rate"}, func(p []float64) (Distribution, error) {
+		return Gamma{Shape: p[0], Rate: p[1]}, firstErr(positive("shape", p[0]), positive("rate", p[1]))
+	}},
+}
+
+// Families returns the distributions New and Parse know, each as its
+// name and parameters, e.g. "binomial(n, p)".
+func Families() []string {
+	var s []string
+	for _, f := range families {
+		s = append(s, f.names[0]+"("+strings.Join(f.params, ", ")+")")
+	}
+	return s
+}
+
+// New returns the distribution named name with the given parameters,
+// checking them. The names are those of Families and some
+// abbreviations: norm, chi2, binom, pois, geom, hyper, exp and unif.
+func New(name string, params ...float64) (Distribution, error) {
+	name = strings.ToLower(name)
+	for _, f := range families {
+		for _, n := range f.names {
+			if n != name {
+				continue
+			}
+			if len(params) != len(f.params) {
+				return nil, fmt.Errorf("%s takes %d parameters (%s), got %d", f.names[0], len(f.params), strings.Join(f.params, ", "), len(params))
+			}
+			d, err := f.make(params)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", f.names[0], err)
+			}
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown distribution %q (want %s)", name, strings.Join(Families(), ", "))
+}
+
+// Parse returns the distribution written as name(parameters), such as
+// "binomial(10, 0.3)" or "t(12)".
+func Parse(s string) (Distribution, error) {
+	name, rest, ok := strings.Cut(strings.TrimSpace(s), "(")
+	if !ok || !strings.HasSuffix(rest, ")") {
+		return nil, fmt.Errorf("distribution %q is not of the form name(parameters), e.g. binomial(10, 0.3)", s)
+	}
+	var params []float64
+	if args := strings.TrimSpace(strings.TrimSuffix(rest, ")")); args != "" {
+		for _, a := range strings.Split(args, ",") {
+			v, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q of %s is not a number", strings.TrimSpace(a), s)
+			}
+			params = append(params, v)
+		}
+	}
+	return New(strings.TrimSpace(name), params...)
+}
+
+func positive(name string, v float64) error {
+	if !(v > 0) || math.IsInf(v, 0) {
+		return fmt.Errorf("%s is %v, want a positive number", name, v)
+	}
+	return nil
+}
+
+// probability checks that v is a probability, allowing 0 if zero is
+// set.
+func probability(name string, v float64, zero bool) error {
+	if !(v >= 0 && v <= 1) || (v == 0 && !zero) {
+		if zero {
+			return fmt.Errorf("%s is %v, want a probability in [0, 1]", name, v)
+		}
+		return fmt.Errorf("%s is %v, want a probability in (0, 1]", name, v)
+	}
+	return nil
+}
+
+// count checks that v is a non-negative whole number.
+func count(name string, v float64) (int, error) {
+	if !(v >= 0) || v != math.Trunc(v) || v > math.MaxInt32 {
+		return 0, fmt.Errorf("%s is %v, want a whole number ≥ 0", name, v)
+	}
+	return int(v), nil
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// g formats a parameter as briefly as it reads back exactly.
+func g(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,201 @@
+package dist
+
+import "math"
+
+// incBeta returns the regularized incomplete beta function I_x(a, b)
+// and its complement 1 - I_x(a, b), evaluated by the continued fraction
+// (Numerical Recipes §6.4). The caller passes y = 1 - x as well, since
+// it can often compute it without the cancellation of 1 - x. The
+// fraction is summed for whichever of the two converges quickly, by the
+// symmetry 1 - I_x(a, b) = I_y(b, a), and that one keeps its full
+// relative accuracy when it is small.
+func incBeta(a, b, x, y float64) (lower, upper float64) {
+	switch {
+	case x <= 0:
+		return 0, 1
+	case y <= 0:
+		return 1, 0
+	}
+	front := math.Exp(a*math.Log(x) + b*math.Log(y) - lbeta(a, b))
+	if x < (a+1)/(a+b+2) {
+		lower = front * betaFraction(a, b, x) / a
+		return lower, 1 - lower
+	}
+	upper = front * betaFraction(b, a, y) / b
+	return 1 - upper, upper
+}
+
+// betaFraction evaluates the continued fraction of incBeta by the
+// modified Lentz method.
+func betaFraction(a, b, x float64) float64 {
+	const (
+		maxIter = 300
+		eps     = 1e-15
+		tiny    = 1e-300
+	)
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		// Even step.
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		// Odd step.
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
+
+// incGamma returns the regularized lower and upper incomplete gamma
+// functions P(a, x) and Q(a, x) = 1 - P(a, x), by the series of P for
+// x < a + 1 and the continued fraction of Q otherwise.
+func incGamma(a, x float64) (lower, upper float64) {
+	if x <= 0 {
+		return 0, 1
+	}
+	lg, _ := math.Lgamma(a)
+	front := math.Exp(-x + a*math.Log(x) - lg)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		lower = sum * front
+		return lower, 1 - lower
+	}
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-15 {
+			break
+		}
+	}
+	upper = front * h
+	return 1 - upper, upper
+}
+
+// lbeta returns log B(a, b).
+func lbeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+// lchoose returns the log of the binomial coefficient n choose k.
+func lchoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// xlogy returns x·log(y), taken as 0 when x is 0 even if y is 0.
+func xlogy(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
+
+// ndtri returns the p-quantile of the standard normal distribution by
+// Wichura's algorithm AS 241 (PPND16), accurate to about 16 digits. It
+// works on min(p, 1-p) in the tails, so that small p keep their
+// relative accuracy where 2p would underflow in erfcinv.
+func ndtri(p float64) float64 {
+	switch {
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	}
+	q := p - 0.5
+	if math.Abs(q) <= 0.425 {
+		r := 0.180625 - q*q
+		return q * (((((((r*2509.0809287301226727+
+			33430.575583588128105)*r+67265.770927008700853)*r+
+			45921.953931549871457)*r+13731.693765509461125)*r+
+			1971.5909503065514427)*r+133.14166789178437745)*r +
+			3.387132872796366608) /
+			(((((((r*5226.495278852545925+
+				28729.085735721942674)*r+39307.89580009271061)*r+
+				21213.794301586595867)*r+5394.1960214247511077)*r+
+				687.1870074920579083)*r+42.313330701600911252)*r + 1)
+	}
+	r := math.Sqrt(-math.Log(min(p, 1-p)))
+	var z float64
+	if r <= 5 {
+		r -= 1.6
+		z = (((((((r*7.7454501427834140764e-4+
+			0.0227238449892691845833)*r+0.24178072517745061177)*r+
+			1.27045825245236838258)*r+3.64784832476320460504)*r+
+			5.7694972214606914055)*r+4.6303378461565452959)*r +
+			1.42343711074968357734) /
+			(((((((r*1.05075007164441684324e-9+
+				5.475938084995344946e-4)*r+0.0151986665636164571966)*r+
+				0.14810397642748007459)*r+0.68976733498510000455)*r+
+				1.6763848301838038494)*r+2.05319162663775882187)*r + 1)
+	} else {
+		r -= 5
+		z = (((((((r*2.01033439929228813265e-7+
+			2.71155556874348757815e-5)*r+0.0012426609473880784386)*r+
+			0.026532189526576123093)*r+0.29656057182850489123)*r+
+			1.7848265399172913358)*r+5.4637849111641143699)*r +
+			6.6579046435011037772) /
+			(((((((r*2.04426310338993978564e-15+
+				1.4215117583164458887e-7)*r+1.8463183175100546818e-5)*r+
+				7.868691311456132591e-4)*r+0.0148753612908506148525)*r+
+				0.13692988092273580531)*r+0.59983220655588793769)*r + 1)
+	}
+	if q < 0 {
+		return -z
+	}
+	return z
+}
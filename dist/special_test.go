@@ -0,0 +1,63 @@
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+// near reports whether got agrees with want to a relative error of tol.
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol*math.Abs(want)
+}
+
+// TestNdtri checks the normal quantile against the test values of
+// Wichura's AS 241 and, in the tails, against Φ⁻¹ solved to full
+// precision from erfc. 1 − 1e-16 rounds to 1 − 2⁻⁵³, the largest
+// probability below 1.
+func TestNdtri(t *testing.T) {
+	for _, c := range []struct {
+		p, want float64
+	}{
+		{0.5, 0},
+		{0.975, 1.959963984540054},
+		{0.025, -1.959963984540054},
+		{0.25, -0.6744897501960817},
+		{1e-5, -4.264890793922825},
+		{1e-10, -6.361340902404056},
+		{1e-300, -37.0470962993612},
+		{1 - 1e-16, 8.209536151601387},
+	} {
+		if got := ndtri(c.p); !near(got, c.want, 1e-14) && got != c.want {
+			t.Errorf("ndtri(%g) = %.16g, want %.16g", c.p, got, c.want)
+		}
+	}
+	if got := ndtri(0); !math.IsInf(got, -1) {
+		t.Errorf("ndtri(0) = %g, want -Inf", got)
+	}
+	if got := ndtri(1); !math.IsInf(got, 1) {
+		t.Errorf("ndtri(1) = %g, want +Inf", got)
+	}
+}
+
+// TestQuantiles checks the quantiles of the sampling distributions
+// against the critical values printed in statistical tables, to the
+// digits of R's qt, qchisq and qf.
+func TestQuantiles(t *testing.T) {
+	for _, c := range []struct {
+		d       Distribution
+		p, want float64
+	}{
+		{StudentT{DF: 1}, 0.975, 12.70620473617471},
+		{StudentT{DF: 10}, 0.975, 2.228138851986274},
+		{StudentT{DF: 30}, 0.995, 2.749995653567090},
+		{ChiSquare{DF: 1}, 0.95, 3.841458820694124},
+		{ChiSquare{DF: 10}, 0.95, 18.30703805327515},
+		{ChiSquare{DF: 10}, 0.05, 3.940299136119258},
+		{F{D1: 5, D2: 10}, 0.95, 3.325834530413011},
+		{F{D1: 1, D2: 20}, 0.99, 8.095958064085690},
+	} {
+		if got := c.d.Quantile(c.p); !near(got, c.want, 1e-9) {
+			t.Errorf("%v.Quantile(%g) = %.16g, want %.16g", c.d, c.p, got, c.want)
+		}
+	}
+}
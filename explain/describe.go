@@ -0,0 +1,344 @@
+package explain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+// undefined returns the derivation of a statistic that x has too few
+// values for.
+func undefined(name string, need int) *Derivation {
+	return &Derivation{
+		Name:   name,
+		Result: fmt.Sprintf("%s is undefined for fewer than %d values", name, need),
+		Value:  math.NaN(),
+	}
+}
+
+func sorted(x []float64) []float64 {
+	s := make([]float64, len(x))
+	copy(s, x)
+	sort.Float64s(s)
+	return s
+}
+
+// texTerms joins the terms of a sum for an align environment, breaking
+// the line after every 10 terms.
+func (o Options) texTerms(x []float64) string {
+	var lines []string
+	for i := 0; i < len(x); i += 10 {
+		lines = append(lines, o.nums(x[i:min(i+10, len(x))], " + "))
+	}
+	return strings.Join(lines, ` \\ &\quad + `)
+}
+
+// Mean returns the derivation of the arithmetic mean of x.
+func Mean(x []float64, o Options) *Derivation {
+	n := len(x)
+	if n == 0 {
+		return undefined("Mean", 1)
+	}
+	if q, ok := rats(x); o.Exact && ok {
+		return exactMean(x, q, o)
+	}
+	sum, mean := stats.Sum(x), stats.Mean(x)
+	d := &Derivation{
+		Name:       "Mean",
+		Formula:    "x̄ = Σx / n",
+		FormulaTeX: `\bar{x} &= \frac{\sum x}{n}`,
+		Result:     "Mean = " + o.withUnit(mean),
+		Value:      mean,
+	}
+	d.add(Full, fmt.Sprintf("Count the values: n = %d", n), "")
+	d.add(Full,
+		fmt.Sprintf("Add them: Σx = %s = %s", o.nums(x, " + "), o.num(sum)),
+		fmt.Sprintf(`\sum x &= %s \\ &= %s`, o.texTerms(x), o.num(sum)))
+	d.add(Key,
+		fmt.Sprintf("x̄ = Σx / n = %s / %d = %s", o.num(sum), n, o.withUnit(mean)),
+		fmt.Sprintf(`\bar{x} &= \frac{%s}{%d} = %s%s`, o.num(sum), n, o.num(mean), o.texUnit()))
+	return d
+}
+
+// Median returns the derivation of the median of x: the middle value
+// of the sorted data for odd n, the average of the two middle values
+// for even n.
+func Median(x []float64, o Options) *Derivation {
+	n := len(x)
+	if n == 0 {
+		return undefined("Median", 1)
+	}
+	if _, ok := rats(x); o.Exact && ok {
+		return exactMedian(x, o)
+	}
+	s := sorted(x)
+	d := &Derivation{
+		Name:       "Median",
+		Formula:    "Median = the middle value of the sorted data",
+		FormulaTeX: `\tilde{x} &= \begin{cases} x_{((n+1)/2)} & n \text{ odd} \\ \frac{x_{(n/2)} + x_{(n/2+1)}}{2} & n \text{ even} \end{cases}`,
+	}
+	d.add(Full, "Sort the values: "+o.nums(s, ", "), "")
+	var median float64
+	if n%2 == 0 {
+		i := n / 2
+		median = (s[i-1] + s[i]) / 2
+		d.add(Key, fmt.Sprintf("n = %d is even, so the median is the average of the values at positions %d and %d", n, i, i+1), "")
+		d.add(Key,
+			fmt.Sprintf("Median = (%s + %s) / 2 = %s", o.num(s[i-1]), o.num(s[i]), o.withUnit(median)),
+			fmt.Sprintf(`\tilde{x} &= \frac{x_{(%d)} + x_{(%d)}}{2} = \frac{%s + %s}{2} = %s%s`, i, i+1, o.num(s[i-1]), o.num(s[i]), o.num(median), o.texUnit()))
+	} else {
+		i := (n + 1) / 2
+		median = s[i-1]
+		d.add(Key, fmt.Sprintf("n = %d is odd, so the median is the value at position (n + 1) / 2 = %d", n, i), "")
+		d.add(Key,
+			fmt.Sprintf("Median = x(%d) = %s", i, o.withUnit(median)),
+			fmt.Sprintf(`\tilde{x} &= x_{(%d)} = %s%s`, i, o.num(median), o.texUnit()))
+	}
+	d.Result = "Median = " + o.withUnit(median)
+	d.Value = median
+	return d
+}
+
+// Mode returns the derivation of the modes of x, the values that occur
+// most often. There is no mode when every value occurs once, and
+// several when values tie for the highest frequency.
+func Mode(x []float64, o Options) *Derivation {
+	n := len(x)
+	if n == 0 {
+		return undefined("Mode", 1)
+	}
+	s := sorted(x)
+	var values []float64
+	var counts []int
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			values = append(values, v)
+			counts = append(counts, 0)
+		}
+		counts[len(counts)-1]++
+	}
+	maxCount := 0
+	t := report.Table{Name: "Frequency of each value", Columns: []string{"Value", "Frequency"}}
+	for i, v := range values {
+		maxCount = max(maxCount, counts[i])
+		t.Rows = append(t.Rows, []any{o.num(v), counts[i]})
+	}
+	modes := []float64{}
+	for i, v := range values {
+		if counts[i] == maxCount && maxCount > 1 {
+			modes = append(modes, v)
+		}
+	}
+
+	d := &Derivation{
+		Name:    "Mode",
+		Formula: "Mode = the most frequent value(s)",
+		Value:   modes,
+	}
+	d.addTable(Full, t)
+	d.add(Key, fmt.Sprintf("The highest frequency is %d", maxCount), "")
+	list := o.nums(modes, ", ")
+	if o.Unit != "" {
+		list += " " + o.Unit
+	}
+	switch len(modes) {
+	case 0:
+		d.add(Key, "Every value appears exactly once, so there is no mode", "")
+		d.Result = "No mode (all values are unique)"
+	case 1:
+		d.add(Key, fmt.Sprintf("Only %s appears %d times, so it is the single mode", o.num(modes[0]), maxCount), "")
+		d.Result = "Mode = " + list
+	default:
+		kind := "multimodal"
+		if len(modes) == 2 {
+			kind = "bimodal"
+		}
+		d.add(Key, fmt.Sprintf("%s each appear %d times, tied for the highest frequency, so the data are %s", o.nums(modes, ", "), maxCount, kind), "")
+		d.Result = fmt.Sprintf("Modes = %s (%s)", list, kind)
+	}
+	return d
+}
+
+// Range returns the derivation of the range of x, max − min.
+func Range(x []float64, o Options) *Derivation {
+	if len(x) == 0 {
+		return undefined("Range", 1)
+	}
+	s := sorted(x)
+	lo, hi := s[0], s[len(s)-1]
+	d := &Derivation{
+		Name:       "Range",
+		Formula:    "Range = Max − Min",
+		FormulaTeX: `\text{Range} &= x_{\max} - x_{\min}`,
+		Result:     "Range = " + o.withUnit(hi-lo),
+		Value:      hi - lo,
+	}
+	d.add(Full, fmt.Sprintf("The smallest value is %s and the largest %s", o.num(lo), o.num(hi)), "")
+	d.add(Key,
+		fmt.Sprintf("Range = %s − %s = %s", o.num(hi), o.num(lo), o.withUnit(hi-lo)),
+		fmt.Sprintf(`\text{Range} &= %s - %s = %s%s`, o.num(hi), o.num(lo), o.num(hi-lo), o.texUnit()))
+	return d
+}
+
+// Variance returns the derivation of the variance of x: of the
+// population, dividing by n, or of a sample, dividing by n − 1.
+func Variance(x []float64, population bool, o Options) *Derivation {
+	n := len(x)
+	// The notation of the population and of a sample.
+	mean, sym, symTeX, meanTeX, div, divTeX := "μ", "σ²", `\sigma^2`, `\mu`, fmt.Sprint(n), fmt.Sprint(n)
+	if !population {
+		if n < 2 {
+			return undefined("Variance", 2)
+		}
+		mean, sym, symTeX, meanTeX = "x̄", "s²", `s^2`, `\bar{x}`
+		div, divTeX = fmt.Sprintf("(%d − 1)", n), fmt.Sprintf("%d - 1", n)
+	} else if n == 0 {
+		return undefined("Variance", 1)
+	}
+	if q, ok := rats(x); o.Exact && ok {
+		return exactVariance(x, q, population, o)
+	}
+	sum, m := stats.Sum(x), stats.Mean(x)
+	t := report.Table{Name: "Squared deviations from the mean", Columns: []string{"x", "x − " + mean, "(x − " + mean + ")²"}}
+	for _, v := range x {
+		t.Rows = append(t.Rows, []any{o.num(v), o.num(v - m), o.num((v - m) * (v - m))})
+	}
+	// The variance is that of package stats, by the corrected two-pass
+	// algorithm, and the sum of squares shown is derived from it.
+	nd, v := float64(n), stats.PopVariance(x)
+	if !population {
+		nd, v = nd-1, stats.Variance(x)
+	}
+	ss := v * nd
+
+	d := &Derivation{Name: "Variance", Value: v}
+	if population {
+		d.Formula = "σ² = Σ(x − μ)² / n"
+		d.FormulaTeX = `\sigma^2 &= \frac{\sum (x - \mu)^2}{n}`
+	} else {
+		d.Formula = "s² = Σ(x − x̄)² / (n − 1)"
+		d.FormulaTeX = `s^2 &= \frac{\sum (x - \bar{x})^2}{n - 1}`
+	}
+	unit2 := ""
+	if o.Unit != "" {
+		unit2 = " " + o.Unit + "²"
+	}
+	d.Result = "Variance = " + o.num(v) + unit2
+	d.add(Key,
+		fmt.Sprintf("%s = %s / %d = %s", mean, o.num(sum), n, o.withUnit(m)),
+		fmt.Sprintf(`%s &= \frac{%s}{%d} = %s%s`, meanTeX, o.num(sum), n, o.num(m), o.texUnit()))
+	d.addTable(Full, t)
+	d.add(Key,
+		fmt.Sprintf("Σ(x − %s)² = %s", mean, o.num(ss)),
+		fmt.Sprintf(`\sum (x - %s)^2 &= %s`, meanTeX, o.num(ss)))
+	d.add(Key,
+		fmt.Sprintf("%s = %s / %s = %s%s", sym, o.num(ss), div, o.num(v), unit2),
+		fmt.Sprintf(`%s &= \frac{%s}{%s} = %s%s`, symTeX, o.num(ss), divTeX, o.num(v), o.texSquaredUnit()))
+	return d
+}
+
+// StdDev returns the derivation of the standard deviation of x, the
+// square root of its Variance, with the steps of the variance.
+func StdDev(x []float64, population bool, o Options) *Derivation {
+	v := Variance(x, population, o)
+	d := StdDevFrom(v, population, o)
+	if d.Formula != "" {
+		d.Formula += ", where " + v.Formula
+		d.FormulaTeX += `, \quad ` + strings.Replace(v.FormulaTeX, "&", "", 1)
+		d.Steps = append(v.Steps, d.Steps...)
+	}
+	return d
+}
+
+// StdDevFrom returns the derivation of the standard deviation from the
+// derivation v of the variance, for showing after it.
+func StdDevFrom(v *Derivation, population bool, o Options) *Derivation {
+	variance, ok := v.Value.(float64)
+	if !ok || math.IsNaN(variance) {
+		if population {
+			return undefined("Standard deviation", 1)
+		}
+		return undefined("Standard deviation", 2)
+	}
+	sd := math.Sqrt(variance)
+	d := &Derivation{
+		Name:       "Standard deviation",
+		Formula:    "s = √s²",
+		FormulaTeX: `s &= \sqrt{s^2}`,
+		Result:     "Standard deviation = " + o.withUnit(sd),
+		Value:      sd,
+	}
+	sym, symTeX := "s", "s"
+	if population {
+		sym, symTeX = "σ", `\sigma`
+		d.Formula, d.FormulaTeX = "σ = √σ²", `\sigma &= \sqrt{\sigma^2}`
+	}
+	if o.Exact && v.Exact != nil {
+		o.exactStdDev(d, v, sym, symTeX)
+		return d
+	}
+	d.add(Key,
+		fmt.Sprintf("%s = √%s = %s", sym, o.num(variance), o.withUnit(sd)),
+		fmt.Sprintf(`%s &= \sqrt{%s} = %s%s`, symTeX, o.num(variance), o.num(sd), o.texUnit()))
+	return d
+}
+
+// IQR returns the derivation of the interquartile range of x, with the
+// quartiles as the medians of the lower and upper halves of the sorted
+// data (Tukey's hinges); for odd n the median is left out of both
+// halves.
+func IQR(x []float64, o Options) *Derivation {
+	n := len(x)
+	if n < 2 {
+		return undefined("IQR", 2)
+	}
+	s := sorted(x)
+	h := n / 2
+	lower, upper := s[:h], s[n-h:]
+	d := &Derivation{
+		Name:       "Interquartile range",
+		Formula:    "IQR = Q3 − Q1, where Q1 and Q3 are the medians of the lower and upper halves of the sorted data",
+		FormulaTeX: `\mathrm{IQR} &= Q_3 - Q_1`,
+	}
+	d.add(Full, "Sort the values: "+o.nums(s, ", "), "")
+	if n%2 == 0 {
+		d.add(Key, fmt.Sprintf("n = %d is even, so the lower half is the first %d values and the upper half the last %d", n, h, h), "")
+	} else {
+		d.add(Key, fmt.Sprintf("n = %d is odd, so the median x(%d) is left out: the lower half is the first %d values and the upper half the last %d", n, h+1, h, h), "")
+	}
+	d.add(Full, "Lower half: "+o.nums(lower, ", "), "")
+	d.add(Full, "Upper half: "+o.nums(upper, ", "), "")
+	q1 := o.halfMedian(d, "Q1", "Q_1", lower, 0)
+	q3 := o.halfMedian(d, "Q3", "Q_3", upper, n-h)
+	iqr := q3 - q1
+	d.add(Key,
+		fmt.Sprintf("IQR = Q3 − Q1 = %s − %s = %s", o.num(q3), o.num(q1), o.withUnit(iqr)),
+		fmt.Sprintf(`\mathrm{IQR} &= %s - %s = %s%s`, o.num(q3), o.num(q1), o.num(iqr), o.texUnit()))
+	d.Result = "IQR = " + o.withUnit(iqr)
+	d.Value = iqr
+	return d
+}
+
+// halfMedian adds the step finding the median of half, the sorted
+// values from position offset+1 of the data, as the quartile q, and
+// returns it.
+func (o Options) halfMedian(d *Derivation, q, qTeX string, half []float64, offset int) float64 {
+	m := len(half)
+	if m%2 == 1 {
+		i := m / 2
+		d.add(Key,
+			fmt.Sprintf("%s is the middle value of its half, %d values: %s = x(%d) = %s", q, m, q, offset+i+1, o.num(half[i])),
+			fmt.Sprintf(`%s &= x_{(%d)} = %s%s`, qTeX, offset+i+1, o.num(half[i]), o.texUnit()))
+		return half[i]
+	}
+	i := m / 2
+	v := (half[i-1] + half[i]) / 2
+	d.add(Key,
+		fmt.Sprintf("%s is the average of the two middle values of its half, %d values: %s = (%s + %s) / 2 = %s", q, m, q, o.num(half[i-1]), o.num(half[i]), o.num(v)),
+		fmt.Sprintf(`%s &= \frac{x_{(%d)} + x_{(%d)}}{2} = \frac{%s + %s}{2} = %s%s`, qTeX, offset+i, offset+i+1, o.num(half[i-1]), o.num(half[i]), o.num(v), o.texUnit()))
+	return v
+}
@@ -0,0 +1,359 @@
+package explain
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+// In exact mode the derivations work in big.Rat, so that a mean of
+// 1201 / 30 is 1201/30 and not 40.0333. The data are read as the
+// decimals they were written as, so 0.1 is 1/10 and not the binary
+// fraction nearest it.
+
+// ratOf returns v as a fraction, the shortest decimal that is v.
+func ratOf(v float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	return r
+}
+
+// rats returns the values of x as fractions, and false if any is not a
+// finite number.
+func rats(x []float64) ([]*big.Rat, bool) {
+	q := make([]*big.Rat, len(x))
+	for i, v := range x {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, false
+		}
+		q[i] = ratOf(v)
+	}
+	return q, true
+}
+
+func toFloat(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+// places returns the number of decimal places of r, and false if its
+// decimals do not end: if its denominator has a prime factor other
+// than 2 and 5.
+func places(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+	fives := 0
+	five, q, m := big.NewInt(5), new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(d, five, m)
+		if m.Sign() != 0 {
+			break
+		}
+		d.Set(q)
+		fives++
+	}
+	return max(twos, fives), d.IsInt64() && d.Int64() == 1
+}
+
+// exactString returns r as a decimal if its decimals end, otherwise as
+// a fraction.
+func exactString(r *big.Rat) string {
+	if p, ok := places(r); ok {
+		return r.FloatString(p)
+	}
+	return r.String()
+}
+
+// operand is exactString, parenthesized if a fraction, for use as an
+// operand.
+func operand(r *big.Rat) string {
+	s := exactString(r)
+	if strings.Contains(s, "/") {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// sigFigures returns r rounded to sig significant figures.
+func sigFigures(r *big.Rat, sig int) string {
+	f := toFloat(r)
+	if f == 0 {
+		return "0"
+	}
+	p := sig - 1 - int(math.Floor(math.Log10(math.Abs(f))))
+	if p >= 0 {
+		return r.FloatString(p)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-p)), nil)
+	i, _ := new(big.Int).SetString(new(big.Rat).Quo(r, new(big.Rat).SetInt(scale)).FloatString(0), 10)
+	return i.Mul(i, scale).String()
+}
+
+func (o Options) sig() int {
+	if o.Sig == 0 {
+		return 6
+	}
+	return o.Sig
+}
+
+// number is a value formatted for a step.
+type number struct {
+	text, tex string
+	// rounded is set when the decimal shown is not the exact value.
+	rounded bool
+}
+
+// rat formats r. In exact mode it is shown in full when its decimals
+// end, and otherwise as the fraction and its decimal to Sig significant
+// figures; out of exact mode it is rounded as num does.
+func (o Options) rat(r *big.Rat) number {
+	if !o.Exact {
+		s := o.num(toFloat(r))
+		return number{text: s, tex: s}
+	}
+	if s := exactString(r); !strings.Contains(s, "/") {
+		return number{text: s, tex: s}
+	}
+	dec := sigFigures(r, o.sig())
+	return number{
+		text:    fmt.Sprintf("%s ≈ %s", r.String(), dec),
+		tex:     fmt.Sprintf(`%s \approx %s`, ratTeX(r), dec),
+		rounded: true,
+	}
+}
+
+// quotient is rat for r = a / n when written after "a / n": the
+// fraction is left out when it would only repeat a / n.
+func (o Options) quotient(r *big.Rat, n int) number {
+	q := o.rat(r)
+	if q.rounded && r.Denom().Cmp(big.NewInt(int64(n))) == 0 {
+		dec := sigFigures(r, o.sig())
+		q.text, q.tex = "≈ "+dec, `\approx `+dec
+	} else {
+		q.text, q.tex = "= "+q.text, "= "+q.tex
+	}
+	return q
+}
+
+// ratUnit is rat followed by the unit, if any.
+func (o Options) ratUnit(n number) string {
+	if o.Unit == "" {
+		return n.text
+	}
+	return n.text + " " + o.Unit
+}
+
+// datum formats a value of the data: in exact mode as written,
+// otherwise as num does.
+func (o Options) datum(v float64) string {
+	if o.Exact {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return o.num(v)
+}
+
+func (o Options) data(x []float64, sep string) string {
+	s := make([]string, len(x))
+	for i, v := range x {
+		s[i] = o.datum(v)
+	}
+	return strings.Join(s, sep)
+}
+
+// texData is texTerms for the data in exact mode.
+func (o Options) texData(x []float64) string {
+	var lines []string
+	for i := 0; i < len(x); i += 10 {
+		lines = append(lines, o.data(x[i:min(i+10, len(x))], " + "))
+	}
+	return strings.Join(lines, ` \\ &\quad + `)
+}
+
+// addExact adds a step showing the numbers nums, flagging it if it is
+// the first in d to round one of them.
+func (d *Derivation) addExact(level Level, text, tex string, nums ...number) {
+	for _, n := range nums {
+		if n.rounded && !d.rounded {
+			d.rounded = true
+			text += " ← rounding first occurs here"
+			if tex != "" {
+				tex += `\quad \text{(rounding first occurs here)}`
+			}
+			break
+		}
+	}
+	d.add(level, text, tex)
+}
+
+// exactMean is Mean in exact mode.
+func exactMean(x []float64, q []*big.Rat, o Options) *Derivation {
+	n := len(x)
+	sum := new(big.Rat)
+	for _, r := range q {
+		sum.Add(sum, r)
+	}
+	mean := new(big.Rat).Quo(sum, big.NewRat(int64(n), 1))
+	s, m := o.rat(sum), o.rat(mean)
+	mq := o.quotient(mean, n)
+	d := &Derivation{
+		Name:       "Mean",
+		Formula:    "x̄ = Σx / n",
+		FormulaTeX: `\bar{x} &= \frac{\sum x}{n}`,
+		Result:     "Mean = " + o.ratUnit(m),
+		Value:      toFloat(mean),
+		Exact:      mean,
+	}
+	d.add(Full, fmt.Sprintf("Count the values: n = %d", n), "")
+	d.addExact(Full,
+		fmt.Sprintf("Add them: Σx = %s = %s", o.data(x, " + "), s.text),
+		fmt.Sprintf(`\sum x &= %s \\ &= %s`, o.texData(x), s.tex), s)
+	d.addExact(Key,
+		fmt.Sprintf("x̄ = Σx / n = %s / %d %s", s.text, n, o.ratUnit(mq)),
+		fmt.Sprintf(`\bar{x} &= \frac{%s}{%d} %s%s`, s.tex, n, mq.tex, o.texUnit()), mq)
+	return d
+}
+
+// exactMedian is Median in exact mode.
+func exactMedian(x []float64, o Options) *Derivation {
+	n := len(x)
+	s := sorted(x)
+	d := &Derivation{
+		Name:       "Median",
+		Formula:    "Median = the middle value of the sorted data",
+		FormulaTeX: `\tilde{x} &= \begin{cases} x_{((n+1)/2)} & n \text{ odd} \\ \frac{x_{(n/2)} + x_{(n/2+1)}}{2} & n \text{ even} \end{cases}`,
+	}
+	d.add(Full, "Sort the values: "+o.data(s, ", "), "")
+	var median *big.Rat
+	if n%2 == 0 {
+		i := n / 2
+		median = new(big.Rat).Add(ratOf(s[i-1]), ratOf(s[i]))
+		median.Quo(median, big.NewRat(2, 1))
+		m := o.rat(median)
+		d.add(Key, fmt.Sprintf("n = %d is even, so the median is the average of the values at positions %d and %d", n, i, i+1), "")
+		d.addExact(Key,
+			fmt.Sprintf("Median = (%s + %s) / 2 = %s", o.datum(s[i-1]), o.datum(s[i]), o.ratUnit(m)),
+			fmt.Sprintf(`\tilde{x} &= \frac{x_{(%d)} + x_{(%d)}}{2} = \frac{%s + %s}{2} = %s%s`, i, i+1, o.datum(s[i-1]), o.datum(s[i]), m.tex, o.texUnit()), m)
+	} else {
+		i := (n + 1) / 2
+		median = ratOf(s[i-1])
+		m := o.rat(median)
+		d.add(Key, fmt.Sprintf("n = %d is odd, so the median is the value at position (n + 1) / 2 = %d", n, i), "")
+		d.addExact(Key,
+			fmt.Sprintf("Median = x(%d) = %s", i, o.ratUnit(m)),
+			fmt.Sprintf(`\tilde{x} &= x_{(%d)} = %s%s`, i, m.tex, o.texUnit()), m)
+	}
+	d.Result = "Median = " + o.ratUnit(o.rat(median))
+	d.Value = toFloat(median)
+	d.Exact = median
+	return d
+}
+
+// exactVariance is Variance in exact mode.
+func exactVariance(x []float64, q []*big.Rat, population bool, o Options) *Derivation {
+	n := len(x)
+	mean, sym, symTeX, meanTeX, div, divTeX := "μ", "σ²", `\sigma^2`, `\mu`, fmt.Sprint(n), fmt.Sprint(n)
+	nd := int64(n)
+	if !population {
+		mean, sym, symTeX, meanTeX = "x̄", "s²", `s^2`, `\bar{x}`
+		div, divTeX = fmt.Sprintf("(%d − 1)", n), fmt.Sprintf("%d - 1", n)
+		nd--
+	}
+	sum := new(big.Rat)
+	for _, r := range q {
+		sum.Add(sum, r)
+	}
+	m := new(big.Rat).Quo(sum, big.NewRat(int64(n), 1))
+	ss := new(big.Rat)
+	t := report.Table{Name: "Squared deviations from the mean", Columns: []string{"x", "x − " + mean, "(x − " + mean + ")²"}}
+	var cells []number
+	for i, r := range q {
+		dev := new(big.Rat).Sub(r, m)
+		sq := new(big.Rat).Mul(dev, dev)
+		ss.Add(ss, sq)
+		dn, sn := o.rat(dev), o.rat(sq)
+		cells = append(cells, dn, sn)
+		t.Rows = append(t.Rows, []any{o.datum(x[i]), dn.text, sn.text})
+	}
+	v := new(big.Rat).Quo(ss, big.NewRat(nd, 1))
+
+	d := &Derivation{Name: "Variance", Value: toFloat(v), Exact: v}
+	if population {
+		d.Formula = "σ² = Σ(x − μ)² / n"
+		d.FormulaTeX = `\sigma^2 &= \frac{\sum (x - \mu)^2}{n}`
+	} else {
+		d.Formula = "s² = Σ(x − x̄)² / (n − 1)"
+		d.FormulaTeX = `s^2 &= \frac{\sum (x - \bar{x})^2}{n - 1}`
+	}
+	unit2 := ""
+	if o.Unit != "" {
+		unit2 = " " + o.Unit + "²"
+	}
+	sn, mn, vn := o.rat(sum), o.quotient(m, n), o.rat(v)
+	d.Result = "Variance = " + vn.text + unit2
+	d.addExact(Key,
+		fmt.Sprintf("%s = %s / %d %s", mean, sn.text, n, o.ratUnit(mn)),
+		fmt.Sprintf(`%s &= \frac{%s}{%d} %s%s`, meanTeX, sn.tex, n, mn.tex, o.texUnit()), sn, mn)
+	d.addTable(Full, t)
+	// The table's rounding is flagged at the step that follows it.
+	ssn := o.rat(ss)
+	d.addExact(Key,
+		fmt.Sprintf("Σ(x − %s)² = %s", mean, ssn.text),
+		fmt.Sprintf(`\sum (x - %s)^2 &= %s`, meanTeX, ssn.tex), append(cells, ssn)...)
+	d.addExact(Key,
+		fmt.Sprintf("%s = %s / %s = %s%s", sym, operand(ss), div, vn.text, unit2),
+		fmt.Sprintf(`%s &= \frac{%s}{%s} = %s%s`, symTeX, ratTeX(ss), divTeX, vn.tex, o.texSquaredUnit()), vn)
+	return d
+}
+
+// exactSqrt returns the square root of r if it is rational: if its
+// numerator and denominator are perfect squares.
+func exactSqrt(r *big.Rat) (*big.Rat, bool) {
+	if r.Sign() < 0 {
+		return nil, false
+	}
+	num := new(big.Int).Sqrt(r.Num())
+	den := new(big.Int).Sqrt(r.Denom())
+	if new(big.Int).Mul(num, num).Cmp(r.Num()) != 0 || new(big.Int).Mul(den, den).Cmp(r.Denom()) != 0 {
+		return nil, false
+	}
+	return new(big.Rat).SetFrac(num, den), true
+}
+
+// exactStdDev adds to d, the derivation of the standard deviation, the
+// step from the exact variance v in exact mode. The root is exact when
+// the variance is the square of a fraction, and otherwise rounds.
+func (o Options) exactStdDev(d, v *Derivation, sym, symTeX string) {
+	d.rounded = v.rounded
+	eq, s := "= ", number{}
+	if sd, ok := exactSqrt(v.Exact); ok {
+		s = o.rat(sd)
+		d.Value, d.Exact = toFloat(sd), sd
+	} else {
+		f := math.Sqrt(toFloat(v.Exact))
+		dec := sigFigures(new(big.Rat).SetFloat64(f), o.sig())
+		eq, s = "", number{text: "≈ " + dec, tex: `\approx ` + dec, rounded: true}
+		d.Value = f
+	}
+	d.Result = "Standard deviation " + eq + o.ratUnit(s)
+	d.addExact(Key,
+		fmt.Sprintf("%s = √(%s) %s%s", sym, exactString(v.Exact), eq, o.ratUnit(s)),
+		fmt.Sprintf(`%s &= \sqrt{%s} %s%s%s`, symTeX, ratTeX(v.Exact), eq, s.tex, o.texUnit()), s)
+}
+
+// ratTeX returns r exactly as LaTeX: a decimal if its decimals end,
+// otherwise a fraction.
+func ratTeX(r *big.Rat) string {
+	s := exactString(r)
+	if i := strings.Index(s, "/"); i >= 0 {
+		sign := ""
+		if s[0] == '-' {
+			sign, s, i = "-", s[1:], i-1
+		}
+		return fmt.Sprintf(`%s\frac{%s}{%s}`, sign, s[:i], s[i+1:])
+	}
+	return s
+}
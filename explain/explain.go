@@ -0,0 +1,172 @@
+// Package explain records how statistics are computed as structured
+// derivations, for rendering as text, Markdown, LaTeX or HTML at a
+// chosen level of detail.
+//
+// Each function computes one statistic from the data and returns its
+// Derivation: the formula, the steps with the numbers substituted, and
+// the result. The steps follow the data: the median of an even number
+// of values averages the two middle ones, the mode says whether there
+// is none, one or several.
+package explain
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+// Level is how much of a derivation is shown.
+type Level int
+
+const (
+	// Answer shows the result only.
+	Answer Level = iota
+	// Key adds the steps that lead to the result.
+	Key
+	// Full shows the complete working: every term of the sums and the
+	// tables of intermediate values.
+	Full
+)
+
+var levelNames = [...]string{Answer: "answer", Key: "key", Full: "full"}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns the level named name: answer, key or full.
+func ParseLevel(name string) (Level, error) {
+	for l, n := range levelNames {
+		if n == name {
+			return Level(l), nil
+		}
+	}
+	return Answer, fmt.Errorf("unknown level %q (want answer, key or full)", name)
+}
+
+// Set implements flag.Value.
+func (l *Level) Set(s string) error {
+	v, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	*l = v
+	return nil
+}
+
+// Step is one step of a derivation, shown from Level on. Text is the
+// step in words and plain symbols; TeX, if set, the same step as LaTeX
+// math for an align environment. A step may instead hold a table of
+// intermediate values.
+type Step struct {
+	Level Level
+	Text  string
+	TeX   string
+	Table *report.Table
+}
+
+// Derivation is the computation of one statistic.
+type Derivation struct {
+	// Name is the statistic, e.g. "Median".
+	Name string
+	// Formula is the definition in plain symbols, FormulaTeX in LaTeX.
+	Formula    string
+	FormulaTeX string
+	Steps      []Step
+	// Result is the answer with its unit, e.g. "Median = 40 hours".
+	Result string
+	// Value is the value of the statistic: a float64, or a []float64
+	// for the modes.
+	Value any
+	// Exact is the value as a fraction, in exact mode, when it is
+	// rational.
+	Exact *big.Rat
+
+	rounded bool // whether a step has rounded, in exact mode
+}
+
+// Options controls how numbers are shown.
+type Options struct {
+	// Unit is appended to the results, e.g. "kg".
+	Unit string
+	// Precision is the number of decimal places, trailing zeros
+	// dropped; zero means 4.
+	Precision int
+	// Exact does the arithmetic in fractions rather than floating
+	// point. Numbers are shown in full when their decimals end, and
+	// otherwise as a fraction with its decimal to Sig significant
+	// figures; the first step that rounds is flagged.
+	Exact bool
+	// Sig is the number of significant figures of rounded decimals in
+	// exact mode; zero means 6.
+	Sig int
+}
+
+func (o Options) num(v float64) string {
+	p := o.Precision
+	if p == 0 {
+		p = 4
+	}
+	s := strconv.FormatFloat(v, 'f', p, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+func (o Options) nums(x []float64, sep string) string {
+	s := make([]string, len(x))
+	for i, v := range x {
+		s[i] = o.num(v)
+	}
+	return strings.Join(s, sep)
+}
+
+// withUnit formats v followed by the unit, if any.
+func (o Options) withUnit(v float64) string {
+	if o.Unit == "" {
+		return o.num(v)
+	}
+	return o.num(v) + " " + o.Unit
+}
+
+// texUnit returns the unit as LaTeX math, after a number.
+func (o Options) texUnit() string {
+	if o.Unit == "" {
+		return ""
+	}
+	return `\ \text{` + report.TeX(o.Unit) + `}`
+}
+
+// texSquaredUnit is like texUnit for the square of the unit.
+func (o Options) texSquaredUnit() string {
+	if o.Unit == "" {
+		return ""
+	}
+	return o.texUnit() + "^2"
+}
+
+func (d *Derivation) add(level Level, text, tex string) {
+	d.Steps = append(d.Steps, Step{Level: level, Text: text, TeX: tex})
+}
+
+func (d *Derivation) addTable(level Level, t report.Table) {
+	d.Steps = append(d.Steps, Step{Level: level, Table: &t})
+}
+
+// Shown returns the steps of d shown at level.
+func (d *Derivation) Shown(level Level) []Step {
+	var steps []Step
+	for _, s := range d.Steps {
+		if s.Level <= level && level > Answer {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
@@ -0,0 +1,187 @@
+package explain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+)
+
+// The grouped-data derivations estimate a statistic from a frequency
+// table alone, taking the values of each class to be at its midpoint,
+// or for the median spread evenly across it. They compute with
+// fractions, so in exact mode every step is exact until a division
+// first fails to end.
+
+// classRats returns the limits, midpoints and frequencies of classes as
+// fractions, and the total frequency.
+func classRats(classes []stats.Class) (lower, upper, mid, f []*big.Rat, n *big.Rat) {
+	n = new(big.Rat)
+	for _, c := range classes {
+		lo, hi := ratOf(c.Lower), ratOf(c.Upper)
+		m := new(big.Rat).Add(lo, hi)
+		m.Quo(m, big.NewRat(2, 1))
+		lower, upper, mid = append(lower, lo), append(upper, hi), append(mid, m)
+		f = append(f, big.NewRat(int64(c.Count), 1))
+		n.Add(n, f[len(f)-1])
+	}
+	return lower, upper, mid, f, n
+}
+
+func (o Options) class(lo, hi *big.Rat) string {
+	return exactString(lo) + "–" + exactString(hi)
+}
+
+// groupedMean returns Σfm / Σf and the table of its products.
+func (o Options) groupedMean(classes []stats.Class) (mean, sum *big.Rat, t report.Table, cells []number) {
+	lower, upper, mid, f, n := classRats(classes)
+	t = report.Table{Name: "Class midpoints and products", Columns: []string{"Class", "m", "f", "f·m"}}
+	sum = new(big.Rat)
+	for i := range classes {
+		fm := new(big.Rat).Mul(f[i], mid[i])
+		sum.Add(sum, fm)
+		mn, fmn := o.rat(mid[i]), o.rat(fm)
+		cells = append(cells, mn, fmn)
+		t.Rows = append(t.Rows, []any{o.class(lower[i], upper[i]), mn.text, classes[i].Count, fmn.text})
+	}
+	return new(big.Rat).Quo(sum, n), sum, t, cells
+}
+
+// GroupedMean returns the derivation of the mean of grouped data,
+// Σfm / Σf, m being the class midpoints and f the frequencies.
+func GroupedMean(classes []stats.Class, o Options) *Derivation {
+	_, _, _, _, n := classRats(classes)
+	if n.Sign() == 0 {
+		return undefined("Grouped mean", 1)
+	}
+	mean, sum, t, cells := o.groupedMean(classes)
+	s, m := o.rat(sum), o.rat(mean)
+	d := &Derivation{
+		Name:       "Grouped mean",
+		Formula:    "x̄ = Σfm / Σf, where m is the midpoint of each class and f its frequency",
+		FormulaTeX: `\bar{x} &= \frac{\sum f m}{\sum f}`,
+		Result:     "Grouped mean = " + o.ratUnit(m),
+		Value:      toFloat(mean),
+		Exact:      mean,
+	}
+	d.add(Full, "The midpoint of each class is (lower + upper) / 2", "")
+	d.addTable(Full, t)
+	d.addExact(Key,
+		fmt.Sprintf("Σf = %s, Σfm = %s", n.RatString(), s.text),
+		fmt.Sprintf(`\sum f &= %s, \quad \sum f m = %s`, n.RatString(), s.tex), append(cells, s)...)
+	d.addExact(Key,
+		fmt.Sprintf("x̄ = Σfm / Σf = %s / %s = %s", s.text, n.RatString(), o.ratUnit(m)),
+		fmt.Sprintf(`\bar{x} &= \frac{%s}{%s} = %s%s`, s.tex, n.RatString(), m.tex, o.texUnit()), m)
+	return d
+}
+
+// GroupedVariance returns the derivation of the variance of grouped
+// data, Σf(m − x̄)² divided by Σf for a population and by Σf − 1 for a
+// sample.
+func GroupedVariance(classes []stats.Class, population bool, o Options) *Derivation {
+	lower, upper, mid, f, n := classRats(classes)
+	mean, sym, symTeX, meanTeX := "μ", "σ²", `\sigma^2`, `\mu`
+	div := new(big.Rat).Set(n)
+	divText, divTeX := n.RatString(), n.RatString()
+	if !population {
+		if n.Cmp(big.NewRat(2, 1)) < 0 {
+			return undefined("Grouped variance", 2)
+		}
+		mean, sym, symTeX, meanTeX = "x̄", "s²", `s^2`, `\bar{x}`
+		div.Sub(div, big.NewRat(1, 1))
+		divText, divTeX = fmt.Sprintf("(%s − 1)", n.RatString()), fmt.Sprintf("%s - 1", n.RatString())
+	} else if n.Sign() == 0 {
+		return undefined("Grouped variance", 1)
+	}
+	m, sum, _, _ := o.groupedMean(classes)
+	ss := new(big.Rat)
+	t := report.Table{Name: "Squared deviations of the midpoints", Columns: []string{"Class", "m", "f", "m − " + mean, "f(m − " + mean + ")²"}}
+	var cells []number
+	for i := range classes {
+		dev := new(big.Rat).Sub(mid[i], m)
+		fsq := new(big.Rat).Mul(dev, dev)
+		fsq.Mul(fsq, f[i])
+		ss.Add(ss, fsq)
+		mn, dn, fn := o.rat(mid[i]), o.rat(dev), o.rat(fsq)
+		cells = append(cells, mn, dn, fn)
+		t.Rows = append(t.Rows, []any{o.class(lower[i], upper[i]), mn.text, classes[i].Count, dn.text, fn.text})
+	}
+	v := new(big.Rat).Quo(ss, div)
+
+	d := &Derivation{Name: "Grouped variance", Value: toFloat(v), Exact: v}
+	if population {
+		d.Formula = "σ² = Σf(m − μ)² / Σf"
+		d.FormulaTeX = `\sigma^2 &= \frac{\sum f (m - \mu)^2}{\sum f}`
+	} else {
+		d.Formula = "s² = Σf(m − x̄)² / (Σf − 1)"
+		d.FormulaTeX = `s^2 &= \frac{\sum f (m - \bar{x})^2}{\sum f - 1}`
+	}
+	unit2 := ""
+	if o.Unit != "" {
+		unit2 = " " + o.Unit + "²"
+	}
+	sn, mn, vn, ssn := o.rat(sum), o.rat(m), o.rat(v), o.rat(ss)
+	d.Result = "Grouped variance = " + vn.text + unit2
+	d.addExact(Key,
+		fmt.Sprintf("%s = Σfm / Σf = %s / %s = %s", mean, sn.text, n.RatString(), o.ratUnit(mn)),
+		fmt.Sprintf(`%s &= \frac{%s}{%s} = %s%s`, meanTeX, sn.tex, n.RatString(), mn.tex, o.texUnit()), sn, mn)
+	d.addTable(Full, t)
+	d.addExact(Key,
+		fmt.Sprintf("Σf(m − %s)² = %s", mean, ssn.text),
+		fmt.Sprintf(`\sum f (m - %s)^2 &= %s`, meanTeX, ssn.tex), append(cells, ssn)...)
+	d.addExact(Key,
+		fmt.Sprintf("%s = %s / %s = %s%s", sym, operand(ss), divText, vn.text, unit2),
+		fmt.Sprintf(`%s &= \frac{%s}{%s} = %s%s`, symTeX, ratTeX(ss), divTeX, vn.tex, o.texSquaredUnit()), vn)
+	return d
+}
+
+// GroupedMedian returns the derivation of the median of grouped data,
+// L + (n/2 − F) / f × h, where the median class is the first whose
+// cumulative frequency reaches n/2, L is its lower limit, F the
+// cumulative frequency before it, f its frequency and h its width.
+func GroupedMedian(classes []stats.Class, o Options) *Derivation {
+	lower, upper, _, f, n := classRats(classes)
+	if n.Sign() == 0 {
+		return undefined("Grouped median", 1)
+	}
+	half := new(big.Rat).Quo(n, big.NewRat(2, 1))
+	t := report.Table{Name: "Cumulative frequencies", Columns: []string{"Class", "f", "Cumulative f"}}
+	k := -1
+	cum, before := new(big.Rat), new(big.Rat)
+	for i := range classes {
+		if k < 0 {
+			before.Set(cum)
+		}
+		cum.Add(cum, f[i])
+		if k < 0 && cum.Cmp(half) >= 0 {
+			k = i
+		}
+		t.Rows = append(t.Rows, []any{o.class(lower[i], upper[i]), classes[i].Count, cum.RatString()})
+	}
+	h := new(big.Rat).Sub(upper[k], lower[k])
+	median := new(big.Rat).Sub(half, before)
+	median.Quo(median, f[k])
+	median.Mul(median, h)
+	median.Add(median, lower[k])
+
+	hn, ln, mn := o.rat(half), o.rat(lower[k]), o.rat(median)
+	d := &Derivation{
+		Name:       "Grouped median",
+		Formula:    "Median = L + (n/2 − F) / f × h",
+		FormulaTeX: `\tilde{x} &= L + \frac{n/2 - F}{f} \times h`,
+		Result:     "Grouped median = " + o.ratUnit(mn),
+		Value:      toFloat(median),
+		Exact:      median,
+	}
+	d.addTable(Full, t)
+	d.addExact(Key,
+		fmt.Sprintf("n/2 = %s / 2 = %s, so the median class is %s, the first whose cumulative frequency reaches it",
+			n.RatString(), hn.text, o.class(lower[k], upper[k])), "", hn)
+	d.add(Key,
+		fmt.Sprintf("L = %s, F = %s, f = %s, h = %s", ln.text, before.RatString(), f[k].RatString(), exactString(h)), "")
+	d.addExact(Key,
+		fmt.Sprintf("Median = %s + (%s − %s) / %s × %s = %s", ln.text, hn.text, before.RatString(), f[k].RatString(), exactString(h), o.ratUnit(mn)),
+		fmt.Sprintf(`\tilde{x} &= %s + \frac{%s - %s}{%s} \times %s = %s%s`, ln.tex, hn.tex, before.RatString(), f[k].RatString(), ratTeX(h), mn.tex, o.texUnit()), mn)
+	return d
+}
@@ -0,0 +1,207 @@
+package explain
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+// Markup is a format that derivations are rendered in.
+type Markup int
+
+// The markups.
+const (
+	Text Markup = iota
+	Markdown
+	LaTeX
+	HTML
+)
+
+var markupNames = [...]string{Text: "text", Markdown: "markdown", LaTeX: "latex", HTML: "html"}
+
+func (m Markup) String() string {
+	return markupNames[m]
+}
+
+// ParseMarkup returns the markup named name: text, markdown, latex or
+// html.
+func ParseMarkup(name string) (Markup, error) {
+	for m, n := range markupNames {
+		if n == name {
+			return Markup(m), nil
+		}
+	}
+	return Text, fmt.Errorf("unknown markup %q (want text, markdown, latex or html)", name)
+}
+
+// Set implements flag.Value.
+func (m *Markup) Set(s string) error {
+	v, err := ParseMarkup(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Write writes d to w in markup m, showing the steps of level. LaTeX is
+// written as a fragment for the body of a document like those of
+// report.Page.WriteLaTeX, HTML as a fragment for a page like those of
+// report.Page.WriteHTML.
+func (d *Derivation) Write(w io.Writer, m Markup, level Level) error {
+	switch m {
+	case Markdown:
+		_, err := io.WriteString(w, d.markdown(level))
+		return err
+	case LaTeX:
+		return d.Section(level).WriteLaTeX(w)
+	case HTML:
+		return d.Section(level).WriteHTML(w)
+	}
+	_, err := io.WriteString(w, d.text(level))
+	return err
+}
+
+// String returns d as text with the key steps.
+func (d *Derivation) String() string {
+	return d.text(Key)
+}
+
+// Section returns d as a report section titled with its name.
+func (d *Derivation) Section(level Level) *report.Section {
+	s := &report.Section{Title: d.Name}
+	d.AddTo(s, level)
+	return s
+}
+
+// AddTo appends d to s: the formula and steps of level, as derivations
+// with their LaTeX form, then the result.
+func (d *Derivation) AddTo(s *report.Section, level Level) {
+	var steps []report.Step
+	if level > Answer && d.Formula != "" {
+		steps = append(steps, report.Step{Text: d.Formula, TeX: d.FormulaTeX})
+	}
+	for _, st := range d.Shown(level) {
+		if st.Table != nil {
+			if len(steps) > 0 {
+				s.AddDerivation(steps...)
+				steps = nil
+			}
+			s.AddTable(*st.Table)
+			continue
+		}
+		steps = append(steps, report.Step{Text: st.Text, TeX: st.TeX})
+	}
+	if len(steps) > 0 {
+		s.AddDerivation(steps...)
+	}
+	s.Para("%s", d.Result)
+}
+
+// text renders d as plain text with numbered steps.
+func (d *Derivation) text(level Level) string {
+	if level == Answer {
+		return d.Result + "\n"
+	}
+	var b bytes.Buffer
+	fmt.Fprintln(&b, d.Name)
+	if d.Formula != "" {
+		fmt.Fprintf(&b, "  Formula: %s\n", d.Formula)
+	}
+	i := 0
+	for _, st := range d.Shown(level) {
+		if st.Table != nil {
+			writeTextTable(&b, st.Table)
+			continue
+		}
+		i++
+		fmt.Fprintf(&b, "  Step %d: %s\n", i, st.Text)
+	}
+	fmt.Fprintf(&b, "✓ %s\n", d.Result)
+	return b.String()
+}
+
+// writeTextTable writes t, indented under the steps, with its columns
+// padded to a common width.
+func writeTextTable(b *bytes.Buffer, t *report.Table) {
+	const indent = "    "
+	rows := [][]string{t.Columns}
+	for _, row := range t.Rows {
+		r := make([]string, len(row))
+		for j, v := range row {
+			r[j] = report.Cell(v)
+		}
+		rows = append(rows, r)
+	}
+	widths := make([]int, len(t.Columns))
+	for _, r := range rows {
+		for j, c := range r {
+			widths[j] = max(widths[j], utf8.RuneCountInString(c))
+		}
+	}
+	fmt.Fprintf(b, "  %s:\n", t.Name)
+	for i, r := range rows {
+		b.WriteString(indent)
+		for j, c := range r {
+			if j > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(c)) + c)
+		}
+		b.WriteString("\n")
+		if i == 0 {
+			total := len(widths)*2 - 2
+			for _, w := range widths {
+				total += w
+			}
+			b.WriteString(indent + strings.Repeat("-", total) + "\n")
+		}
+	}
+}
+
+// markdown renders d as Markdown: a heading, the formula, a numbered
+// list of steps with tables in between, and the result in bold.
+func (d *Derivation) markdown(level Level) string {
+	if level == Answer {
+		return "**" + d.Result + "**\n"
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "### %s\n\n", d.Name)
+	if d.Formula != "" {
+		fmt.Fprintf(&b, "*Formula:* %s\n\n", d.Formula)
+	}
+	i := 0
+	list := false
+	for _, st := range d.Shown(level) {
+		if st.Table != nil {
+			if list {
+				b.WriteString("\n")
+				list = false
+			}
+			fmt.Fprintf(&b, "*%s*\n\n", st.Table.Name)
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(st.Table.Columns, " | "))
+			b.WriteString("|" + strings.Repeat(" ---: |", len(st.Table.Columns)) + "\n")
+			for _, row := range st.Table.Rows {
+				cells := make([]string, len(row))
+				for j, v := range row {
+					cells[j] = report.Cell(v)
+				}
+				fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+			}
+			b.WriteString("\n")
+			continue
+		}
+		i++
+		fmt.Fprintf(&b, "%d. %s\n", i, st.Text)
+		list = true
+	}
+	if list {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "**%s**\n", d.Result)
+	return b.String()
+}
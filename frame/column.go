@@ -0,0 +1,272 @@
+package frame
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of values a column holds.
+type Kind int
+
+// The kinds of columns.
+const (
+	// Numeric columns hold measurements.
+	Numeric Kind = iota
+	// Nominal columns hold unordered categories.
+	Nominal
+	// Ordinal columns hold ordered categories.
+	Ordinal
+	// Boolean columns hold true or false.
+	Boolean
+	// ID columns identify the observations and are not analysed.
+	ID
+)
+
+var kindNames = [...]string{Numeric: "numeric", Nominal: "nominal", Ordinal: "ordinal", Boolean: "boolean", ID: "id"}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// ParseKind returns the kind named name: numeric, nominal, ordinal,
+// boolean or id.
+func ParseKind(name string) (Kind, error) {
+	for k, n := range kindNames {
+		if n == name {
+			return Kind(k), nil
+		}
+	}
+	return Numeric, fmt.Errorf("unknown column kind %q (want numeric, nominal, ordinal, boolean or id)", name)
+}
+
+// Categorical reports whether k is nominal, ordinal or boolean.
+func (k Kind) Categorical() bool {
+	return k == Nominal || k == Ordinal || k == Boolean
+}
+
+// Column is a named column of values of one kind.
+type Column struct {
+	Name string
+	Kind Kind
+	// Unit is the unit of a numeric column, if any.
+	Unit string
+	// Levels are the categories of a categorical column, in order for
+	// ordinal ones; boolean columns have the levels false and true.
+	Levels []string
+
+	nums  []float64 // numeric values, NaN if missing
+	codes []int     // categorical values, as indices into Levels, -1 if missing
+	ids   []string  // identifiers
+}
+
+// NewNumeric returns a numeric column of the values x, in which NaN is
+// missing.
+func NewNumeric(name, unit string, x []float64) *Column {
+	return &Column{Name: name, Kind: Numeric, Unit: unit, nums: slices.Clone(x)}
+}
+
+// NewNominal returns a nominal column of values from levels, in which
+// the empty string is missing. If levels is nil, they are the distinct
+// values, sorted numerically if they are all numbers and alphabetically
+// otherwise.
+func NewNominal(name string, levels, values []string) (*Column, error) {
+	if levels == nil {
+		levels = distinct(values)
+	}
+	return categorical(name, Nominal, levels, values)
+}
+
+// NewOrdinal returns an ordinal column of values from levels, which are
+// given in order.
+func NewOrdinal(name string, levels, values []string) (*Column, error) {
+	return categorical(name, Ordinal, levels, values)
+}
+
+// NewBoolean returns a boolean column of the values b.
+func NewBoolean(name string, b []bool) *Column {
+	c := &Column{Name: name, Kind: Boolean, Levels: []string{"false", "true"}, codes: make([]int, len(b))}
+	for i, v := range b {
+		if v {
+			c.codes[i] = 1
+		}
+	}
+	return c
+}
+
+// NewID returns a column of identifiers.
+func NewID(name string, ids []string) *Column {
+	return &Column{Name: name, Kind: ID, ids: slices.Clone(ids)}
+}
+
+func categorical(name string, kind Kind, levels, values []string) (*Column, error) {
+	c := &Column{Name: name, Kind: kind, Levels: slices.Clone(levels), codes: make([]int, len(values))}
+	for i, v := range values {
+		k := slices.Index(levels, v)
+		if v == "" {
+			k = -1
+		} else if k < 0 {
+			return nil, fmt.Errorf("column %s, row %d: %q is not one of the levels %s", name, i+1, v, strings.Join(levels, ", "))
+		}
+		c.codes[i] = k
+	}
+	return c, nil
+}
+
+// distinct returns the distinct values, sorted numerically if they are
+// all numbers and alphabetically otherwise.
+func distinct(values []string) []string {
+	var levels []string
+	for _, v := range values {
+		if v != "" && !slices.Contains(levels, v) {
+			levels = append(levels, v)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		a, errA := strconv.ParseFloat(levels[i], 64)
+		b, errB := strconv.ParseFloat(levels[j], 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return levels[i] < levels[j]
+	})
+	return levels
+}
+
+// Len returns the number of values of c.
+func (c *Column) Len() int {
+	switch c.Kind {
+	case Numeric:
+		return len(c.nums)
+	case ID:
+		return len(c.ids)
+	}
+	return len(c.codes)
+}
+
+// Float returns value i of c as a number: the value of a numeric
+// column, the 1-based rank of the level of an ordinal column, 0 or 1
+// for a boolean column, and NaN if it is missing or otherwise.
+func (c *Column) Float(i int) float64 {
+	if c.IsNA(i) {
+		return math.NaN()
+	}
+	switch c.Kind {
+	case Numeric:
+		return c.nums[i]
+	case Ordinal:
+		return float64(c.codes[i] + 1)
+	case Boolean:
+		return float64(c.codes[i])
+	}
+	return math.NaN()
+}
+
+// String returns value i of c as text, "NA" if it is missing.
+func (c *Column) String(i int) string {
+	if c.IsNA(i) {
+		return "NA"
+	}
+	switch c.Kind {
+	case Numeric:
+		return strconv.FormatFloat(c.nums[i], 'f', -1, 64)
+	case ID:
+		return c.ids[i]
+	}
+	return c.Levels[c.codes[i]]
+}
+
+// Code returns the index in Levels of value i of a categorical column,
+// or -1 if it is missing or for other columns.
+func (c *Column) Code(i int) int {
+	if !c.Kind.Categorical() {
+		return -1
+	}
+	return c.codes[i]
+}
+
+// IsNA reports whether value i of c is missing.
+func (c *Column) IsNA(i int) bool {
+	switch c.Kind {
+	case Numeric:
+		return math.IsNaN(c.nums[i])
+	case ID:
+		return c.ids[i] == ""
+	}
+	return c.codes[i] < 0
+}
+
+// Missing returns the number of missing values of c.
+func (c *Column) Missing() int {
+	n := 0
+	for i := 0; i < c.Len(); i++ {
+		if c.IsNA(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// Floats returns the values of c as numbers, as by Float.
+func (c *Column) Floats() []float64 {
+	out := make([]float64, c.Len())
+	for i := range out {
+		out[i] = c.Float(i)
+	}
+	return out
+}
+
+// Strings returns the values of c as text.
+func (c *Column) Strings() []string {
+	out := make([]string, c.Len())
+	for i := range out {
+		out[i] = c.String(i)
+	}
+	return out
+}
+
+// Counts returns the levels of a categorical column, or the distinct
+// values of another, and how often each occurs, leaving out missing
+// values. Levels that do not occur are counted as zero.
+func (c *Column) Counts() (levels []string, counts []int) {
+	if !c.Kind.Categorical() {
+		var values []string
+		for i := 0; i < c.Len(); i++ {
+			if !c.IsNA(i) {
+				values = append(values, c.String(i))
+			}
+		}
+		levels = distinct(values)
+		counts = make([]int, len(levels))
+		for _, v := range values {
+			counts[slices.Index(levels, v)]++
+		}
+		return levels, counts
+	}
+	counts = make([]int, len(c.Levels))
+	for _, k := range c.codes {
+		if k >= 0 {
+			counts[k]++
+		}
+	}
+	return slices.Clone(c.Levels), counts
+}
+
+// take returns the column of the values at rows.
+func (c *Column) take(rows []int) *Column {
+	out := &Column{Name: c.Name, Kind: c.Kind, Unit: c.Unit, Levels: slices.Clone(c.Levels)}
+	for _, i := range rows {
+		switch c.Kind {
+		case Numeric:
+			out.nums = append(out.nums, c.nums[i])
+		case ID:
+			out.ids = append(out.ids, c.ids[i])
+		default:
+			out.codes = append(out.codes, c.codes[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,399 @@
+// Package frame is a small column-oriented data frame. Each column has
+// a declared kind, so that the analyses know a nominal variable from an
+// ordinal one and an identifier from a measurement:
+//
+//	quality, err := frame.NewNominal("quality", []string{"good", "bad"}, grades)
+//	apples, err := frame.New(frame.NewNumeric("weight", "g", weights), quality)
+//	good := apples.Filter(func(r frame.Row) bool { return r.String("quality") == "good" })
+//	byQuality, err := apples.GroupBy("quality")
+//
+// Frames are not modified once built: Select, Filter and GroupBy
+// return new frames.
+package frame
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Frame is a table of columns of equal length, one row per
+// observation.
+type Frame struct {
+	cols []*Column
+}
+
+// New returns the frame of the columns cols, which must have distinct
+// names and the same length.
+func New(cols ...*Column) (*Frame, error) {
+	for i, c := range cols {
+		if c.Len() != cols[0].Len() {
+			return nil, fmt.Errorf("column %s has %d values, column %s %d", c.Name, c.Len(), cols[0].Name, cols[0].Len())
+		}
+		for _, d := range cols[:i] {
+			if d.Name == c.Name {
+				return nil, fmt.Errorf("two columns are called %s", c.Name)
+			}
+		}
+	}
+	return &Frame{cols: cols}, nil
+}
+
+// Len returns the number of rows of f.
+func (f *Frame) Len() int {
+	if len(f.cols) == 0 {
+		return 0
+	}
+	return f.cols[0].Len()
+}
+
+// Columns returns the columns of f.
+func (f *Frame) Columns() []*Column {
+	return slices.Clone(f.cols)
+}
+
+// Names returns the names of the columns of f.
+func (f *Frame) Names() []string {
+	names := make([]string, len(f.cols))
+	for i, c := range f.cols {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Column returns the column called name.
+func (f *Frame) Column(name string) (*Column, error) {
+	for _, c := range f.cols {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no column %q; the columns are %s", name, strings.Join(f.Names(), ", "))
+}
+
+// Floats returns the values of the numeric column called name.
+func (f *Frame) Floats(name string) ([]float64, error) {
+	c, err := f.Column(name)
+	if err != nil {
+		return nil, err
+	}
+	if c.Kind != Numeric {
+		return nil, fmt.Errorf("column %s is %s, not numeric", name, c.Kind)
+	}
+	return c.Floats(), nil
+}
+
+// Select returns the frame of the columns called names, in that order.
+func (f *Frame) Select(names ...string) (*Frame, error) {
+	cols := make([]*Column, len(names))
+	for i, name := range names {
+		c, err := f.Column(name)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = c
+	}
+	return New(cols...)
+}
+
+// Row is one row of a frame, as passed to the function of Filter.
+type Row struct {
+	f *Frame
+	i int
+}
+
+// Index returns the index of r in its frame.
+func (r Row) Index() int {
+	return r.i
+}
+
+// col returns the column called name, panicking if there is none, as
+// that is a mistake in the calling code rather than in the data.
+func (r Row) col(name string) *Column {
+	c, err := r.f.Column(name)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Float returns the value of column name in r as a number, as by
+// Column.Float.
+func (r Row) Float(name string) float64 {
+	return r.col(name).Float(r.i)
+}
+
+// String returns the value of column name in r as text.
+func (r Row) String(name string) string {
+	return r.col(name).String(r.i)
+}
+
+// Bool returns the value of the boolean column name in r.
+func (r Row) Bool(name string) bool {
+	c := r.col(name)
+	return c.Kind == Boolean && c.codes[r.i] == 1
+}
+
+// Rows returns the rows of f.
+func (f *Frame) Rows() []Row {
+	rows := make([]Row, f.Len())
+	for i := range rows {
+		rows[i] = Row{f, i}
+	}
+	return rows
+}
+
+// Filter returns the frame of the rows for which keep returns true.
+func (f *Frame) Filter(keep func(Row) bool) *Frame {
+	var rows []int
+	for i := 0; i < f.Len(); i++ {
+		if keep(Row{f, i}) {
+			rows = append(rows, i)
+		}
+	}
+	return f.take(rows)
+}
+
+// DropNA returns the frame of the rows of f with no missing value in
+// the columns called names, or in any column if none are named.
+func (f *Frame) DropNA(names ...string) (*Frame, error) {
+	cols := f.cols
+	if len(names) > 0 {
+		g, err := f.Select(names...)
+		if err != nil {
+			return nil, err
+		}
+		cols = g.cols
+	}
+	return f.Filter(func(r Row) bool {
+		for _, c := range cols {
+			if c.IsNA(r.i) {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+func (f *Frame) take(rows []int) *Frame {
+	cols := make([]*Column, len(f.cols))
+	for i, c := range f.cols {
+		cols[i] = c.take(rows)
+	}
+	return &Frame{cols: cols}
+}
+
+// Group is the rows of a frame that share the values Key of the
+// grouping columns.
+type Group struct {
+	Key []string
+	*Frame
+}
+
+// GroupBy splits f by the values of the columns called names. The
+// groups are ordered by the levels of categorical columns and by first
+// appearance for the others; combinations that do not occur, and rows
+// missing a value of a grouping column, are left out.
+func (f *Frame) GroupBy(names ...string) ([]Group, error) {
+	by := make([]*Column, len(names))
+	for i, name := range names {
+		c, err := f.Column(name)
+		if err != nil {
+			return nil, err
+		}
+		by[i] = c
+	}
+	// Each row's key as codes: level indices for categorical columns,
+	// order of first appearance for the others.
+	seen := make([]map[string]int, len(by))
+	for i := range seen {
+		seen[i] = make(map[string]int)
+	}
+	type group struct {
+		codes []int
+		rows  []int
+	}
+	var groups []*group
+rows:
+	for r := 0; r < f.Len(); r++ {
+		codes := make([]int, len(by))
+		for j, c := range by {
+			if c.IsNA(r) {
+				continue rows
+			}
+			if c.Kind.Categorical() {
+				codes[j] = c.codes[r]
+				continue
+			}
+			v := c.String(r)
+			k, ok := seen[j][v]
+			if !ok {
+				k = len(seen[j])
+				seen[j][v] = k
+			}
+			codes[j] = k
+		}
+		k := slices.IndexFunc(groups, func(g *group) bool { return slices.Equal(g.codes, codes) })
+		if k < 0 {
+			k = len(groups)
+			groups = append(groups, &group{codes: codes})
+		}
+		groups[k].rows = append(groups[k].rows, r)
+	}
+	slices.SortStableFunc(groups, func(a, b *group) int { return slices.Compare(a.codes, b.codes) })
+
+	out := make([]Group, len(groups))
+	for i, g := range groups {
+		key := make([]string, len(by))
+		for j, c := range by {
+			key[j] = c.String(g.rows[0])
+		}
+		out[i] = Group{Key: key, Frame: f.take(g.rows)}
+	}
+	return out, nil
+}
+
+// Read reads a frame from CSV with a header row, inferring the kinds
+// of the columns as FromRecords does.
+func Read(r io.Reader) (*Frame, error) {
+	return ReadNA(r, DefaultNA)
+}
+
+// ReadNA is Read with the sentinels na, rather than DefaultNA, read as
+// missing values.
+func ReadNA(r io.Reader, na NA) (*Frame, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no header row")
+	}
+	return fromRecords(rows[0], rows[1:], nil, na)
+}
+
+// Spec declares a column: its kind, the unit of a numeric column, the
+// levels of a categorical one and the sentinels of its missing values,
+// DefaultNA if nil.
+type Spec struct {
+	Kind   Kind
+	Unit   string
+	Levels []string
+	NA     NA
+}
+
+// FromRecords returns the frame of records with the column names
+// header. Columns are declared by specs, keyed by name; the kinds of
+// the others are inferred from the values present: numeric if every one
+// is a number, boolean if every one is true or false, and nominal
+// otherwise.
+func FromRecords(header []string, records [][]string, specs map[string]Spec) (*Frame, error) {
+	return fromRecords(header, records, specs, DefaultNA)
+}
+
+// fromRecords is FromRecords with the sentinels na for the columns
+// whose spec declares none.
+func fromRecords(header []string, records [][]string, specs map[string]Spec, na NA) (*Frame, error) {
+	cols := make([]*Column, len(header))
+	for j, name := range header {
+		values := make([]string, len(records))
+		for i, rec := range records {
+			if j >= len(rec) {
+				return nil, fmt.Errorf("row %d has %d fields, want %d", i+1, len(rec), len(header))
+			}
+			values[i] = strings.TrimSpace(rec[j])
+		}
+		spec, ok := specs[name]
+		if spec.NA == nil {
+			spec.NA = na
+		}
+		for i, v := range values {
+			if spec.NA.Is(v) {
+				values[i] = ""
+			}
+		}
+		if !ok {
+			spec.Kind = infer(values)
+		}
+		c, err := column(name, spec, values)
+		if err != nil {
+			return nil, err
+		}
+		cols[j] = c
+	}
+	return New(cols...)
+}
+
+// infer returns the kind of a column of values.
+func infer(values []string) Kind {
+	numeric, boolean := true, true
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			numeric = false
+		}
+		if _, err := strconv.ParseBool(v); err != nil || v == "0" || v == "1" {
+			boolean = false
+		}
+	}
+	switch {
+	case numeric:
+		return Numeric
+	case boolean:
+		return Boolean
+	}
+	return Nominal
+}
+
+// column returns the column declared by spec parsed from values, in
+// which the empty string is missing.
+func column(name string, spec Spec, values []string) (*Column, error) {
+	levels := spec.Levels
+	switch spec.Kind {
+	case Numeric:
+		x := make([]float64, len(values))
+		for i, v := range values {
+			if v == "" {
+				x[i] = math.NaN()
+				continue
+			}
+			var err error
+			if x[i], err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("column %s, row %d: %q is not a number", name, i+1, v)
+			}
+		}
+		return NewNumeric(name, spec.Unit, x), nil
+	case Boolean:
+		c := NewBoolean(name, make([]bool, len(values)))
+		for i, v := range values {
+			if v == "" {
+				c.codes[i] = -1
+				continue
+			}
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("column %s, row %d: %q is not true or false", name, i+1, v)
+			}
+			if b {
+				c.codes[i] = 1
+			}
+		}
+		return c, nil
+	case Ordinal:
+		if levels == nil {
+			levels = distinct(values)
+		}
+		return NewOrdinal(name, levels, values)
+	case ID:
+		return NewID(name, values), nil
+	}
+	return NewNominal(name, levels, values)
+}
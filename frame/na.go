@@ -0,0 +1,15 @@
+package frame
+
+import "slices"
+
+// NA is a set of sentinels read as missing values. The empty string is
+// always missing.
+type NA []string
+
+// DefaultNA are the sentinels of a Spec that declares none.
+var DefaultNA = NA{"NA", "."}
+
+// Is reports whether s is a missing value.
+func (na NA) Is(s string) bool {
+	return s == "" || slices.Contains(na, s)
+}
@@ -0,0 +1,343 @@
+// Package plots contains the gonum/plot building blocks shared by the
+// plotting commands: annotated box plots, violin, strip and beeswarm
+// plots, and helpers for saving figures.
+package plots
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand/v2"
+
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Box is a box plot that can additionally mark the mean, draw a notch
+// around the median, overlay the raw observations as jittered points
+// and label outliers.
+//
+// The quartiles, fences and outliers are the ones computed by
+// plotter.NewBoxPlot, so the drawing and the printed summary always
+// agree: Q1 is the median of the lower half of the sorted values,
+// x[:n/2], and Q3 the median of the upper half, x[n/2:], with fences
+// 1.5 × IQR beyond them. For odd n the upper half includes the median
+// and the lower half does not, so Q3 is not Tukey's upper hinge;
+// SetQuartiles draws the box with other quartiles.
+type Box struct {
+	*plotter.BoxPlot
+
+	// Mean is the arithmetic mean of the values.
+	Mean float64
+
+	// ShowMean draws a diamond at the mean.
+	ShowMean bool
+
+	// MeanStyle is the glyph style of the mean marker.
+	MeanStyle draw.GlyphStyle
+
+	// Notch draws a notch around the median whose height is the
+	// approximate 95% confidence interval of the median
+	// (McGill, Tukey and Larsen, 1978).
+	Notch bool
+
+	// Jitter overlays every observation as a point, spread randomly
+	// across JitterWidth of the box so ties stay visible.
+	Jitter      bool
+	JitterWidth float64
+
+	// PointStyle is the glyph style of the jittered points.
+	PointStyle draw.GlyphStyle
+
+	// IDs are optional row identifiers used by OutlierLabels instead
+	// of the outlying values themselves.
+	IDs []string
+
+	jitter []float64
+}
+
+// NewBox returns a Box of width w at location loc for the given values.
+// Jitter offsets are drawn from a fixed seed so that repeated runs
+// produce identical figures.
+func NewBox(w vg.Length, loc float64, values plotter.Valuer) (*Box, error) {
+	bp, err := plotter.NewBoxPlot(w, loc, values)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Box{
+		BoxPlot: bp,
+		MeanStyle: draw.GlyphStyle{
+			Color:  color.RGBA{R: 220, G: 20, B: 60, A: 255}, // crimson
+			Radius: vg.Points(4),
+			Shape:  DiamondGlyph{},
+		},
+		JitterWidth: 0.6,
+		PointStyle: draw.GlyphStyle{
+			Color:  color.RGBA{R: 90, G: 90, B: 90, A: 160},
+			Radius: vg.Points(1.8),
+			Shape:  draw.CircleGlyph{},
+		},
+	}
+
+	if len(bp.Values) > 0 {
+		b.Mean = stats.Mean(bp.Values)
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	b.jitter = make([]float64, len(bp.Values))
+	for i := range b.jitter {
+		b.jitter[i] = rng.Float64() - 0.5
+	}
+	return b, nil
+}
+
+// SetQuartiles sets the quartiles of the box to q1 and q3, such as
+// Tukey's hinges from stats.Hinges, and recomputes the 1.5 × IQR fences,
+// the adjacent values and the outside points from them.
+func (b *Box) SetQuartiles(q1, q3 float64) {
+	b.Quartile1, b.Quartile3 = q1, q3
+	low, high := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+	b.AdjLow, b.AdjHigh = math.Inf(1), math.Inf(-1)
+	b.Outside = nil
+	for i, v := range b.Values {
+		if v < low || v > high {
+			b.Outside = append(b.Outside, i)
+			continue
+		}
+		b.AdjLow = math.Min(b.AdjLow, v)
+		b.AdjHigh = math.Max(b.AdjHigh, v)
+	}
+}
+
+// NotchBounds returns the lower and upper limits of the median notch,
+// median ± 1.57 × IQR / √n.
+func (b *Box) NotchBounds() (lo, hi float64) {
+	n := float64(len(b.Values))
+	if n == 0 {
+		return b.Median, b.Median
+	}
+	half := 1.57 * (b.Quartile3 - b.Quartile1) / math.Sqrt(n)
+	return b.Median - half, b.Median + half
+}
+
+// Summary returns the five-number summary drawn by the box.
+func (b *Box) Summary() FiveNumber {
+	return FiveNumber{
+		N:      len(b.Values),
+		Min:    b.Min,
+		Q1:     b.Quartile1,
+		Median: b.Median,
+		Q3:     b.Quartile3,
+		Max:    b.Max,
+	}
+}
+
+// Outliers returns the values lying outside the 1.5 × IQR fences.
+func (b *Box) Outliers() []float64 {
+	out := make([]float64, len(b.Outside))
+	for i, idx := range b.Outside {
+		out[i] = b.Value(idx)
+	}
+	return out
+}
+
+// OutlierLabels returns labels for the outside points. Each label is
+// the row ID from IDs when set, and the value otherwise.
+func (b *Box) OutlierLabels() (*plotter.Labels, error) {
+	return b.OutsideLabels(outlierLabeller{b})
+}
+
+type outlierLabeller struct{ b *Box }
+
+func (l outlierLabeller) Label(i int) string {
+	if i < len(l.b.IDs) {
+		return l.b.IDs[i]
+	}
+	return fmt.Sprintf("%g", l.b.Value(i))
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *Box) Plot(c draw.Canvas, plt *plot.Plot) {
+	if !b.Notch {
+		b.BoxPlot.Plot(c, plt)
+	} else {
+		// Let the embedded box plot draw the whiskers and outside
+		// points only, then draw the notched box over them.
+		inner := *b.BoxPlot
+		inner.FillColor = nil
+		inner.BoxStyle.Color = color.Transparent
+		inner.MedianStyle.Color = color.Transparent
+		inner.Plot(c, plt)
+		b.plotNotch(c, plt)
+	}
+
+	at := b.locator(c, plt)
+	if b.Jitter {
+		for i, v := range b.Values {
+			pt, ok := at(vg.Length(b.jitter[i]*b.JitterWidth)*b.Width, v)
+			if ok {
+				c.DrawGlyphNoClip(b.PointStyle, pt)
+			}
+		}
+	}
+	if b.ShowMean && len(b.Values) > 0 {
+		if pt, ok := at(0, b.Mean); ok {
+			c.DrawGlyphNoClip(b.MeanStyle, pt)
+		}
+	}
+}
+
+func (b *Box) plotNotch(c draw.Canvas, plt *plot.Plot) {
+	at := b.locator(c, plt)
+	lo, hi := b.NotchBounds()
+	lo = math.Max(lo, b.Quartile1)
+	hi = math.Min(hi, b.Quartile3)
+
+	w, n := b.Width/2, b.Width/4
+	var pts []vg.Point
+	for _, p := range []struct {
+		off vg.Length
+		v   float64
+	}{
+		{-w, b.Quartile1}, {-w, lo}, {-n, b.Median}, {-w, hi}, {-w, b.Quartile3},
+		{w, b.Quartile3}, {w, hi}, {n, b.Median}, {w, lo}, {w, b.Quartile1},
+		{-w, b.Quartile1},
+	} {
+		pt, _ := at(p.off, p.v)
+		pts = append(pts, pt)
+	}
+	if b.FillColor != nil {
+		c.FillPolygon(b.FillColor, clipPolygon(c, b.Horizontal, pts))
+	}
+	c.StrokeLines(b.BoxStyle, clipLines(c, b.Horizontal, pts)...)
+
+	m0, _ := at(-n, b.Median)
+	m1, _ := at(n, b.Median)
+	c.StrokeLines(b.MedianStyle, clipLines(c, b.Horizontal, []vg.Point{m0, m1})...)
+}
+
+func (b *Box) locator(c draw.Canvas, plt *plot.Plot) func(off vg.Length, v float64) (vg.Point, bool) {
+	return locator(c, plt, b.Horizontal, b.Location, b.Offset)
+}
+
+// locator returns a function mapping an offset across a group drawn at
+// location loc and a data value to a canvas point, honouring the
+// orientation. The boolean reports whether the value lies inside the
+// canvas; like gonum's box plots, groups may spill across the other
+// axis into the padding reserved by their glyph boxes.
+func locator(c draw.Canvas, plt *plot.Plot, horizontal bool, loc float64, offset vg.Length) func(off vg.Length, v float64) (vg.Point, bool) {
+	trX, trY := plt.Transforms(&c)
+	return func(off vg.Length, v float64) (vg.Point, bool) {
+		if horizontal {
+			pt := vg.Point{X: trX(v), Y: trY(loc) + offset + off}
+			return pt, c.ContainsX(pt.X)
+		}
+		pt := vg.Point{X: trX(loc) + offset + off, Y: trY(v)}
+		return pt, c.ContainsY(pt.Y)
+	}
+}
+
+// clipLines clips lines along the value axis only.
+func clipLines(c draw.Canvas, horizontal bool, lines ...[]vg.Point) [][]vg.Point {
+	if horizontal {
+		return c.ClipLinesX(lines...)
+	}
+	return c.ClipLinesY(lines...)
+}
+
+// clipPolygon clips a polygon along the value axis only.
+func clipPolygon(c draw.Canvas, horizontal bool, pts []vg.Point) []vg.Point {
+	if horizontal {
+		return c.ClipPolygonX(pts)
+	}
+	return c.ClipPolygonY(pts)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface, adding the mean
+// marker to the boxes of the embedded box plot.
+func (b *Box) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := b.BoxPlot.GlyphBoxes(plt)
+	if b.ShowMean && len(b.Values) > 0 {
+		var gb plot.GlyphBox
+		if b.Horizontal {
+			gb.X, gb.Y = plt.X.Norm(b.Mean), plt.Y.Norm(b.Location)
+		} else {
+			gb.X, gb.Y = plt.X.Norm(b.Location), plt.Y.Norm(b.Mean)
+		}
+		gb.Rectangle = b.MeanStyle.Rectangle()
+		bs = append(bs, gb)
+	}
+	return bs
+}
+
+// FiveNumber is Tukey's five-number summary of a sample.
+type FiveNumber struct {
+	N                        int
+	Min, Q1, Median, Q3, Max float64
+}
+
+// String formats the summary on a single line.
+func (s FiveNumber) String() string {
+	return fmt.Sprintf("n = %d   Min = %.2f   Q1 = %.2f   Median = %.2f   Q3 = %.2f   Max = %.2f",
+		s.N, s.Min, s.Q1, s.Median, s.Q3, s.Max)
+}
+
+// DiamondGlyph is a glyph that draws a filled diamond.
+type DiamondGlyph struct{}
+
+// DrawGlyph implements the draw.GlyphDrawer interface.
+func (DiamondGlyph) DrawGlyph(c *draw.Canvas, sty draw.GlyphStyle, pt vg.Point) {
+	r := sty.Radius
+	p := make(vg.Path, 0, 5)
+	p.Move(vg.Point{X: pt.X, Y: pt.Y + r})
+	p.Line(vg.Point{X: pt.X + r, Y: pt.Y})
+	p.Line(vg.Point{X: pt.X, Y: pt.Y - r})
+	p.Line(vg.Point{X: pt.X - r, Y: pt.Y})
+	p.Close()
+	c.SetColor(sty.Color)
+	c.Fill(p)
+}
+
+// LegendGlyph returns a legend thumbnail showing a single glyph, for
+// adding markers such as the mean diamond to a plot legend.
+func LegendGlyph(sty draw.GlyphStyle) plot.Thumbnailer {
+	return glyphThumbnail(sty)
+}
+
+type glyphThumbnail draw.GlyphStyle
+
+func (g glyphThumbnail) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(draw.GlyphStyle(g), c.Center())
+}
+
+// AddBoxStatistics records the summary drawn by b, the statistics of
+// variable in unit, in res: the five-number summary with the quartiles
+// of the box, the mean, the outliers and, for a notched box, the notch.
+// The methods given for the quartiles are those of NewBox, not of
+// quartiles set with SetQuartiles.
+func AddBoxStatistics(res *report.Result, variable string, b *Box, unit string) {
+	s := b.Summary()
+	res.AddOf(variable, "n", s.N, "", "count")
+	res.AddOf(variable, "min", s.Min, unit, "smallest value")
+	res.AddOf(variable, "q1", s.Q1, unit, "median of the lower half x[:n/2]")
+	res.AddOf(variable, "median", s.Median, unit, "middle value of the sorted data")
+	res.AddOf(variable, "q3", s.Q3, unit, "median of the upper half x[n/2:], including the median for odd n")
+	res.AddOf(variable, "max", s.Max, unit, "largest value")
+	res.AddOf(variable, "mean", b.Mean, unit, "arithmetic mean")
+	res.AddOf(variable, "adjacent_low", b.AdjLow, unit, "smallest value within Q1 - 1.5 IQR")
+	res.AddOf(variable, "adjacent_high", b.AdjHigh, unit, "largest value within Q3 + 1.5 IQR")
+	out := b.Outliers()
+	if out == nil {
+		out = []float64{}
+	}
+	res.AddOf(variable, "outliers", out, unit, "values outside the 1.5 IQR fences")
+	if b.Notch {
+		lo, hi := b.NotchBounds()
+		res.AddOf(variable, "median_ci", []float64{lo, hi}, unit, "notch, median ± 1.57 IQR / √n")
+	}
+}
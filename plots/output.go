@@ -0,0 +1,190 @@
+package plots
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// Output is where and how a command saves its figures, as set by the
+// -out, -width, -height and -dpi flags. The format is inferred from the
+// file extension: svg, pdf, eps, png, jpg or tiff.
+type Output struct {
+	// File is the file to save to. Commands that save several figures
+	// use only its directory and extension, prefixing each figure's own
+	// name with its base name, so "-out figs/apple.svg" saves
+	// figs/apple_quality_distribution.svg and so on.
+	File string
+
+	// Width and Height override the command's figure size when
+	// non-zero.
+	Width, Height vg.Length
+
+	// DPI is the resolution of raster formats; zero uses gonum/plot's
+	// default of 96. Vector formats ignore it.
+	DPI int
+}
+
+// OutputFlags registers the -out, -width, -height and -dpi flags on the
+// default flag set, with file as the default output, and returns the
+// Output they set once flag.Parse has been called.
+func OutputFlags(file string) *Output {
+	return OutputFlagSet(flag.CommandLine, file)
+}
+
+// OutputFlagSet is like OutputFlags for the flag set fs.
+func OutputFlagSet(fs *flag.FlagSet, file string) *Output {
+	o := &Output{}
+	fs.StringVar(&o.File, "out", file, "output file; the extension sets the format: svg, pdf, eps, png, jpg or tiff")
+	fs.Var((*lengthFlag)(&o.Width), "width", "figure width, e.g. 8in, 20cm or 576pt (default: the command's own size)")
+	fs.Var((*lengthFlag)(&o.Height), "height", "figure height, e.g. 6in, 15cm or 432pt (default: the command's own size)")
+	fs.IntVar(&o.DPI, "dpi", 0, "resolution of png, jpg and tiff output (default 96)")
+	return o
+}
+
+// lengthFlag is a vg.Length flag accepting the units of vg.ParseLength.
+type lengthFlag vg.Length
+
+func (l *lengthFlag) String() string {
+	if l == nil || *l == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%gin", vg.Length(*l)/vg.Inch)
+}
+
+func (l *lengthFlag) Set(s string) error {
+	v, err := vg.ParseLength(s)
+	if err != nil {
+		return fmt.Errorf("invalid length %q", s)
+	}
+	if v <= 0 {
+		return fmt.Errorf("length %q must be positive", s)
+	}
+	*l = lengthFlag(v)
+	return nil
+}
+
+// size returns w × h with the -width and -height overrides applied.
+func (o *Output) size(w, h vg.Length) (vg.Length, vg.Length) {
+	if o.Width > 0 {
+		w = o.Width
+	}
+	if o.Height > 0 {
+		h = o.Height
+	}
+	return w, h
+}
+
+// Save saves p to o.File, w × h unless overridden, with optional
+// caption lines under the plot. It returns the file written.
+func (o *Output) Save(p *plot.Plot, w, h vg.Length, caption ...string) (string, error) {
+	w, h = o.size(w, h)
+	return o.File, saveWithCaption(p, w, h, o.File, o.DPI, caption...)
+}
+
+// SaveFigure saves p as one of several figures of a command, to the
+// file Path(name). It returns the file written.
+func (o *Output) SaveFigure(p *plot.Plot, name string, w, h vg.Length, caption ...string) (string, error) {
+	w, h = o.size(w, h)
+	file := o.Path(name)
+	return file, saveWithCaption(p, w, h, file, o.DPI, caption...)
+}
+
+// SaveText is the package function SaveText writing to o.File at the
+// resolution set by -dpi. Unlike Save it ignores -width and -height: the
+// image is sized to fit the text. It returns the file written.
+func (o *Output) SaveText(text, title string) (string, error) {
+	return o.File, saveText(text, title, o.File, o.DPI)
+}
+
+// Path returns the file that the figure with default file name name is
+// saved to: name itself without -out, else name in the directory and
+// format of -out, prefixed with the base name of -out if it has one.
+func (o *Output) Path(name string) string {
+	if o.File == "" {
+		return name
+	}
+	dir, base := filepath.Split(o.File)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	if ext == "" {
+		// A bare directory or name keeps the figure's own format.
+		ext = filepath.Ext(name)
+		if strings.HasSuffix(o.File, string(filepath.Separator)) || isDir(o.File) {
+			dir, stem = o.File, ""
+		}
+	}
+	fig := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	if stem != "" {
+		fig = stem + "_" + fig
+	}
+	return filepath.Join(dir, fig+ext)
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+// newCanvas returns a w × h canvas for the format given by the
+// extension of file. Raster formats are drawn at dpi dots per inch, or
+// the gonum/plot default when dpi is zero.
+func newCanvas(w, h vg.Length, file string, dpi int) (vg.CanvasWriterTo, error) {
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	if dpi <= 0 {
+		return draw.NewFormattedCanvas(w, h, format)
+	}
+	switch format {
+	case "png":
+		return vgimg.PngCanvas{Canvas: vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseDPI(dpi))}, nil
+	case "jpg", "jpeg":
+		return vgimg.JpegCanvas{Canvas: vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseDPI(dpi))}, nil
+	case "tif", "tiff":
+		return vgimg.TiffCanvas{Canvas: vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseDPI(dpi))}, nil
+	}
+	return draw.NewFormattedCanvas(w, h, format)
+}
+
+// writeCanvas writes c to file.
+func writeCanvas(c vg.CanvasWriterTo, file string) (err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	_, err = c.WriteTo(f)
+	return err
+}
+
+// SVG renders p as a w × h SVG element, without the XML prolog, for
+// embedding in an HTML page.
+func SVG(p *plot.Plot, w, h vg.Length) (string, error) {
+	c := vgsvg.New(w, h)
+	p.Draw(draw.New(c))
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		return "", err
+	}
+	svg := buf.String()
+	if i := strings.Index(svg, "<svg"); i >= 0 {
+		svg = svg[i:]
+	}
+	return svg, nil
+}
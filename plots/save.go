@@ -0,0 +1,38 @@
+package plots
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// SaveWithCaption saves p to file like plot.Save, reserving space under
+// the plot for the given caption lines (e.g. a five-number summary).
+func SaveWithCaption(p *plot.Plot, w, h vg.Length, file string, caption ...string) error {
+	return saveWithCaption(p, w, h, file, 0, caption...)
+}
+
+func saveWithCaption(p *plot.Plot, w, h vg.Length, file string, dpi int, caption ...string) error {
+	c, err := newCanvas(w, h, file, dpi)
+	if err != nil {
+		return err
+	}
+	dc := draw.New(c)
+
+	sty := p.Legend.TextStyle
+	sty.XAlign = draw.XCenter
+	sty.YAlign = draw.YBottom
+	lineH := sty.Rectangle("Mg").Size().Y
+	pad := vg.Points(6)
+	var captionH vg.Length
+	if len(caption) > 0 {
+		captionH = lineH*vg.Length(len(caption)) + pad
+	}
+
+	p.Draw(draw.Crop(dc, 0, 0, captionH, 0))
+	for i, line := range caption {
+		y := dc.Min.Y + pad/2 + lineH*vg.Length(len(caption)-1-i)
+		dc.FillText(sty, vg.Point{X: dc.Center().X, Y: y}, line)
+	}
+	return writeCanvas(c, file)
+}
@@ -0,0 +1,234 @@
+package plots
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Strip implements the plot.Plotter interface, drawing every value as a
+// point at the group location, randomly jittered across Width so that
+// tied values remain visible.
+type Strip struct {
+	// Values is a copy of the values used to create the strip.
+	Values plotter.Values
+
+	// Location is the location of the strip along its axis.
+	Location float64
+
+	// Offset is added to the location of the strip.
+	Offset vg.Length
+
+	// Width is the width across which the points are jittered.
+	Width vg.Length
+
+	// GlyphStyle is the style of the points.
+	GlyphStyle draw.GlyphStyle
+
+	// Horizontal dictates whether the strip is drawn horizontally.
+	Horizontal bool
+
+	jitter []float64
+}
+
+// NewStrip returns a Strip of width w at location loc for the given
+// values. Jitter offsets are drawn from a fixed seed so that repeated
+// runs produce identical figures.
+func NewStrip(w vg.Length, loc float64, values plotter.Valuer) (*Strip, error) {
+	if w < 0 {
+		return nil, errors.New("plots: negative strip width")
+	}
+	vs, err := plotter.CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(math.Float64bits(loc)), 3))
+	jitter := make([]float64, len(vs))
+	for i := range jitter {
+		jitter[i] = rng.Float64() - 0.5
+	}
+	return &Strip{
+		Values:     vs,
+		Location:   loc,
+		Width:      w,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+		jitter:     jitter,
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (s *Strip) Plot(c draw.Canvas, plt *plot.Plot) {
+	at := locator(c, plt, s.Horizontal, s.Location, s.Offset)
+	for i, v := range s.Values {
+		if pt, ok := at(vg.Length(s.jitter[i])*s.Width, v); ok {
+			c.DrawGlyphNoClip(s.GlyphStyle, pt)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (s *Strip) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return groupRange(s.Values, s.Location, s.Horizontal)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (s *Strip) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return groupGlyphBoxes(plt, s.Values, s.Location, s.Horizontal, s.GlyphStyle, s.Offset-s.Width/2, s.Offset+s.Width/2)
+}
+
+// Beeswarm implements the plot.Plotter interface, drawing every value
+// as a point and shifting points sideways just far enough that no two
+// glyphs overlap. Unlike a strip plot, the outline of the swarm shows
+// the shape of the distribution.
+type Beeswarm struct {
+	// Values is a copy of the values used to create the swarm.
+	Values plotter.Values
+
+	// Location is the location of the swarm along its axis.
+	Location float64
+
+	// Offset is added to the location of the swarm.
+	Offset vg.Length
+
+	// GlyphStyle is the style of the points.
+	GlyphStyle draw.GlyphStyle
+
+	// Horizontal dictates whether the swarm is drawn horizontally.
+	Horizontal bool
+}
+
+// NewBeeswarm returns a Beeswarm at location loc for the given values.
+func NewBeeswarm(loc float64, values plotter.Valuer) (*Beeswarm, error) {
+	vs, err := plotter.CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	return &Beeswarm{
+		Values:     vs,
+		Location:   loc,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *Beeswarm) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	tr := trY
+	if b.Horizontal {
+		tr = trX
+	}
+
+	pos := make([]vg.Length, len(b.Values))
+	for i, v := range b.Values {
+		pos[i] = tr(v)
+	}
+	offs := swarm(pos, 2*b.GlyphStyle.Radius+vg.Points(0.5))
+
+	at := locator(c, plt, b.Horizontal, b.Location, b.Offset)
+	for i, v := range b.Values {
+		if pt, ok := at(offs[i], v); ok {
+			c.DrawGlyphNoClip(b.GlyphStyle, pt)
+		}
+	}
+}
+
+// swarm returns the sideways offset of each point at the canvas
+// positions pos so that no two points are closer than d. Points are
+// placed in order of position, each at the offset nearest the centre
+// line that does not collide with a point already placed.
+func swarm(pos []vg.Length, d vg.Length) []vg.Length {
+	order := make([]int, len(pos))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return pos[order[a]] < pos[order[b]] })
+
+	offs := make([]vg.Length, len(pos))
+	var placed []int
+	for _, i := range order {
+		// Only points within d along the value axis can collide.
+		var near []int
+		for _, j := range placed {
+			if pos[i]-pos[j] < d {
+				near = append(near, j)
+			}
+		}
+
+		cands := []vg.Length{0}
+		for _, j := range near {
+			dy := float64(pos[i] - pos[j])
+			dx := vg.Length(math.Sqrt(math.Max(float64(d*d)-dy*dy, 0)))
+			cands = append(cands, offs[j]+dx, offs[j]-dx)
+		}
+		sort.SliceStable(cands, func(a, b int) bool {
+			return math.Abs(float64(cands[a])) < math.Abs(float64(cands[b]))
+		})
+
+	search:
+		for _, off := range cands {
+			for _, j := range near {
+				dx, dy := float64(off-offs[j]), float64(pos[i]-pos[j])
+				if math.Hypot(dx, dy) < float64(d)-1e-6 {
+					continue search
+				}
+			}
+			offs[i] = off
+			break
+		}
+		placed = append(placed, i)
+	}
+	return offs
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (b *Beeswarm) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return groupRange(b.Values, b.Location, b.Horizontal)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (b *Beeswarm) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return groupGlyphBoxes(plt, b.Values, b.Location, b.Horizontal, b.GlyphStyle, b.Offset, b.Offset)
+}
+
+// groupRange returns the data range of values drawn at location loc.
+func groupRange(vs plotter.Values, loc float64, horizontal bool) (xmin, xmax, ymin, ymax float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range vs {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if horizontal {
+		return lo, hi, loc, loc
+	}
+	return loc, loc, lo, hi
+}
+
+// groupGlyphBoxes returns a glyph box for every value drawn at location
+// loc, widened across the group axis to span [from, to].
+func groupGlyphBoxes(plt *plot.Plot, vs plotter.Values, loc float64, horizontal bool, sty draw.GlyphStyle, from, to vg.Length) []plot.GlyphBox {
+	r := sty.Rectangle()
+	bs := make([]plot.GlyphBox, len(vs))
+	for i, v := range vs {
+		if horizontal {
+			bs[i].X, bs[i].Y = plt.X.Norm(v), plt.Y.Norm(loc)
+			bs[i].Rectangle = vg.Rectangle{
+				Min: vg.Point{X: r.Min.X, Y: from + r.Min.Y},
+				Max: vg.Point{X: r.Max.X, Y: to + r.Max.Y},
+			}
+		} else {
+			bs[i].X, bs[i].Y = plt.X.Norm(loc), plt.Y.Norm(v)
+			bs[i].Rectangle = vg.Rectangle{
+				Min: vg.Point{X: from + r.Min.X, Y: r.Min.Y},
+				Max: vg.Point{X: to + r.Max.X, Y: r.Max.Y},
+			}
+		}
+	}
+	return bs
+}
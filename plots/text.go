@@ -0,0 +1,85 @@
+package plots
+
+import (
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/textplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// SaveText saves a text display such as a stem-and-leaf display to an
+// image file, set in a monospaced font under an optional title. The
+// image is sized to fit the text; the format is determined by the file
+// extension as for plot.Save.
+func SaveText(text, title, file string) error {
+	return saveText(text, title, file, 0)
+}
+
+func saveText(text, title, file string, dpi int) error {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	sty := plot.New().Title.TextStyle
+	titleSty := sty
+	titleSty.XAlign = draw.XCenter
+	sty.Font = font.Font{Typeface: "Liberation", Variant: "Mono", Size: vg.Points(12)}
+	sty.XAlign = draw.XLeft
+	sty.YAlign = draw.YTop
+
+	pad := vg.Points(18)
+	lineH := sty.Rectangle("Mg").Size().Y
+	var w vg.Length
+	for _, l := range lines {
+		w = max(w, sty.Rectangle(l).Size().X)
+	}
+	h := lineH * vg.Length(len(lines))
+	var titleH vg.Length
+	if title != "" {
+		titleH = titleSty.Rectangle(title).Size().Y + pad/2
+		w = max(w, titleSty.Rectangle(title).Size().X)
+	}
+	w, h = w+2*pad, h+titleH+2*pad
+
+	c, err := newCanvas(w, h, file, dpi)
+	if err != nil {
+		return err
+	}
+	dc := draw.New(c)
+	dc.SetColor(plot.New().BackgroundColor)
+	dc.Fill(dc.Rectangle.Path())
+
+	y := dc.Max.Y - pad
+	if title != "" {
+		titleSty.YAlign = draw.YTop
+		dc.FillText(titleSty, vg.Point{X: dc.Center().X, Y: y}, title)
+		y -= titleH
+	}
+	for _, l := range lines {
+		dc.FillText(sty, vg.Point{X: dc.Min.X + pad, Y: y}, l)
+		y -= lineH
+	}
+
+	return writeCanvas(c, file)
+}
+
+// NewDotPlot returns a scatter of stacked dots, one per observation,
+// above the values of the text dot plot d, so that terminal and image
+// dot plots agree.
+func NewDotPlot(d *textplot.DotPlot) (*plotter.Scatter, error) {
+	var pts plotter.XYs
+	for i, n := range d.Counts {
+		for k := 1; k <= n; k++ {
+			pts = append(pts, plotter.XY{X: d.Value(i), Y: float64(k)})
+		}
+	}
+	s, err := plotter.NewScatter(pts)
+	if err != nil {
+		return nil, err
+	}
+	s.GlyphStyle.Shape = draw.CircleGlyph{}
+	s.GlyphStyle.Radius = vg.Points(4)
+	return s, nil
+}
@@ -0,0 +1,171 @@
+package plots
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Violin implements the plot.Plotter interface, drawing the kernel
+// density estimate of the values mirrored about the group location.
+type Violin struct {
+	// Values is a copy of the values used to create the violin.
+	Values plotter.Values
+
+	// KDE is the density estimate outlining the violin.
+	KDE *stats.KDE
+
+	// Location is the location of the violin along its axis.
+	Location float64
+
+	// Offset is added to the location of the violin.
+	Offset vg.Length
+
+	// Width is the width of the violin at its densest point. Each
+	// violin is scaled to its own maximum density.
+	Width vg.Length
+
+	// Cut is how many bandwidths the density extends past the
+	// extreme values.
+	Cut float64
+
+	// Samples is the number of points at which the density is
+	// evaluated.
+	Samples int
+
+	// FillColor is the color used to fill the violin.
+	FillColor color.Color
+
+	// LineStyle is the style of the violin outline.
+	LineStyle draw.LineStyle
+
+	// ShowQuartiles draws a bar from Q1 to Q3 and a dot at the median
+	// inside the violin.
+	ShowQuartiles bool
+
+	// Horizontal dictates whether the violin is drawn horizontally.
+	Horizontal bool
+
+	q1, median, q3 float64
+}
+
+// NewViolin returns a Violin of width w at location loc for the given
+// values, using a Gaussian kernel with Silverman's bandwidth.
+func NewViolin(w vg.Length, loc float64, values plotter.Valuer) (*Violin, error) {
+	if w < 0 {
+		return nil, errors.New("plots: negative violin width")
+	}
+	vs, err := plotter.CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) == 0 {
+		return nil, errors.New("plots: violin needs at least one value")
+	}
+
+	s := stats.Sorted(vs)
+	return &Violin{
+		Values:        vs,
+		KDE:           stats.NewKDE(vs),
+		Location:      loc,
+		Width:         w,
+		Cut:           1,
+		Samples:       100,
+		LineStyle:     plotter.DefaultLineStyle,
+		ShowQuartiles: true,
+		q1:            stats.Quantile(s, 0.25),
+		median:        stats.Quantile(s, 0.5),
+		q3:            stats.Quantile(s, 0.75),
+	}, nil
+}
+
+// extent returns the range of values over which the density is drawn.
+func (v *Violin) extent() (lo, hi float64) {
+	d := v.KDE.Data
+	pad := v.Cut * v.KDE.Bandwidth
+	return d[0] - pad, d[len(d)-1] + pad
+}
+
+// Plot implements the plot.Plotter interface.
+func (v *Violin) Plot(c draw.Canvas, plt *plot.Plot) {
+	at := locator(c, plt, v.Horizontal, v.Location, v.Offset)
+	lo, hi := v.extent()
+
+	n := v.Samples
+	if n < 2 {
+		n = 2
+	}
+	xs := make([]float64, n)
+	ds := make([]float64, n)
+	var maxd float64
+	for i := range xs {
+		xs[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+		ds[i] = v.KDE.Density(xs[i])
+		if ds[i] > maxd {
+			maxd = ds[i]
+		}
+	}
+	if maxd == 0 {
+		return
+	}
+
+	pts := make([]vg.Point, 0, 2*n+1)
+	for i := range xs {
+		pt, _ := at(vg.Length(ds[i]/maxd)*v.Width/2, xs[i])
+		pts = append(pts, pt)
+	}
+	for i := n - 1; i >= 0; i-- {
+		pt, _ := at(-vg.Length(ds[i]/maxd)*v.Width/2, xs[i])
+		pts = append(pts, pt)
+	}
+	pts = append(pts, pts[0])
+
+	if v.FillColor != nil {
+		c.FillPolygon(v.FillColor, clipPolygon(c, v.Horizontal, pts))
+	}
+	c.StrokeLines(v.LineStyle, clipLines(c, v.Horizontal, pts)...)
+
+	if v.ShowQuartiles {
+		bar := draw.LineStyle{Color: color.Black, Width: v.Width / 16}
+		a, _ := at(0, v.q1)
+		b, _ := at(0, v.q3)
+		c.StrokeLines(bar, clipLines(c, v.Horizontal, []vg.Point{a, b})...)
+		if pt, ok := at(0, v.median); ok {
+			c.DrawGlyphNoClip(draw.GlyphStyle{Color: color.White, Radius: v.Width / 24, Shape: draw.CircleGlyph{}}, pt)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (v *Violin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	lo, hi := v.extent()
+	if v.Horizontal {
+		return lo, hi, v.Location, v.Location
+	}
+	return v.Location, v.Location, lo, hi
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface so that the
+// violin's width is kept inside the plot area.
+func (v *Violin) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var gb plot.GlyphBox
+	if v.Horizontal {
+		gb.X, gb.Y = plt.X.Norm(v.median), plt.Y.Norm(v.Location)
+		gb.Rectangle = vg.Rectangle{
+			Min: vg.Point{Y: v.Offset - v.Width/2},
+			Max: vg.Point{Y: v.Offset + v.Width/2},
+		}
+	} else {
+		gb.X, gb.Y = plt.X.Norm(v.Location), plt.Y.Norm(v.median)
+		gb.Rectangle = vg.Rectangle{
+			Min: vg.Point{X: v.Offset - v.Width/2},
+			Max: vg.Point{X: v.Offset + v.Width/2},
+		}
+	}
+	return []plot.GlyphBox{gb}
+}
@@ -0,0 +1,320 @@
+// Package descriptive builds the descriptive analysis of one numeric
+// variable as a report page: the data, a grouped frequency table,
+// charts, summary statistics, step-by-step derivations and an
+// interpretation in words.
+package descriptive
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/explain"
+	"github.com/mayura-andrew/applied-statistics/plots"
+	"github.com/mayura-andrew/applied-statistics/report"
+	"github.com/mayura-andrew/applied-statistics/stats"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Data is a numeric variable to analyse.
+type Data struct {
+	Title       string
+	Description string
+	Variable    string
+	Unit        string
+	Values      []float64
+}
+
+// Page returns the analysis of d. With figures empty the charts are
+// inlined as SVG, for HTML; otherwise they are saved as PDFs named
+// figures_histogram.pdf and so on, for LaTeX, and the files written are
+// returned.
+func Page(d Data, figures string) (*report.Page, []string, error) {
+	if len(d.Values) < 3 {
+		return nil, nil, errors.New("at least 3 observations are needed")
+	}
+	page := &report.Page{
+		Title:    "Descriptive Analysis: " + d.Title,
+		Subtitle: fmt.Sprintf("%s %s (%s), n = %d", d.Description, d.Variable, d.Unit, len(d.Values)),
+	}
+	a := newAnalysis(d)
+	a.figures = figures
+	a.data(page.Section("1. The Data"))
+	a.frequency(page.Section("2. Frequency Distribution"))
+	if err := a.charts(page.Section("3. Charts")); err != nil {
+		return nil, a.files, err
+	}
+	a.summary(page.Section("4. Summary Statistics"))
+	a.derivations(page.Section("5. Step-by-Step Derivations"))
+	a.interpretation(page.Section("6. Interpretation"))
+	return page, a.files, nil
+}
+
+// analysis holds the statistics of a dataset computed once and shared
+// by the sections of the report.
+type analysis struct {
+	Data
+	sorted         []float64
+	n              int
+	mean           float64
+	median         float64
+	modes          []float64
+	variance       float64
+	sd             float64
+	q1, q3, iqr    float64
+	lowFence       float64
+	highFence      float64
+	outliers       []float64
+	skewness       float64
+	classes        []stats.Class
+	kdeBandwidth   float64
+	kdeModes       []float64
+	kdeBandwidthBy string
+
+	// figures is the path prefix of the figure files of a LaTeX
+	// report; HTML reports inline their figures and leave it empty.
+	figures string
+	// files are the figure files saved.
+	files []string
+}
+
+func newAnalysis(d Data) *analysis {
+	a := &analysis{Data: d, sorted: stats.Sorted(d.Values), n: len(d.Values)}
+	a.mean = stats.Mean(d.Values)
+	a.median = stats.Median(a.sorted)
+	a.modes = stats.Modes(d.Values)
+	a.variance = stats.Variance(d.Values)
+	a.sd = math.Sqrt(a.variance)
+	a.q1, a.q3 = stats.Hinges(a.sorted)
+	a.iqr = a.q3 - a.q1
+	a.lowFence, a.highFence = a.q1-1.5*a.iqr, a.q3+1.5*a.iqr
+	for _, v := range a.sorted {
+		if v < a.lowFence || v > a.highFence {
+			a.outliers = append(a.outliers, v)
+		}
+	}
+	a.skewness = stats.Skewness(d.Values)
+	a.classes = stats.FrequencyTable(d.Values, stats.SqrtClasses(a.n))
+
+	a.kdeBandwidthBy = "Sheather–Jones"
+	h, err := stats.SheatherJones(d.Values)
+	if err != nil {
+		a.kdeBandwidthBy = "Silverman's rule"
+		h = stats.Silverman(d.Values)
+	}
+	kde := stats.NewKDE(d.Values)
+	kde.Bandwidth = h
+	a.kdeBandwidth = h
+	a.kdeModes = kde.Modes()
+	return a
+}
+
+// figure adds p to s as a w × h figure: inlined as SVG, or for a LaTeX
+// report saved as a PDF named after the report and the figure.
+func (a *analysis) figure(s *report.Section, p *plot.Plot, name string, w, h vg.Length, caption string) error {
+	f := report.Figure{Caption: caption}
+	if a.figures == "" {
+		svg, err := plots.SVG(p, w, h)
+		if err != nil {
+			return err
+		}
+		f.SVG = svg
+	} else {
+		file := a.figures + "_" + name + ".pdf"
+		if err := plots.SaveWithCaption(p, w, h, file); err != nil {
+			return err
+		}
+		a.files = append(a.files, file)
+		f.File = filepath.Base(file)
+	}
+	s.AddFigure(f)
+	return nil
+}
+
+// num formats a value for the prose and derivations.
+func num(v float64) string {
+	return report.Cell(v)
+}
+
+func nums(vs []float64) string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = num(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+func (a *analysis) data(s *report.Section) {
+	s.Para("%s The variable is %s, measured in %s. The %d observations, in the order recorded:", a.Description, a.Variable, a.Unit, a.n)
+	t := report.Table{Name: "Observations", Columns: []string{"#"}}
+	for j := 1; j <= 10; j++ {
+		t.Columns = append(t.Columns, fmt.Sprint(j))
+	}
+	for i := 0; i < a.n; i += 10 {
+		row := []any{fmt.Sprintf("%d–%d", i+1, min(i+10, a.n))}
+		for _, v := range a.Values[i:min(i+10, a.n)] {
+			row = append(row, v)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	s.AddTable(t)
+}
+
+func (a *analysis) frequency(s *report.Section) {
+	k := len(a.classes)
+	width := a.classes[0].Upper - a.classes[0].Lower
+	rng := a.sorted[a.n-1] - a.sorted[0]
+	s.Para("The observations are grouped into classes of equal width, chosen as follows.")
+	s.AddSteps(
+		fmt.Sprintf("Number of observations n = %d", a.n),
+		fmt.Sprintf("Range = Max − Min = %s − %s = %s %s", num(a.sorted[a.n-1]), num(a.sorted[0]), num(rng), a.Unit),
+		fmt.Sprintf("Number of classes by the square-root rule: k = round(√%d) = round(%s) = %d", a.n, num(math.Sqrt(float64(a.n))), k),
+		fmt.Sprintf("Class width = Range / k = %s / %d = %s, rounded up to %s %s", num(rng), k, num(rng/float64(k)), num(width), a.Unit),
+	)
+
+	t := report.Table{
+		Name:    "Grouped frequency table",
+		Columns: []string{a.Variable + " (" + a.Unit + ")", "Midpoint", "Frequency", "Relative frequency", "Cumulative frequency"},
+	}
+	cum := 0
+	for i, c := range a.classes {
+		cum += c.Count
+		bracket := ")"
+		if i == len(a.classes)-1 {
+			bracket = "]"
+		}
+		t.Rows = append(t.Rows, []any{
+			fmt.Sprintf("[%s, %s%s", num(c.Lower), num(c.Upper), bracket),
+			c.Midpoint(), c.Count, float64(c.Count) / float64(a.n), cum,
+		})
+	}
+	t.Rows = append(t.Rows, []any{"Total", "", a.n, 1.0, ""})
+	s.AddTable(t)
+}
+
+func (a *analysis) charts(s *report.Section) error {
+	// The histogram uses the classes of the frequency table.
+	p := plot.New()
+	p.Title.Text = "Histogram of " + a.Title
+	p.X.Label.Text = fmt.Sprintf("%s (%s)", a.Variable, a.Unit)
+	p.Y.Label.Text = "Frequency"
+	hist := &plotter.Histogram{FillColor: color.RGBA{R: 173, G: 216, B: 230, A: 255}, LineStyle: plotter.DefaultLineStyle}
+	for _, c := range a.classes {
+		hist.Bins = append(hist.Bins, plotter.HistogramBin{Min: c.Lower, Max: c.Upper, Weight: float64(c.Count)})
+	}
+	hist.Width = a.classes[0].Upper - a.classes[0].Lower
+	p.Add(hist)
+	if err := a.figure(s, p, "histogram", 6*vg.Inch, 4*vg.Inch, "Histogram with the classes of the frequency table."); err != nil {
+		return err
+	}
+
+	p = plot.New()
+	p.Title.Text = "Box Plot of " + a.Title
+	p.X.Label.Text = fmt.Sprintf("%s (%s)", a.Variable, a.Unit)
+	p.HideY()
+	box, err := plots.NewBox(vg.Points(50), 0, plotter.Values(a.Values))
+	if err != nil {
+		return err
+	}
+	// Draw the box with the hinges of the summary table.
+	box.SetQuartiles(a.q1, a.q3)
+	box.FillColor = color.RGBA{R: 173, G: 216, B: 230, A: 255}
+	box.ShowMean = true
+	box.Horizontal = true
+	p.Add(box)
+	labels, err := box.OutlierLabels()
+	if err != nil {
+		return err
+	}
+	p.Add(labels)
+	p.Legend.Add("mean", plots.LegendGlyph(box.MeanStyle))
+	p.Legend.Top = true
+	return a.figure(s, p, "boxplot", 6*vg.Inch, 2.5*vg.Inch, "Box plot: the box spans Q1 to Q3 with the median inside, whiskers reach the most extreme values within 1.5 × IQR of the box.")
+}
+
+func (a *analysis) summary(s *report.Section) {
+	mode := "none (all values unique)"
+	if len(a.modes) > 0 {
+		mode = nums(a.modes)
+	}
+	t := report.Table{Name: "Summary statistics", Columns: []string{"Statistic", "Value", "Method"}}
+	add := func(name string, v any, method string) {
+		t.Rows = append(t.Rows, []any{name, v, method})
+	}
+	add("n", a.n, "count")
+	add("Mean", a.mean, "Σx / n")
+	add("Median", a.median, "middle value of the sorted data")
+	add("Mode", mode, "most frequent value(s)")
+	add("Minimum", a.sorted[0], "")
+	add("Maximum", a.sorted[a.n-1], "")
+	add("Range", a.sorted[a.n-1]-a.sorted[0], "Max − Min")
+	add("Variance", a.variance, "sample, Σ(x − x̄)² / (n − 1)")
+	add("Standard deviation", a.sd, "√variance")
+	add("Q1", a.q1, "median of the lower half")
+	add("Q3", a.q3, "median of the upper half")
+	add("IQR", a.iqr, "Q3 − Q1")
+	add("Coefficient of variation", a.sd/a.mean, "s / x̄")
+	add("Skewness", a.skewness, "moment coefficient m₃ / m₂^1.5")
+	s.Para("All values are in %s except n, the coefficient of variation and the skewness, which have no unit.", a.Unit)
+	s.AddTable(t)
+}
+
+func (a *analysis) derivations(s *report.Section) {
+	o := explain.Options{Unit: a.Unit}
+	s.Para("Mean: the sum of all values divided by their number.")
+	explain.Mean(a.Values, o).AddTo(s, explain.Full)
+
+	s.Para("Median: the middle value of the sorted data.")
+	explain.Median(a.Values, o).AddTo(s, explain.Full)
+
+	s.Para("Variance and standard deviation: the average squared deviation from the mean, dividing by n − 1 for a sample.")
+	v := explain.Variance(a.Values, false, o)
+	v.AddTo(s, explain.Key)
+	explain.StdDevFrom(v, false, o).AddTo(s, explain.Key)
+
+	if a.n%2 == 1 {
+		s.Para("Quartiles: the medians of the lower and upper halves of the sorted data, leaving out the median itself since n is odd.")
+	} else {
+		s.Para("Quartiles: the medians of the lower and upper halves of the sorted data.")
+	}
+	explain.IQR(a.Values, o).AddTo(s, explain.Full)
+	s.AddDerivation(report.Step{
+		Text: fmt.Sprintf("Fences: Q1 − 1.5 × IQR = %s and Q3 + 1.5 × IQR = %s", num(a.lowFence), num(a.highFence)),
+		TeX:  fmt.Sprintf(`Q_1 - 1.5 \times \mathrm{IQR} &= %s, \qquad Q_3 + 1.5 \times \mathrm{IQR} = %s`, num(a.lowFence), num(a.highFence)),
+	})
+}
+
+func (a *analysis) interpretation(s *report.Section) {
+	switch {
+	case math.Abs(a.skewness) < 0.5:
+		s.Para("The distribution is approximately symmetric (skewness %s): the mean (%s) and median (%s) are close, so the mean and standard deviation describe the data well.", num(a.skewness), num(a.mean), num(a.median))
+	case a.skewness > 0:
+		s.Para("The distribution is positively (right) skewed (skewness %s): a tail of larger values pulls the mean (%s) above the median (%s), so the median and IQR are the more robust summaries.", num(a.skewness), num(a.mean), num(a.median))
+	default:
+		s.Para("The distribution is negatively (left) skewed (skewness %s): a tail of smaller values pulls the mean (%s) below the median (%s), so the median and IQR are the more robust summaries.", num(a.skewness), num(a.mean), num(a.median))
+	}
+
+	s.Para("The typical value is %s %s, give or take %s %s (one standard deviation); the middle half of the observations lie between %s and %s %s.",
+		num(a.mean), a.Unit, num(a.sd), a.Unit, num(a.q1), num(a.q3), a.Unit)
+
+	if len(a.outliers) == 0 {
+		s.Para("No observation lies outside the 1.5 × IQR fences [%s, %s], so there are no outliers by Tukey's rule.", num(a.lowFence), num(a.highFence))
+	} else {
+		s.Para("The observation(s) %s lie outside the 1.5 × IQR fences [%s, %s] and are potential outliers worth checking.", nums(a.outliers), num(a.lowFence), num(a.highFence))
+	}
+
+	peaks := "a single peak"
+	if len(a.kdeModes) > 1 {
+		peaks = fmt.Sprintf("%d peaks", len(a.kdeModes))
+	}
+	s.Para("A Gaussian kernel density estimate with the %s bandwidth (h = %s %s) has %s, at %s %s.",
+		a.kdeBandwidthBy, num(a.kdeBandwidth), a.Unit, peaks, nums(a.kdeModes), a.Unit)
+	if len(a.modes) > 1 && len(a.kdeModes) == 1 {
+		s.Para("Several values tie as the most frequent (%s), but the smoothed distribution has one peak: the tied modes are an artefact of the small sample.", nums(a.modes))
+	}
+}
@@ -0,0 +1,172 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+)
+
+// Page is a self-contained document of an analysis: sections of prose,
+// tables, step-by-step derivations and figures, rendered as a single
+// HTML file with the figures inlined as SVG, as a LaTeX document or as
+// Markdown.
+type Page struct {
+	Title    string
+	Subtitle string
+	Sections []*Section
+}
+
+// Section is a headed part of a page. Its blocks are rendered in the
+// order they were added.
+type Section struct {
+	Title  string
+	Blocks []Block
+}
+
+// Block is one element of a section; exactly one field is set.
+type Block struct {
+	Text   string
+	Table  *Table
+	Steps  []Step
+	Figure *Figure
+}
+
+// Step is one line of a derivation. Text is the line as shown in HTML;
+// TeX, if set, is the same line as LaTeX math for an align environment,
+// with & marking the alignment point, e.g. `\bar{x} &= 4651 / 30`.
+type Step struct {
+	Text string
+	TeX  string
+}
+
+// Figure is a chart with a caption: SVG is the chart inlined in HTML
+// pages, File the image file that LaTeX documents include.
+type Figure struct {
+	SVG     string
+	File    string
+	Caption string
+}
+
+// Section appends a new section titled title to p and returns it.
+func (p *Page) Section(title string) *Section {
+	s := &Section{Title: title}
+	p.Sections = append(p.Sections, s)
+	return s
+}
+
+// Para appends a paragraph, formatted as by fmt.Sprintf.
+func (s *Section) Para(format string, args ...any) {
+	s.Blocks = append(s.Blocks, Block{Text: fmt.Sprintf(format, args...)})
+}
+
+// AddTable appends a table.
+func (s *Section) AddTable(t Table) {
+	s.Blocks = append(s.Blocks, Block{Table: &t})
+}
+
+// AddSteps appends a numbered list of steps given as text only.
+func (s *Section) AddSteps(steps ...string) {
+	b := Block{Steps: make([]Step, len(steps))}
+	for i, text := range steps {
+		b.Steps[i].Text = text
+	}
+	s.Blocks = append(s.Blocks, b)
+}
+
+// AddDerivation appends a derivation whose steps carry their LaTeX
+// form, rendered as an align environment in LaTeX documents.
+func (s *Section) AddDerivation(steps ...Step) {
+	s.Blocks = append(s.Blocks, Block{Steps: steps})
+}
+
+// AddFigure appends a figure with a caption.
+func (s *Section) AddFigure(f Figure) {
+	s.Blocks = append(s.Blocks, Block{Figure: &f})
+}
+
+// WriteHTML writes p to w as a standalone HTML document.
+func (p *Page) WriteHTML(w io.Writer) error {
+	return pageTemplate.Execute(w, p)
+}
+
+// WriteHTML writes s to w as an HTML fragment, for embedding in a page
+// styled like those of Page.WriteHTML.
+func (s *Section) WriteHTML(w io.Writer) error {
+	return pageTemplate.ExecuteTemplate(w, "section", s)
+}
+
+// Cell formats a table value for display: floats with at most 4
+// decimals and no trailing zeros, anything else as by fmt.Sprint.
+func Cell(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return trimmed(v)
+	case nil:
+		return "–"
+	}
+	return fmt.Sprint(v)
+}
+
+// trimmed formats v with 4 decimals, dropping trailing zeros.
+func trimmed(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+var pageTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"cell": Cell,
+	"svg":  func(s string) template.HTML { return template.HTML(s) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, "Liberation Serif", serif; max-width: 52em; margin: 2em auto; padding: 0 1em; color: #222; line-height: 1.5; }
+h1 { margin-bottom: 0; }
+.subtitle { color: #666; margin-top: 0.2em; }
+h2 { border-bottom: 1px solid #ccc; padding-bottom: 0.2em; margin-top: 2em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.75em; }
+th { background: #f0f4f8; }
+td { text-align: right; font-variant-numeric: tabular-nums; }
+td:first-child { text-align: left; }
+caption { caption-side: top; font-weight: bold; text-align: left; padding-bottom: 0.3em; }
+ol.steps { font-family: "Liberation Mono", monospace; font-size: 0.9em; background: #f8f8f8; padding: 0.8em 0.8em 0.8em 2.5em; }
+figure { margin: 1.5em 0; text-align: center; }
+figure svg { max-width: 100%; height: auto; }
+figcaption { color: #555; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Subtitle}}<p class="subtitle">{{.}}</p>{{end}}
+{{range .Sections}}{{template "section" .}}{{end}}
+</body>
+</html>
+{{define "section"}}
+<h2>{{.Title}}</h2>
+{{range .Blocks}}
+{{- if .Text}}<p>{{.Text}}</p>
+{{else if .Table}}<table>
+<caption>{{.Table.Name}}</caption>
+<tr>{{range .Table.Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Table.Rows}}<tr>{{range .}}<td>{{cell .}}</td>{{end}}</tr>
+{{end}}</table>
+{{else if .Steps}}<ol class="steps">
+{{range .Steps}}<li>{{.Text}}</li>
+{{end}}</ol>
+{{else if .Figure}}<figure>
+{{if .Figure.SVG}}{{svg .Figure.SVG}}{{else}}<img src="{{.Figure.File}}" alt="{{.Figure.Caption}}">{{end}}
+<figcaption>{{.Figure.Caption}}</figcaption>
+</figure>
+{{end}}
+{{- end}}
+{{end}}`))
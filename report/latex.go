@@ -0,0 +1,201 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// WriteLaTeX writes p to w as a standalone LaTeX document for pdflatex:
+// tables as booktabs tabulars, derivations as align environments and
+// figures included from their File, which must be a PDF, PNG or JPEG
+// path relative to the document.
+func (p *Page) WriteLaTeX(w io.Writer) error {
+	return latexTemplate.Execute(w, p)
+}
+
+// WriteLaTeX writes s to w as a LaTeX fragment, needing the packages
+// loaded by Page.WriteLaTeX.
+func (s *Section) WriteLaTeX(w io.Writer) error {
+	return latexTemplate.ExecuteTemplate(w, "section", s)
+}
+
+// TeX escapes s for use as LaTeX text, replacing the special
+// characters and the Unicode symbols used in the derivations by their
+// LaTeX equivalents.
+func TeX(s string) string {
+	return texReplacer.Replace(s)
+}
+
+var texReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	"x̄", `$\bar{x}$`,
+	"−", `$-$`,
+	"–", `--`,
+	"—", `---`,
+	"×", `$\times$`,
+	"±", `$\pm$`,
+	"≈", `$\approx$`,
+	"≤", `$\le$`,
+	"≥", `$\ge$`,
+	"→", `$\to$`,
+	"√", `$\surd$`,
+	"Σ", `$\Sigma$`,
+	"σ", `$\sigma$`,
+	"μ", `$\mu$`,
+	"α", `$\alpha$`,
+	"λ", `$\lambda$`,
+	"χ", `$\chi$`,
+	"Φ", `$\Phi$`,
+	"∞", `$\infty$`,
+	"²", `\textsuperscript{2}`,
+	"³", `\textsuperscript{3}`,
+	"₂", `$_2$`,
+	"₃", `$_3$`,
+	"✓", `$\checkmark$`,
+)
+
+// texCell formats a table value like Cell, as LaTeX text with a proper
+// minus sign for negative numbers.
+func texCell(v any) string {
+	s := Cell(v)
+	switch v.(type) {
+	case float64, int:
+		if strings.HasPrefix(s, "-") {
+			return "$-$" + s[1:]
+		}
+	}
+	return TeX(s)
+}
+
+// texColumns returns the tabular column specification of t: the first
+// column left-aligned, the others right-aligned unless they hold text
+// other than numbers.
+func texColumns(t *Table) string {
+	spec := []byte(strings.Repeat("r", len(t.Columns)))
+	spec[0] = 'l'
+	for _, row := range t.Rows {
+		for j, v := range row {
+			if s, ok := v.(string); ok && s != "" && !numeric(s) && j < len(spec) {
+				spec[j] = 'l'
+			}
+		}
+	}
+	return string(spec)
+}
+
+// numeric reports whether s is a number written as a string, such as
+// an entry of a table formatted to fixed decimals.
+func numeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil || s == "∞"
+}
+
+// hasTeX reports whether any of the steps has a LaTeX form.
+func hasTeX(steps []Step) bool {
+	for _, s := range steps {
+		if s.TeX != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// alignSteps renders a derivation: the leading steps without a LaTeX
+// form as paragraphs, the rest as an align environment in which such
+// steps become \intertext.
+func alignSteps(steps []Step) string {
+	var b strings.Builder
+	i := 0
+	for ; i < len(steps) && steps[i].TeX == ""; i++ {
+		fmt.Fprintf(&b, "%s\n\n", TeX(steps[i].Text))
+	}
+	b.WriteString("\\begin{align*}\n")
+	for j, s := range steps[i:] {
+		if s.TeX == "" {
+			fmt.Fprintf(&b, "\\intertext{%s}\n", TeX(s.Text))
+			continue
+		}
+		b.WriteString(s.TeX)
+		if i+j < len(steps)-1 {
+			b.WriteString(" \\\\")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\\end{align*}")
+	return b.String()
+}
+
+var latexTemplate = template.Must(template.New("latex").Delims("<<", ">>").Funcs(template.FuncMap{
+	"tex":     TeX,
+	"cell":    texCell,
+	"columns": texColumns,
+	"hasTeX":  hasTeX,
+	"align":   alignSteps,
+}).Parse(`\documentclass[11pt,a4paper]{article}
+\usepackage[utf8]{inputenc}
+\usepackage[T1]{fontenc}
+\usepackage{lmodern}
+\usepackage{amsmath}
+\usepackage{amssymb}
+\usepackage{booktabs}
+\usepackage{caption}
+\usepackage{graphicx}
+\usepackage[margin=2.5cm]{geometry}
+
+\title{<<tex .Title>>}
+\author{<<tex .Subtitle>>}
+\date{}
+
+\begin{document}
+\maketitle
+<<range .Sections>><<template "section" .>><<end>>
+\end{document}
+<<define "section">>
+\section*{<<tex .Title>>}
+<<range .Blocks>>
+<<- if .Text>>
+<<tex .Text>>
+<<else if .Table>>
+\begin{table}[!htbp]
+\centering
+\caption*{<<tex .Table.Name>>}
+\resizebox{\ifdim\width>\linewidth\linewidth\else\width\fi}{!}{%
+\begin{tabular}{<<columns .Table>>}
+\toprule
+<<range $i, $c := .Table.Columns>><<if $i>> & <<end>><<tex $c>><<end>> \\
+\midrule
+<<range .Table.Rows>><<range $i, $v := .>><<if $i>> & <<end>><<cell $v>><<end>> \\
+<<end>>\bottomrule
+\end{tabular}}
+\end{table}
+<<else if .Steps>><<if hasTeX .Steps>>
+<<align .Steps>>
+<<else>>
+\begin{enumerate}
+<<range .Steps>>\item <<tex .Text>>
+<<end>>\end{enumerate}
+<<end>>
+<<else if .Figure>><<if .Figure.File>>
+\begin{figure}[!htbp]
+\centering
+\includegraphics[width=0.9\linewidth]{<<.Figure.File>>}
+\caption*{<<tex .Figure.Caption>>}
+\end{figure}
+<<else>>
+% Figure not available as a file: <<tex .Figure.Caption>>
+<<end>>
+<<end>>
+<<- end>>
+<<end>>`))
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes p to w as a Markdown document: sections as
+// headings, tables as pipe tables with the first column left-aligned,
+// derivations as numbered lists and figures as images linking their
+// File.
+func (p *Page) WriteMarkdown(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "# %s\n\n", p.Title)
+	if p.Subtitle != "" {
+		fmt.Fprintf(b, "*%s*\n\n", p.Subtitle)
+	}
+	for _, s := range p.Sections {
+		s.markdown(b)
+	}
+	return b.Flush()
+}
+
+func (s *Section) markdown(b *bufio.Writer) {
+	fmt.Fprintf(b, "## %s\n\n", s.Title)
+	for _, bl := range s.Blocks {
+		switch {
+		case bl.Text != "":
+			fmt.Fprintf(b, "%s\n\n", bl.Text)
+		case bl.Table != nil:
+			t := bl.Table
+			if t.Name != "" {
+				fmt.Fprintf(b, "**%s**\n\n", t.Name)
+			}
+			fmt.Fprintf(b, "| %s |\n|", strings.Join(t.Columns, " | "))
+			for j := range t.Columns {
+				if j == 0 {
+					b.WriteString(" :--- |")
+				} else {
+					b.WriteString(" ---: |")
+				}
+			}
+			b.WriteString("\n")
+			for _, row := range t.Rows {
+				cells := make([]string, len(row))
+				for j, v := range row {
+					cells[j] = strings.ReplaceAll(Cell(v), "|", `\|`)
+				}
+				fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+			}
+			b.WriteString("\n")
+		case bl.Steps != nil:
+			for i, st := range bl.Steps {
+				fmt.Fprintf(b, "%d. %s\n", i+1, st.Text)
+			}
+			b.WriteString("\n")
+		case bl.Figure != nil:
+			if bl.Figure.File != "" {
+				fmt.Fprintf(b, "![%s](%s)\n\n", bl.Figure.Caption, bl.Figure.File)
+			} else {
+				fmt.Fprintf(b, "*Figure: %s*\n\n", bl.Figure.Caption)
+			}
+		}
+	}
+}
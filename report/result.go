@@ -0,0 +1,278 @@
+// Package report builds the structured result documents that the
+// analysis commands emit with -format json, and the self-contained HTML
+// pages written by the report command.
+//
+// Every document carries the schema name and version, so consumers can
+// detect incompatible changes: fields may be added within a version,
+// but renaming or removing a field, or changing its meaning, bumps
+// Version.
+package report
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Schema identifies the result document format.
+const Schema = "applied-statistics/result"
+
+// Version is the version of the result schema.
+const Version = 1
+
+// Result is the structured result of one analysis.
+type Result struct {
+	Schema     string      `json:"schema"`
+	Version    int         `json:"version"`
+	Command    string      `json:"command"`
+	Dataset    Dataset     `json:"dataset"`
+	Statistics []Statistic `json:"statistics"`
+	Tables     []Table     `json:"tables,omitempty"`
+	Tests      []Test      `json:"tests,omitempty"`
+	Intervals  []Interval  `json:"intervals,omitempty"`
+	Plots      []string    `json:"plots,omitempty"`
+}
+
+// Dataset describes the data an analysis was run on. N counts the rows
+// analysed, after leaving out the Dropped rows with missing values.
+type Dataset struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	N           int        `json:"n"`
+	Dropped     int        `json:"dropped,omitempty"`
+	Variables   []Variable `json:"variables"`
+}
+
+// Variable describes one variable of a dataset. Kind is "numeric",
+// "nominal", "ordinal", "boolean" or "id". Missing counts its missing
+// values in the input, of which Imputed were filled in by the method
+// Imputation.
+type Variable struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Unit       string `json:"unit,omitempty"`
+	Missing    int    `json:"missing,omitempty"`
+	Imputed    int    `json:"imputed,omitempty"`
+	Imputation string `json:"imputation,omitempty"`
+}
+
+// Statistic is one computed statistic, of a variable and, for grouped
+// data, of one group. Value is a number, a level of a categorical
+// variable, or a list of numbers for multi-valued statistics such as
+// the modes; it is null when the statistic is undefined. Method says how it was computed, e.g.
+// "sample (n-1)" for a variance.
+type Statistic struct {
+	Name     string `json:"name"`
+	Variable string `json:"variable,omitempty"`
+	Group    string `json:"group,omitempty"`
+	Value    any    `json:"value"`
+	Unit     string `json:"unit,omitempty"`
+	Method   string `json:"method,omitempty"`
+}
+
+// Table is a table of results such as a frequency table, as column
+// names and rows of values.
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// Test is the result of a hypothesis test or a test-like summary such
+// as a correlation coefficient, with the sizes of the effect it tests.
+// Value and PValue are numbers and DF a slice of them, or null when
+// undefined.
+type Test struct {
+	Name        string   `json:"name"`
+	Statistic   string   `json:"statistic"`
+	Value       any      `json:"value"`
+	DF          any      `json:"df,omitempty"`
+	PValue      any      `json:"p_value,omitempty"`
+	Alternative string   `json:"alternative,omitempty"`
+	Conclusion  string   `json:"conclusion,omitempty"`
+	Effects     []Effect `json:"effects,omitempty"`
+}
+
+// Effect is an effect size such as Cohen's d, with its confidence
+// interval [Lower, Upper] at Level and the conventional label of its
+// Magnitude, e.g. "medium". Value, Lower and Upper are numbers, or
+// null when undefined.
+type Effect struct {
+	Name      string  `json:"name"`
+	Symbol    string  `json:"symbol"`
+	Value     any     `json:"value"`
+	Lower     any     `json:"lower"`
+	Upper     any     `json:"upper"`
+	Level     float64 `json:"level"`
+	Magnitude string  `json:"magnitude,omitempty"`
+}
+
+// Interval is a confidence interval for a parameter of a variable,
+// such as its mean, computed by Method at confidence Level. Coverage,
+// if set and not 0, is the exact coverage probability of a
+// distribution-free interval. Estimate, Lower, Upper and Coverage are
+// numbers, or null when undefined.
+type Interval struct {
+	Parameter string  `json:"parameter"`
+	Variable  string  `json:"variable,omitempty"`
+	Estimate  any     `json:"estimate"`
+	Lower     any     `json:"lower"`
+	Upper     any     `json:"upper"`
+	Level     float64 `json:"level"`
+	Coverage  any     `json:"coverage,omitempty"`
+	Unit      string  `json:"unit,omitempty"`
+	Method    string  `json:"method"`
+}
+
+// New returns an empty result of command for dataset.
+func New(command string, dataset Dataset) *Result {
+	return &Result{
+		Schema:     Schema,
+		Version:    Version,
+		Command:    command,
+		Dataset:    dataset,
+		Statistics: []Statistic{},
+	}
+}
+
+// Add records the statistic name of the dataset as a whole, or of its
+// only variable.
+func (r *Result) Add(name string, value any, unit, method string) {
+	r.AddOf("", name, value, unit, method)
+}
+
+// AddOf records the statistic name of variable.
+func (r *Result) AddOf(variable, name string, value any, unit, method string) {
+	r.Statistics = append(r.Statistics, Statistic{
+		Name:     name,
+		Variable: variable,
+		Value:    value,
+		Unit:     unit,
+		Method:   method,
+	})
+}
+
+// AddGroup records the statistic name of one group of the data.
+func (r *Result) AddGroup(group, name string, value any, unit, method string) {
+	r.Statistics = append(r.Statistics, Statistic{
+		Name:   name,
+		Group:  group,
+		Value:  value,
+		Unit:   unit,
+		Method: method,
+	})
+}
+
+// Write writes r to w as indented JSON. Non-finite numbers, which JSON
+// cannot represent, are written as null.
+func (r *Result) Write(w io.Writer) error {
+	for i := range r.Statistics {
+		r.Statistics[i].Value = finite(r.Statistics[i].Value)
+	}
+	for i := range r.Tests {
+		t := &r.Tests[i]
+		t.Value, t.DF, t.PValue = finite(t.Value), finite(t.DF), finite(t.PValue)
+		if df, ok := t.DF.([]any); ok && len(df) == 0 {
+			t.DF = nil
+		}
+		for i := range t.Effects {
+			e := &t.Effects[i]
+			e.Value, e.Lower, e.Upper = finite(e.Value), finite(e.Lower), finite(e.Upper)
+		}
+	}
+	for i := range r.Intervals {
+		iv := &r.Intervals[i]
+		iv.Estimate, iv.Lower, iv.Upper = finite(iv.Estimate), finite(iv.Lower), finite(iv.Upper)
+		if iv.Coverage = finite(iv.Coverage); iv.Coverage == 0.0 {
+			iv.Coverage = nil
+		}
+	}
+	for _, t := range r.Tables {
+		for _, row := range t.Rows {
+			for j := range row {
+				row[j] = finite(row[j])
+			}
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// finite replaces NaN and infinite float64s, alone or in a slice, by
+// nil.
+func finite(v any) any {
+	switch v := v.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+	case []float64:
+		out := make([]any, len(v))
+		for i, x := range v {
+			out[i] = finite(x)
+		}
+		return out
+	}
+	return v
+}
+
+// Format is the output format of a command: text for people, json for
+// programs, and for commands that write a document also md, latex or
+// pdf.
+type Format string
+
+// The output formats.
+const (
+	Text     Format = "text"
+	JSON     Format = "json"
+	Markdown Format = "md"
+	LaTeX    Format = "latex"
+	PDF      Format = "pdf"
+)
+
+// FormatFlag registers the -format flag on the default flag set and
+// returns the format it sets once flag.Parse has been called.
+func FormatFlag() *Format {
+	return FormatFlagSet(flag.CommandLine)
+}
+
+// FormatFlagSet is like FormatFlag for the flag set fs.
+func FormatFlagSet(fs *flag.FlagSet) *Format {
+	f := Text
+	fs.Var(&f, "format", "output format: text or json")
+	return &f
+}
+
+// DocumentFormatFlagSet is like FormatFlagSet for a command that writes
+// a document, which -format md, latex and pdf also select.
+func DocumentFormatFlagSet(fs *flag.FlagSet) *Format {
+	f := Text
+	fs.Func("format", "output format: text, json, md, latex or pdf (default text)", func(s string) error {
+		switch Format(s) {
+		case Text, JSON, Markdown, LaTeX, PDF:
+			f = Format(s)
+			return nil
+		}
+		return fmt.Errorf("unknown format %q (want text, json, md, latex or pdf)", s)
+	})
+	return &f
+}
+
+func (f *Format) String() string {
+	if f == nil {
+		return string(Text)
+	}
+	return string(*f)
+}
+
+func (f *Format) Set(s string) error {
+	switch Format(s) {
+	case Text, JSON:
+		*f = Format(s)
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want text or json)", s)
+}
@@ -0,0 +1,105 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// TestWriteNonFinite checks that every number of a result that may be
+// undefined is written as null rather than failing the encoding.
+func TestWriteNonFinite(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	r := New("test", Dataset{Name: "data"})
+	r.Add("mean", nan, "", "")
+	r.Add("values", []float64{1, inf}, "", "")
+	r.Tables = append(r.Tables, Table{Name: "t", Columns: []string{"x"}, Rows: [][]any{{nan}}})
+	r.Tests = append(r.Tests, Test{
+		Name:      "t-test",
+		Statistic: "t",
+		Value:     nan,
+		DF:        []float64{nan},
+		PValue:    nan,
+		Effects:   []Effect{{Name: "Cohen's d", Symbol: "d", Value: nan, Lower: -inf, Upper: inf}},
+	})
+	r.Intervals = append(r.Intervals, Interval{
+		Parameter: "mean",
+		Estimate:  nan,
+		Lower:     -inf,
+		Upper:     inf,
+		Level:     0.95,
+		Coverage:  nan,
+		Method:    "t",
+	})
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	var got struct {
+		Statistics []struct{ Value any }
+		Tables     []struct{ Rows [][]any }
+		Tests      []struct {
+			Value   any
+			DF      []any
+			PValue  any `json:"p_value"`
+			Effects []struct{ Value, Lower, Upper any }
+		}
+		Intervals []map[string]any
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("reading the JSON back: %v", err)
+	}
+	for _, c := range []struct {
+		name string
+		v    any
+	}{
+		{"statistic", got.Statistics[0].Value},
+		{"statistic slice element", got.Statistics[1].Value.([]any)[1]},
+		{"table cell", got.Tables[0].Rows[0][0]},
+		{"test value", got.Tests[0].Value},
+		{"test df", got.Tests[0].DF[0]},
+		{"test p-value", got.Tests[0].PValue},
+		{"effect value", got.Tests[0].Effects[0].Value},
+		{"effect lower", got.Tests[0].Effects[0].Lower},
+		{"effect upper", got.Tests[0].Effects[0].Upper},
+		{"interval estimate", got.Intervals[0]["estimate"]},
+		{"interval lower", got.Intervals[0]["lower"]},
+		{"interval upper", got.Intervals[0]["upper"]},
+	} {
+		if c.v != nil {
+			t.Errorf("%s = %v, want null", c.name, c.v)
+		}
+	}
+	if c, ok := got.Intervals[0]["coverage"]; ok {
+		t.Errorf("coverage = %v, want it left out", c)
+	}
+}
+
+// TestWriteOmitsUnset checks that the optional numbers of a test and an
+// interval are left out when they are not set.
+func TestWriteOmitsUnset(t *testing.T) {
+	r := New("test", Dataset{Name: "data"})
+	r.Tests = append(r.Tests, Test{Name: "r", Statistic: "r", Value: 0.5})
+	r.Intervals = append(r.Intervals, Interval{Parameter: "mean", Estimate: 1.0, Lower: 0.0, Upper: 2.0, Level: 0.95, Coverage: 0.0, Method: "t"})
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	var got struct {
+		Tests     []map[string]any
+		Intervals []map[string]any
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"df", "p_value"} {
+		if v, ok := got.Tests[0][key]; ok {
+			t.Errorf("test %s = %v, want it left out", key, v)
+		}
+	}
+	if v, ok := got.Intervals[0]["coverage"]; ok {
+		t.Errorf("interval coverage = %v, want it left out", v)
+	}
+}
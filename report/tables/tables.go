@@ -0,0 +1,265 @@
+// Package tables builds the statistical tables printed at the back of
+// textbooks — the standard normal, t, chi-square and F critical values
+// and the binomial and Poisson cumulative probabilities — as a report
+// page. Every entry is computed from the distribution functions of
+// package dist.
+package tables
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/mayura-andrew/applied-statistics/dist"
+	"github.com/mayura-andrew/applied-statistics/report"
+)
+
+// Names are the tables, in the order of Page.
+var Names = []string{"z", "t", "chisq", "f", "binomial", "poisson"}
+
+// Options controls which entries the tables have.
+type Options struct {
+	// Decimals is the number of decimal places of every entry; zero
+	// means 4 for probabilities and 3 for critical values.
+	Decimals int
+	// Alpha are the upper-tail probabilities of the F tables, one
+	// table each; empty means 0.05 and 0.01.
+	Alpha []float64
+	// N are the numbers of trials of the binomial table; empty means
+	// 5, 10, 15 and 20.
+	N []int
+}
+
+func (o Options) decimals(def int) int {
+	if o.Decimals > 0 {
+		return o.Decimals
+	}
+	return def
+}
+
+// fixed formats v with d decimals, keeping trailing zeros so that the
+// columns line up.
+func fixed(v float64, d int) string {
+	if math.IsInf(v, 1) {
+		return "∞"
+	}
+	return strconv.FormatFloat(v, 'f', d, 64)
+}
+
+// label formats a parameter for a row or column heading.
+func label(v float64) string {
+	if math.IsInf(v, 1) {
+		return "∞"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Page returns a page with the tables named, in the order of Names.
+func Page(names []string, o Options) (*report.Page, error) {
+	page := &report.Page{
+		Title:    "Statistical Tables",
+		Subtitle: "Computed from the distribution functions, not transcribed",
+	}
+	add := map[string]func(*report.Section, Options){
+		"z":        normal,
+		"t":        studentT,
+		"chisq":    chiSquare,
+		"f":        fisherF,
+		"binomial": binomial,
+		"poisson":  poisson,
+	}
+	titles := map[string]string{
+		"z":        "Standard Normal Distribution",
+		"t":        "Critical Values of Student's t",
+		"chisq":    "Critical Values of the Chi-Square Distribution",
+		"f":        "Critical Values of the F Distribution",
+		"binomial": "Cumulative Binomial Probabilities",
+		"poisson":  "Cumulative Poisson Probabilities",
+	}
+	want := make(map[string]bool)
+	for _, n := range names {
+		if add[n] == nil {
+			return nil, fmt.Errorf("unknown table %q (want z, t, chisq, f, binomial or poisson)", n)
+		}
+		want[n] = true
+	}
+	for _, n := range Names {
+		if want[n] {
+			add[n](page.Section(titles[n]), o)
+		}
+	}
+	return page, nil
+}
+
+// normal adds the table of Φ(z) and the common critical values of z.
+func normal(s *report.Section, o Options) {
+	d := o.decimals(4)
+	z := dist.Normal{Mu: 0, Sigma: 1}
+	s.Para("The entries are Φ(z) = P(Z ≤ z) for the standard normal Z, the row giving z to one decimal and the column its second decimal. For z < 0, Φ(z) = 1 − Φ(−z).")
+	t := report.Table{Name: "Φ(z)", Columns: []string{"z"}}
+	for j := range 10 {
+		t.Columns = append(t.Columns, fmt.Sprintf("0.0%d", j))
+	}
+	for i := range 35 {
+		row := []any{fmt.Sprintf("%.1f", float64(i)/10)}
+		for j := range 10 {
+			row = append(row, fixed(z.CDF(float64(10*i+j)/100), d))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	s.AddTable(t)
+
+	s.Para("The critical value z_α has P(Z > z_α) = α; a two-sided test at level α uses z_(α/2).")
+	c := report.Table{Name: "Critical values z_α", Columns: []string{"α"}}
+	row := []any{"z_α"}
+	for _, a := range []float64{0.10, 0.05, 0.025, 0.01, 0.005, 0.001, 0.0005} {
+		c.Columns = append(c.Columns, label(a))
+		row = append(row, fixed(z.Quantile(1-a), o.decimals(3)))
+	}
+	c.Rows = [][]any{row}
+	s.AddTable(c)
+}
+
+// degrees are the degrees of freedom of the rows of the t and F
+// tables.
+var degrees = []float64{
+	1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+	21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 40, 60, 120, math.Inf(1),
+}
+
+// studentT adds the upper-tail critical values of t.
+func studentT(s *report.Section, o Options) {
+	d := o.decimals(3)
+	alphas := []float64{0.10, 0.05, 0.025, 0.01, 0.005, 0.001, 0.0005}
+	s.Para("The entries are t such that P(T > t) = α for Student's t with df degrees of freedom; a two-sided test at level α uses the column α/2. The row ∞ is the standard normal.")
+	t := report.Table{Name: "Upper-tail critical values t_(α, df)", Columns: []string{"df"}}
+	for _, a := range alphas {
+		t.Columns = append(t.Columns, label(a))
+	}
+	for _, df := range degrees {
+		row := []any{label(df)}
+		for _, a := range alphas {
+			var q float64
+			if math.IsInf(df, 1) {
+				q = dist.Normal{Mu: 0, Sigma: 1}.Quantile(1 - a)
+			} else {
+				q = dist.StudentT{DF: df}.Quantile(1 - a)
+			}
+			row = append(row, fixed(q, d))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	s.AddTable(t)
+}
+
+// chiSquare adds the critical values of chi-square in both tails.
+func chiSquare(s *report.Section, o Options) {
+	d := o.decimals(3)
+	alphas := []float64{0.995, 0.99, 0.975, 0.95, 0.90, 0.10, 0.05, 0.025, 0.01, 0.005}
+	s.Para("The entries are χ² such that P(X > χ²) = α for the chi-square distribution with df degrees of freedom. The columns α > 0.5 give the lower tail.")
+	t := report.Table{Name: "Critical values χ²_(α, df)", Columns: []string{"df"}}
+	for _, a := range alphas {
+		t.Columns = append(t.Columns, label(a))
+	}
+	dfs := append(degrees[:30:30], 40, 50, 60, 70, 80, 90, 100)
+	for _, df := range dfs {
+		row := []any{label(df)}
+		for _, a := range alphas {
+			row = append(row, fixed(dist.ChiSquare{DF: df}.Quantile(1-a), d))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	s.AddTable(t)
+}
+
+// fisherF adds a table of upper-tail critical values of F for each α.
+func fisherF(s *report.Section, o Options) {
+	d := o.decimals(3)
+	alphas := o.Alpha
+	if len(alphas) == 0 {
+		alphas = []float64{0.05, 0.01}
+	}
+	num := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 15, 20, 24, 30, 40, 60, 120, math.Inf(1)}
+	s.Para("The entries are F such that P(X > F) = α for the F distribution with df1 degrees of freedom in the numerator (columns) and df2 in the denominator (rows). The lower-tail value is 1 / F with the degrees of freedom swapped.")
+	for _, a := range alphas {
+		t := report.Table{Name: fmt.Sprintf("Critical values F_(%s; df1, df2)", label(a)), Columns: []string{"df2 \\ df1"}}
+		for _, d1 := range num {
+			t.Columns = append(t.Columns, label(d1))
+		}
+		for _, d2 := range degrees {
+			row := []any{label(d2)}
+			for _, d1 := range num {
+				row = append(row, fixed(fCritical(a, d1, d2), d))
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		s.AddTable(t)
+	}
+}
+
+// fCritical returns the upper-tail α critical value of F(d1, d2), with
+// the limits of infinite degrees of freedom: F(d1, ∞) is χ²(d1)/d1 and
+// F(∞, d2) is d2/χ²(d2).
+func fCritical(alpha, d1, d2 float64) float64 {
+	switch {
+	case math.IsInf(d1, 1) && math.IsInf(d2, 1):
+		return 1
+	case math.IsInf(d2, 1):
+		return dist.ChiSquare{DF: d1}.Quantile(1-alpha) / d1
+	case math.IsInf(d1, 1):
+		return d2 / dist.ChiSquare{DF: d2}.Quantile(alpha)
+	}
+	return dist.F{D1: d1, D2: d2}.Quantile(1 - alpha)
+}
+
+// binomial adds the cumulative probabilities P(X ≤ k) of each n.
+func binomial(s *report.Section, o Options) {
+	d := o.decimals(4)
+	ns := o.N
+	if len(ns) == 0 {
+		ns = []int{5, 10, 15, 20}
+	}
+	ps := []float64{0.05, 0.10, 0.20, 0.25, 0.30, 0.40, 0.50, 0.60, 0.70, 0.75, 0.80, 0.90, 0.95}
+	s.Para("The entries are P(X ≤ k) for X ~ Binomial(n, p), the number of successes in n trials of success probability p. P(X = k) is the difference of consecutive entries, and P(X ≥ k) = 1 − P(X ≤ k − 1).")
+	for _, n := range ns {
+		t := report.Table{Name: fmt.Sprintf("n = %d", n), Columns: []string{"k"}}
+		for _, p := range ps {
+			t.Columns = append(t.Columns, label(p))
+		}
+		for k := range n + 1 {
+			row := []any{strconv.Itoa(k)}
+			for _, p := range ps {
+				row = append(row, fixed(dist.Binomial{N: n, P: p}.CDF(float64(k)), d))
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		s.AddTable(t)
+	}
+}
+
+// poisson adds the cumulative probabilities P(X ≤ k), the rows running
+// until every entry rounds to 1.
+func poisson(s *report.Section, o Options) {
+	d := o.decimals(4)
+	lambdas := []float64{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5, 6, 7, 8, 9, 10}
+	s.Para("The entries are P(X ≤ k) for X ~ Poisson(λ). P(X = k) is the difference of consecutive entries, and P(X ≥ k) = 1 − P(X ≤ k − 1).")
+	t := report.Table{Name: "P(X ≤ k)", Columns: []string{"k \\ λ"}}
+	for _, l := range lambdas {
+		t.Columns = append(t.Columns, label(l))
+	}
+	one := fixed(1, d)
+	for k := 0; ; k++ {
+		row := []any{strconv.Itoa(k)}
+		done := true
+		for _, l := range lambdas {
+			v := fixed(dist.Poisson{Lambda: l}.CDF(float64(k)), d)
+			done = done && v == one
+			row = append(row, v)
+		}
+		t.Rows = append(t.Rows, row)
+		if done {
+			break
+		}
+	}
+	s.AddTable(t)
+}
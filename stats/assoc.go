@@ -0,0 +1,225 @@
+package stats
+
+import "math"
+
+// The measures of association below take a contingency table of
+// counts, rows by columns. The ordinal measures, gamma, Somers' d and
+// Kendall's tau-c, need the rows and the columns in their natural
+// order; the others ignore the order. Their intervals use the
+// asymptotic standard errors of Agresti's Categorical Data Analysis,
+// and are NaN where none is given.
+
+// Transpose returns the table with its rows and columns swapped, for
+// the asymmetric measures in the other direction.
+func Transpose(table [][]float64) [][]float64 {
+	t := make([][]float64, len(table[0]))
+	for j := range t {
+		t[j] = make([]float64, len(table))
+		for i := range table {
+			t[j][i] = table[i][j]
+		}
+	}
+	return t
+}
+
+// Association returns the measures of association of a table that
+// suit it: Cramér's V, the contingency coefficient and Goodman and
+// Kruskal's lambda always; gamma, Somers' d and Kendall's tau-c if
+// ordinal; and φ, the odds ratio and the relative risk for a 2 × 2
+// table.
+func Association(table [][]float64, ordinal bool) []EffectSize {
+	out := []EffectSize{
+		CramersV(table), ContingencyCoefficient(table),
+		Lambda(table), Lambda(Transpose(table)), LambdaSymmetric(table),
+	}
+	out[3].Name = "Goodman–Kruskal lambda, rows from columns"
+	out[3].Symbol = "λ(R|C)"
+	if ordinal {
+		d := SomersD(Transpose(table))
+		d.Name, d.Symbol = "Somers' d, rows on columns", "d(R|C)"
+		out = append(out, Gamma(table), SomersD(table), d, KendallTauC(table))
+	}
+	if len(table) == 2 && len(table[0]) == 2 {
+		out = append(out, Phi(table), OddsRatio(table), RelativeRisk(table))
+	}
+	return out
+}
+
+// noInterval returns the effect v, without an interval.
+func noInterval(name, symbol string, v float64) EffectSize {
+	return EffectSize{Name: name, Symbol: symbol, Value: v, Lower: math.NaN(), Upper: math.NaN(), Level: EffectLevel}
+}
+
+// aseEffect returns the effect v with the interval v ± z ase, clamped
+// to [−1, 1], labelled as a correlation.
+func aseEffect(name, symbol string, v, ase float64) EffectSize {
+	e := normalEffect(name, symbol, v, ase, rLabel)
+	e.Lower, e.Upper = max(-1, e.Lower), min(1, e.Upper)
+	return e
+}
+
+// ContingencyCoefficient returns Pearson's contingency coefficient
+// C = √(χ²/(χ² + n)). Its maximum is √((k − 1)/k), with k the smaller
+// of the numbers of rows and columns, so it is best compared between
+// tables of the same shape.
+func ContingencyCoefficient(table [][]float64) EffectSize {
+	chi2, _, n := chiSquare(table)
+	return noInterval("contingency coefficient", "C", math.Sqrt(chi2/(chi2+n)))
+}
+
+// Lambda returns Goodman and Kruskal's λ(C|R), the proportional
+// reduction in the error of guessing the column of an observation from
+// its row instead of guessing the commonest column: 0 when the row
+// does not help, 1 when it settles the column.
+func Lambda(table [][]float64) EffectSize {
+	_, cols, n := tableTotals(table)
+	l := argmax(cols)
+	var sum, same float64
+	for _, row := range table {
+		li := argmax(row)
+		sum += row[li]
+		if li == l {
+			same += row[li]
+		}
+	}
+	r := cols[l]
+	v := (sum - r) / (n - r)
+	ase := math.Sqrt((n - sum) * (sum + r - 2*same) / math.Pow(n-r, 3))
+	e := normalEffect("Goodman–Kruskal lambda, columns from rows", "λ(C|R)", v, ase, func(float64) string { return "" })
+	e.Lower, e.Upper = max(0, e.Lower), min(1, e.Upper)
+	if ase == 0 {
+		// The standard error is degenerate at λ = 0 and λ = 1.
+		e.Lower, e.Upper = math.NaN(), math.NaN()
+	}
+	return e
+}
+
+// LambdaSymmetric returns Goodman and Kruskal's symmetric λ, the
+// reduction in error when the row and the column are each guessed from
+// the other, pooled.
+func LambdaSymmetric(table [][]float64) EffectSize {
+	rows, cols, n := tableTotals(table)
+	var sr, sc float64
+	for _, row := range table {
+		sr += row[argmax(row)]
+	}
+	for _, col := range Transpose(table) {
+		sc += col[argmax(col)]
+	}
+	r, c := rows[argmax(rows)], cols[argmax(cols)]
+	return noInterval("Goodman–Kruskal lambda, symmetric", "λ", (sr+sc-r-c)/(2*n-r-c))
+}
+
+// argmax returns the index of the first largest element of x.
+func argmax(x []float64) int {
+	m := 0
+	for i, v := range x {
+		if v > x[m] {
+			m = i
+		}
+	}
+	return m
+}
+
+// pairs returns, for each cell of an ordered table, the number of
+// observations concordant with it, above and to the left or below and
+// to the right, and discordant with it; and P and Q, their totals
+// weighted by the cell counts, twice the numbers of concordant and
+// discordant pairs.
+func pairs(table [][]float64) (conc, disc [][]float64, p, q float64) {
+	conc = make([][]float64, len(table))
+	disc = make([][]float64, len(table))
+	for i, row := range table {
+		conc[i] = make([]float64, len(row))
+		disc[i] = make([]float64, len(row))
+		for j, nij := range row {
+			for k, other := range table {
+				for l, nkl := range other {
+					switch {
+					case (k > i && l > j) || (k < i && l < j):
+						conc[i][j] += nkl
+					case (k > i && l < j) || (k < i && l > j):
+						disc[i][j] += nkl
+					}
+				}
+			}
+			p += nij * conc[i][j]
+			q += nij * disc[i][j]
+		}
+	}
+	return conc, disc, p, q
+}
+
+// Gamma returns Goodman and Kruskal's γ of an ordered table, the
+// difference between the proportions of concordant and discordant
+// pairs among the pairs not tied on either variable.
+func Gamma(table [][]float64) EffectSize {
+	conc, disc, p, q := pairs(table)
+	var s float64
+	for i, row := range table {
+		for j, nij := range row {
+			d := q*conc[i][j] - p*disc[i][j]
+			s += nij * d * d
+		}
+	}
+	return aseEffect("Goodman–Kruskal gamma", "γ", (p-q)/(p+q), 4/((p+q)*(p+q))*math.Sqrt(s))
+}
+
+// SomersD returns Somers' d(C|R) of an ordered table, the difference
+// between the proportions of concordant and discordant pairs among
+// the pairs not tied on the row, with the column as the dependent
+// variable.
+func SomersD(table [][]float64) EffectSize {
+	conc, disc, p, q := pairs(table)
+	rows, _, n := tableTotals(table)
+	w := n * n
+	for _, r := range rows {
+		w -= r * r
+	}
+	var s float64
+	for i, row := range table {
+		for j, nij := range row {
+			d := w*(conc[i][j]-disc[i][j]) - (p-q)*(n-rows[i])
+			s += nij * d * d
+		}
+	}
+	return aseEffect("Somers' d, columns on rows", "d(C|R)", (p-q)/w, 2/(w*w)*math.Sqrt(s))
+}
+
+// KendallTauC returns Kendall's τc, Stuart's tau-c, of an ordered
+// table, the difference between the numbers of concordant and
+// discordant pairs adjusted to reach ±1 in a table that is not square.
+func KendallTauC(table [][]float64) EffectSize {
+	conc, disc, p, q := pairs(table)
+	_, _, n := tableTotals(table)
+	m := float64(min(len(table), len(table[0])))
+	var s float64
+	for i, row := range table {
+		for j, nij := range row {
+			d := conc[i][j] - disc[i][j]
+			s += nij * d * d
+		}
+	}
+	k := m / ((m - 1) * n * n)
+	return aseEffect("Kendall's tau-c", "τc", k*(p-q), 2*k*math.Sqrt(max(0, s-(p-q)*(p-q)/n)))
+}
+
+// RelativeRisk returns the relative risk of a 2 × 2 table of counts
+// [[a, b], [c, d]], the proportion of the first column in the first
+// row over that in the second, (a/(a + b))/(c/(c + d)), with the
+// interval exp(ln RR ± z √(b/(a(a + b)) + d/(c(c + d)))). With a zero
+// count, 0.5 is added to every cell. It is NaN for other tables.
+func RelativeRisk(table [][]float64) EffectSize {
+	e := noInterval("relative risk", "RR", math.NaN())
+	if len(table) != 2 || len(table[0]) != 2 {
+		return e
+	}
+	a, b, c, d := table[0][0], table[0][1], table[1][0], table[1][1]
+	if a == 0 || b == 0 || c == 0 || d == 0 {
+		a, b, c, d = a+0.5, b+0.5, c+0.5, d+0.5
+	}
+	l := math.Log(a / (a + b) / (c / (c + d)))
+	h := z(EffectLevel) * math.Sqrt(b/(a*(a+b))+d/(c*(c+d)))
+	e.Value, e.Lower, e.Upper = math.Exp(l), math.Exp(l-h), math.Exp(l+h)
+	return e
+}
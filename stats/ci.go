@@ -0,0 +1,213 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mayura-andrew/applied-statistics/dist"
+)
+
+// Interval is a confidence interval [Lower, Upper] for a parameter,
+// computed by the method Name at confidence Level. Coverage is the
+// exact coverage probability of the distribution-free intervals of
+// quantiles, which is at least Level, and zero for the others.
+type Interval struct {
+	Name     string
+	Estimate float64
+	Lower    float64
+	Upper    float64
+	Level    float64
+	Coverage float64
+}
+
+// checkLevel checks that the confidence level is a probability.
+func checkLevel(level float64) error {
+	if !(level > 0 && level < 1) {
+		return fmt.Errorf("confidence level %v is not between 0 and 1", level)
+	}
+	return nil
+}
+
+// z returns the two-sided critical value of the standard normal at
+// confidence level.
+func z(level float64) float64 {
+	return dist.Normal{Mu: 0, Sigma: 1}.Quantile(1 - (1-level)/2)
+}
+
+// MeanTInterval returns Student's t interval for the mean of the
+// population x was sampled from, x̄ ± t(n−1) s/√n.
+func MeanTInterval(x []float64, level float64) (Interval, error) {
+	if err := checkLevel(level); err != nil {
+		return Interval{}, err
+	}
+	n := len(x)
+	if n < 2 {
+		return Interval{}, errors.New("t interval needs at least 2 observations")
+	}
+	m := Mean(x)
+	h := dist.StudentT{DF: float64(n - 1)}.Quantile(1-(1-level)/2) * StdDev(x) / math.Sqrt(float64(n))
+	return Interval{Name: "t interval", Estimate: m, Lower: m - h, Upper: m + h, Level: level}, nil
+}
+
+// MeanZInterval returns the z interval for the mean when the
+// population standard deviation sigma is known, x̄ ± z σ/√n.
+func MeanZInterval(x []float64, sigma, level float64) (Interval, error) {
+	if err := checkLevel(level); err != nil {
+		return Interval{}, err
+	}
+	if len(x) == 0 {
+		return Interval{}, errors.New("z interval needs at least 1 observation")
+	}
+	if !(sigma > 0) {
+		return Interval{}, fmt.Errorf("known standard deviation %v is not positive", sigma)
+	}
+	m := Mean(x)
+	h := z(level) * sigma / math.Sqrt(float64(len(x)))
+	return Interval{Name: "z interval", Estimate: m, Lower: m - h, Upper: m + h, Level: level}, nil
+}
+
+// checkCount checks k successes in n trials.
+func checkCount(k, n int, level float64) error {
+	if err := checkLevel(level); err != nil {
+		return err
+	}
+	if n < 1 || k < 0 || k > n {
+		return fmt.Errorf("%d successes in %d trials", k, n)
+	}
+	return nil
+}
+
+// WilsonInterval returns Wilson's score interval for a proportion from
+// k successes in n trials, the proportions that a two-sided score test
+// would not reject. It stays within [0, 1] and keeps close to its
+// nominal coverage even for small n.
+func WilsonInterval(k, n int, level float64) (Interval, error) {
+	if err := checkCount(k, n, level); err != nil {
+		return Interval{}, err
+	}
+	z := z(level)
+	nf, p := float64(n), float64(k)/float64(n)
+	d := 1 + z*z/nf
+	c := (p + z*z/(2*nf)) / d
+	h := z / d * math.Sqrt(p*(1-p)/nf+z*z/(4*nf*nf))
+	return Interval{Name: "Wilson score interval", Estimate: p, Lower: max(0, c-h), Upper: min(1, c+h), Level: level}, nil
+}
+
+// AgrestiCoullInterval returns the Agresti–Coull interval for a
+// proportion: the Wald interval after adding z²/2 successes and z²/2
+// failures.
+func AgrestiCoullInterval(k, n int, level float64) (Interval, error) {
+	if err := checkCount(k, n, level); err != nil {
+		return Interval{}, err
+	}
+	z := z(level)
+	nt := float64(n) + z*z
+	pt := (float64(k) + z*z/2) / nt
+	h := z * math.Sqrt(pt*(1-pt)/nt)
+	return Interval{Name: "Agresti–Coull interval", Estimate: float64(k) / float64(n), Lower: max(0, pt-h), Upper: min(1, pt+h), Level: level}, nil
+}
+
+// ClopperPearsonInterval returns the Clopper–Pearson "exact" interval
+// for a proportion, from the quantiles of beta distributions. Its
+// coverage is never below level, and usually above.
+func ClopperPearsonInterval(k, n int, level float64) (Interval, error) {
+	if err := checkCount(k, n, level); err != nil {
+		return Interval{}, err
+	}
+	a := (1 - level) / 2
+	lo, hi := 0.0, 1.0
+	if k > 0 {
+		lo = dist.Beta{Alpha: float64(k), Beta: float64(n - k + 1)}.Quantile(a)
+	}
+	if k < n {
+		hi = dist.Beta{Alpha: float64(k + 1), Beta: float64(n - k)}.Quantile(1 - a)
+	}
+	return Interval{Name: "Clopper–Pearson interval", Estimate: float64(k) / float64(n), Lower: lo, Upper: hi, Level: level}, nil
+}
+
+// VarianceInterval returns the chi-square interval for the variance of
+// the normal population x was sampled from,
+// [(n−1)s²/χ²(1−α/2), (n−1)s²/χ²(α/2)]. Unlike the t interval it is
+// sensitive to departures from normality.
+func VarianceInterval(x []float64, level float64) (Interval, error) {
+	if err := checkLevel(level); err != nil {
+		return Interval{}, err
+	}
+	n := len(x)
+	if n < 2 {
+		return Interval{}, errors.New("variance interval needs at least 2 observations")
+	}
+	v := Variance(x)
+	df := float64(n - 1)
+	chi := dist.ChiSquare{DF: df}
+	a := (1 - level) / 2
+	return Interval{
+		Name:     "chi-square interval",
+		Estimate: v,
+		Lower:    df * v / chi.Quantile(1-a),
+		Upper:    df * v / chi.Quantile(a),
+		Level:    level,
+	}, nil
+}
+
+// StdDevInterval returns the chi-square interval for the standard
+// deviation, the square root of the ends of VarianceInterval.
+func StdDevInterval(x []float64, level float64) (Interval, error) {
+	iv, err := VarianceInterval(x, level)
+	if err != nil {
+		return Interval{}, err
+	}
+	iv.Estimate, iv.Lower, iv.Upper = math.Sqrt(iv.Estimate), math.Sqrt(iv.Lower), math.Sqrt(iv.Upper)
+	return iv, nil
+}
+
+// QuantileInterval returns the distribution-free interval for the
+// p-quantile of the population x was sampled from, between two order
+// statistics x(r) and x(s). The number of observations below the
+// quantile is Binomial(n, p), so the coverage P(r ≤ B < s) is exact
+// for any continuous population; r and s are chosen with at most
+// (1 − level)/2 in each tail. It fails when n is too small to reach
+// level.
+func QuantileInterval(x []float64, p, level float64) (Interval, error) {
+	if err := checkLevel(level); err != nil {
+		return Interval{}, err
+	}
+	if !(p > 0 && p < 1) {
+		return Interval{}, fmt.Errorf("quantile %v is not between 0 and 1", p)
+	}
+	n := len(x)
+	if n == 0 {
+		return Interval{}, errors.New("quantile interval needs at least 1 observation")
+	}
+	b := dist.Binomial{N: n, P: p}
+	a := (1 - level) / 2
+	// r is the largest rank with P(B ≤ r − 1) ≤ a, s the smallest
+	// with P(B ≤ s − 1) ≥ 1 − a.
+	r := 0
+	for r < n && b.CDF(float64(r)) <= a {
+		r++
+	}
+	s := n + 1
+	for s > 1 && b.CDF(float64(s-2)) >= 1-a {
+		s--
+	}
+	if r < 1 || s > n {
+		return Interval{}, fmt.Errorf("%d observations are too few for a %g%% interval for the %g-quantile", n, 100*level, p)
+	}
+	sorted := Sorted(x)
+	return Interval{
+		Name:     fmt.Sprintf("order-statistic interval x(%d) to x(%d)", r, s),
+		Estimate: Quantile(sorted, p),
+		Lower:    sorted[r-1],
+		Upper:    sorted[s-1],
+		Level:    level,
+		Coverage: b.CDF(float64(s-1)) - b.CDF(float64(r-1)),
+	}, nil
+}
+
+// MedianInterval returns the distribution-free interval for the
+// median, QuantileInterval at p = 0.5.
+func MedianInterval(x []float64, level float64) (Interval, error) {
+	return QuantileInterval(x, 0.5, level)
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// TestProportionIntervals checks the 95% intervals for a proportion
+// against methods 3 and 5 of Table II of Newcombe (1998), "Two-sided
+// confidence intervals for the single proportion", Statistics in
+// Medicine 17, which are given to four decimals.
+func TestProportionIntervals(t *testing.T) {
+	for _, c := range []struct {
+		k, n            int
+		wilson, clopper [2]float64
+	}{
+		{81, 263, [2]float64{0.2553, 0.3662}, [2]float64{0.2527, 0.3676}},
+		{15, 148, [2]float64{0.0624, 0.1605}, [2]float64{0.0578, 0.1617}},
+		{0, 20, [2]float64{0, 0.1611}, [2]float64{0, 0.1684}},
+		{1, 29, [2]float64{0.0061, 0.1718}, [2]float64{0.0009, 0.1776}},
+	} {
+		for _, m := range []struct {
+			name     string
+			interval func(k, n int, level float64) (Interval, error)
+			want     [2]float64
+		}{
+			{"Wilson", WilsonInterval, c.wilson},
+			{"Clopper–Pearson", ClopperPearsonInterval, c.clopper},
+		} {
+			iv, err := m.interval(c.k, c.n, 0.95)
+			if err != nil {
+				t.Errorf("%s %d/%d: %v", m.name, c.k, c.n, err)
+				continue
+			}
+			if math.Abs(iv.Lower-m.want[0]) > 5e-5 || math.Abs(iv.Upper-m.want[1]) > 5e-5 {
+				t.Errorf("%s %d/%d = [%.4f, %.4f], want [%.4f, %.4f]", m.name, c.k, c.n, iv.Lower, iv.Upper, m.want[0], m.want[1])
+			}
+		}
+	}
+}
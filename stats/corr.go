@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Pearson returns Pearson's product-moment correlation coefficient of
+// the paired samples x and y.
+func Pearson(x, y []float64) (float64, error) {
+	if len(x) != len(y) {
+		return math.NaN(), errors.New("samples of different lengths")
+	}
+	if len(x) < 3 {
+		return math.NaN(), errors.New("correlation needs at least 3 pairs")
+	}
+	mx, my := Mean(x), Mean(y)
+	sxy, sxx, syy := coSum(x, y, mx, my), sumSquares(x, mx), sumSquares(y, my)
+	if sxx <= 0 || syy <= 0 {
+		return math.NaN(), errors.New("correlation undefined for a constant sample")
+	}
+	return sxy / math.Sqrt(sxx*syy), nil
+}
+
+// Spearman returns Spearman's rank correlation coefficient of the
+// paired samples x and y: Pearson's r of their ranks, with tied values
+// given their average rank.
+func Spearman(x, y []float64) (float64, error) {
+	if len(x) != len(y) {
+		return math.NaN(), errors.New("samples of different lengths")
+	}
+	return Pearson(Ranks(x), Ranks(y))
+}
+
+// Ranks returns the ranks of x, from 1, with ties given the average of
+// the ranks they span.
+func Ranks(x []float64) []float64 {
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return x[idx[i]] < x[idx[j]] })
+	r := make([]float64, len(x))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			r[idx[k]] = rank
+		}
+		i = j
+	}
+	return r
+}
+
+// CorrelationTest tests whether a correlation coefficient r of n pairs
+// differs from zero, using t = r √((n - 2) / (1 - r²)) with n - 2
+// degrees of freedom. Its effect size is r itself, with Fisher's
+// interval.
+func CorrelationTest(r float64, n int) TestResult {
+	df := float64(n - 2)
+	t := r * math.Sqrt(df/(1-r*r))
+	return TestResult{
+		Name:      "correlation t-test",
+		Statistic: "t",
+		Value:     t,
+		DF:        []float64{df},
+		PValue:    2 * StudentTSF(math.Abs(t), df),
+		Effects:   []EffectSize{CorrelationEffect(r, n)},
+	}
+}
@@ -0,0 +1,124 @@
+// Package stats implements the descriptive statistics shared by the
+// analysis commands.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Mean returns the arithmetic mean of x, or NaN when x is empty. The
+// sum is compensated, as by Sum.
+func Mean(x []float64) float64 {
+	if len(x) == 0 {
+		return math.NaN()
+	}
+	return Sum(x) / float64(len(x))
+}
+
+// Variance returns the sample variance of x, Σ(xi - x̄)² / (n - 1), by
+// the corrected two-pass algorithm.
+func Variance(x []float64) float64 {
+	n := len(x)
+	if n < 2 {
+		return math.NaN()
+	}
+	return sumSquares(x, Mean(x)) / float64(n-1)
+}
+
+// StdDev returns the sample standard deviation of x.
+func StdDev(x []float64) float64 {
+	return math.Sqrt(Variance(x))
+}
+
+// Sorted returns a sorted copy of x.
+func Sorted(x []float64) []float64 {
+	s := make([]float64, len(x))
+	copy(s, x)
+	sort.Float64s(s)
+	return s
+}
+
+// Quantile returns the p-quantile of the sorted sample s using linear
+// interpolation between order statistics (Hyndman & Fan type 7, the
+// default of R and spreadsheets).
+func Quantile(s []float64, p float64) float64 {
+	n := len(s)
+	if n == 0 {
+		return math.NaN()
+	}
+	h := p * float64(n-1)
+	lo := int(math.Floor(h))
+	if lo >= n-1 {
+		return s[n-1]
+	}
+	if lo < 0 {
+		return s[0]
+	}
+	return s[lo] + (h-float64(lo))*(s[lo+1]-s[lo])
+}
+
+// PopVariance returns the population variance of x, Σ(xi - μ)² / n.
+func PopVariance(x []float64) float64 {
+	n := len(x)
+	if n == 0 {
+		return math.NaN()
+	}
+	return sumSquares(x, Mean(x)) / float64(n)
+}
+
+// Median returns the median of the sorted sample s: the middle value,
+// or the mean of the two middle values when len(s) is even.
+func Median(s []float64) float64 {
+	n := len(s)
+	switch {
+	case n == 0:
+		return math.NaN()
+	case n%2 == 0:
+		return (s[n/2-1] + s[n/2]) / 2
+	}
+	return s[n/2]
+}
+
+// Hinges returns the lower and upper quartiles of the sorted sample s
+// as the medians of its lower and upper halves, excluding the median
+// itself when len(s) is odd (the textbook "median of halves" method).
+func Hinges(s []float64) (q1, q3 float64) {
+	n := len(s)
+	return Median(s[:n/2]), Median(s[(n+1)/2:])
+}
+
+// Modes returns the most frequent values of x in increasing order, or
+// nil when every value occurs once.
+func Modes(x []float64) []float64 {
+	freq := make(map[float64]int)
+	top := 0
+	for _, v := range x {
+		freq[v]++
+		top = max(top, freq[v])
+	}
+	if top < 2 {
+		return nil
+	}
+	var modes []float64
+	for v, f := range freq {
+		if f == top {
+			modes = append(modes, v)
+		}
+	}
+	sort.Float64s(modes)
+	return modes
+}
+
+// Skewness returns the moment coefficient of skewness of x,
+// m3 / m2^(3/2), with the central moments taken over n.
+func Skewness(x []float64) float64 {
+	m := Mean(x)
+	var m3 compensated
+	for _, v := range x {
+		d := v - m
+		m3.add(d * d * d)
+	}
+	n := float64(len(x))
+	return (m3.value() / n) / math.Pow(sumSquares(x, m)/n, 1.5)
+}
@@ -0,0 +1,331 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/mayura-andrew/applied-statistics/dist"
+)
+
+// EffectLevel is the confidence level of the intervals of the effect
+// sizes attached to test results.
+const EffectLevel = 0.95
+
+// EffectSize is a standardized measure of how large an effect is,
+// whatever the sample size: Value with the confidence interval [Lower,
+// Upper] at Level, and the conventional label of its magnitude,
+// "negligible", "small", "medium" or "large". Lower and Upper are NaN
+// when the interval is undefined.
+type EffectSize struct {
+	Name      string
+	Symbol    string
+	Value     float64
+	Lower     float64
+	Upper     float64
+	Level     float64
+	Magnitude string
+}
+
+// magnitude labels |v| by the thresholds of a small, medium and large
+// effect.
+func magnitude(v, small, medium, large float64) string {
+	switch v = math.Abs(v); {
+	case math.IsNaN(v):
+		return ""
+	case v < small:
+		return "negligible"
+	case v < medium:
+		return "small"
+	case v < large:
+		return "medium"
+	}
+	return "large"
+}
+
+// dLabel labels a standardized mean difference by Cohen's thresholds
+// 0.2, 0.5 and 0.8.
+func dLabel(d float64) string { return magnitude(d, 0.2, 0.5, 0.8) }
+
+// rLabel labels a correlation by Cohen's thresholds 0.1, 0.3 and 0.5.
+func rLabel(r float64) string { return magnitude(r, 0.1, 0.3, 0.5) }
+
+// CohensD returns Cohen's d of the one-sample t-test, the distance of
+// the mean of x from mu in standard deviations, (x̄ − μ)/s. The
+// interval uses the normal approximation of Hedges and Olkin, with
+// standard error √(1/n + d²/2n).
+func CohensD(x []float64, mu float64) EffectSize {
+	n := float64(len(x))
+	d := (Mean(x) - mu) / StdDev(x)
+	return normalEffect("Cohen's d", "d", d, math.Sqrt(1/n+d*d/(2*n)), dLabel)
+}
+
+// CohensDTwo returns Cohen's d of two samples, the difference of their
+// means in pooled standard deviations, with the interval of the normal
+// approximation, standard error √((n₁ + n₂)/n₁n₂ + d²/2(n₁ + n₂)).
+func CohensDTwo(x, y []float64) EffectSize {
+	n1, n2 := float64(len(x)), float64(len(y))
+	d := (Mean(x) - Mean(y)) / pooledSD(x, y)
+	return normalEffect("Cohen's d", "d", d, math.Sqrt((n1+n2)/(n1*n2)+d*d/(2*(n1+n2))), dLabel)
+}
+
+// HedgesG returns Hedges' g of two samples, Cohen's d corrected for
+// its upward bias in small samples by J = 1 − 3/(4(n₁ + n₂) − 9).
+func HedgesG(x, y []float64) EffectSize {
+	d := CohensDTwo(x, y)
+	j := 1 - 3/(4*float64(len(x)+len(y))-9)
+	d.Name, d.Symbol = "Hedges' g", "g"
+	d.Value, d.Lower, d.Upper = j*d.Value, j*d.Lower, j*d.Upper
+	d.Magnitude = dLabel(d.Value)
+	return d
+}
+
+// GlassDelta returns Glass's Δ, the difference of the means of x and
+// y in standard deviations of y, the control group. It suits groups
+// whose spreads differ, when the treatment may change the spread.
+func GlassDelta(x, y []float64) EffectSize {
+	n1, n2 := float64(len(x)), float64(len(y))
+	d := (Mean(x) - Mean(y)) / StdDev(y)
+	return normalEffect("Glass's Δ", "Δ", d, math.Sqrt((n1+n2)/(n1*n2)+d*d/(2*(n2-1))), dLabel)
+}
+
+// pooledSD returns the pooled standard deviation of two samples.
+func pooledSD(x, y []float64) float64 {
+	n1, n2 := float64(len(x)), float64(len(y))
+	return math.Sqrt(((n1-1)*Variance(x) + (n2-1)*Variance(y)) / (n1 + n2 - 2))
+}
+
+// normalEffect returns the effect v with the interval v ± z se.
+func normalEffect(name, symbol string, v, se float64, label func(float64) string) EffectSize {
+	h := z(EffectLevel) * se
+	return EffectSize{Name: name, Symbol: symbol, Value: v, Lower: v - h, Upper: v + h, Level: EffectLevel, Magnitude: label(v)}
+}
+
+// CorrelationEffect returns the correlation r of n pairs as an effect
+// size, with the interval of Fisher's z transformation,
+// tanh(atanh r ± z/√(n − 3)).
+func CorrelationEffect(r float64, n int) EffectSize {
+	return fisherEffect("correlation", "r", r, 1/math.Sqrt(float64(n-3)))
+}
+
+// fisherEffect returns the correlation-like effect r with the interval
+// tanh(atanh r ± z se).
+func fisherEffect(name, symbol string, r, se float64) EffectSize {
+	e := EffectSize{Name: name, Symbol: symbol, Value: r, Lower: math.NaN(), Upper: math.NaN(), Level: EffectLevel, Magnitude: rLabel(r)}
+	if se > 0 && !math.IsInf(se, 0) {
+		h := z(EffectLevel) * se
+		e.Lower, e.Upper = math.Tanh(math.Atanh(r)-h), math.Tanh(math.Atanh(r)+h)
+	}
+	return e
+}
+
+// RankBiserial returns the rank-biserial correlation of two samples,
+// the probability that a value of x exceeds one of y minus the
+// probability of the reverse, 2U/n₁n₂ − 1 with U the Mann–Whitney
+// count of x above y, ties counting half. It is the effect size of a
+// rank test and is unaffected by outliers. The interval is Fisher's,
+// with standard error √((n₁ + n₂ + 1)/3n₁n₂).
+func RankBiserial(x, y []float64) EffectSize {
+	var u float64
+	for _, a := range x {
+		for _, b := range y {
+			switch {
+			case a > b:
+				u++
+			case a == b:
+				u += 0.5
+			}
+		}
+	}
+	n1, n2 := float64(len(x)), float64(len(y))
+	return fisherEffect("rank-biserial correlation", "r_rb", 2*u/(n1*n2)-1, math.Sqrt((n1+n2+1)/(3*n1*n2)))
+}
+
+// anova returns the between-group and within-group sums of squares of
+// samples, with their degrees of freedom.
+func anova(samples [][]float64) (between, within, df1, df2, n float64) {
+	var all []float64
+	for _, s := range samples {
+		all = append(all, s...)
+	}
+	m := Mean(all)
+	for _, s := range samples {
+		ms := Mean(s)
+		between += float64(len(s)) * (ms - m) * (ms - m)
+		within += Variance(s) * float64(len(s)-1)
+	}
+	n = float64(len(all))
+	return between, within, float64(len(samples) - 1), n - float64(len(samples)), n
+}
+
+// EtaSquared returns η², the share of the variance of the pooled
+// samples explained by the groups, SS_between/SS_total. It overstates
+// the share in the population; OmegaSquared corrects it. The interval
+// inverts the noncentral F distribution of the ANOVA F statistic for
+// its noncentrality λ, and reports λ/(λ + N).
+func EtaSquared(samples [][]float64) EffectSize {
+	b, w, df1, df2, n := anova(samples)
+	e := EffectSize{Name: "eta squared", Symbol: "η²", Value: b / (b + w), Level: EffectLevel}
+	e.Lower, e.Upper = varianceExplained(b/df1/(w/df2), df1, df2, n)
+	e.Magnitude = magnitude(e.Value, 0.01, 0.06, 0.14)
+	return e
+}
+
+// OmegaSquared returns ω², the less biased estimate of the share of
+// the variance explained by the groups, (SS_between − df₁ MS_within) /
+// (SS_total + MS_within), which may be negative. Its interval, for the
+// same population share as η², is that of EtaSquared.
+func OmegaSquared(samples [][]float64) EffectSize {
+	b, w, df1, df2, n := anova(samples)
+	ms := w / df2
+	e := EffectSize{Name: "omega squared", Symbol: "ω²", Value: (b - df1*ms) / (b + w + ms), Level: EffectLevel}
+	e.Lower, e.Upper = varianceExplained(b/df1/ms, df1, df2, n)
+	e.Magnitude = magnitude(max(e.Value, 0), 0.01, 0.06, 0.14)
+	return e
+}
+
+// varianceExplained returns the confidence interval of the share of
+// variance explained, λ/(λ + n), from the observed F statistic f.
+func varianceExplained(f, df1, df2, n float64) (lo, hi float64) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return math.NaN(), math.NaN()
+	}
+	a := (1 - EffectLevel) / 2
+	sf := func(l float64) float64 { return fSurvival(f, df1, df2, l) }
+	ll, lu := noncentrality(sf, a), noncentrality(sf, 1-a)
+	return ll / (ll + n), lu / (lu + n)
+}
+
+// noncentrality returns the noncentrality λ at which the survival
+// function sf of the observed statistic, increasing in λ, reaches p,
+// or 0 if it already exceeds p at λ = 0.
+func noncentrality(sf func(lambda float64) float64, p float64) float64 {
+	if sf(0) >= p {
+		return 0
+	}
+	lo, hi := 0.0, 1.0
+	for sf(hi) < p {
+		lo, hi = hi, 2*hi
+		if hi > 1e7 {
+			return math.Inf(1)
+		}
+	}
+	for range 60 {
+		m := (lo + hi) / 2
+		if sf(m) < p {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// chiSquareSurvival returns P(X > x) for the noncentral chi-square
+// distribution with df degrees of freedom and noncentrality lambda, a
+// Poisson(λ/2) mixture of central chi-square distributions with df +
+// 2j degrees of freedom.
+func chiSquareSurvival(x, df, lambda float64) float64 {
+	mu := lambda / 2
+	var sum, weights float64
+	for j := 0.0; ; j++ {
+		lg, _ := math.Lgamma(j + 1)
+		w := math.Exp(-mu + j*math.Log(mu) - lg)
+		if mu == 0 {
+			w = 1
+		}
+		sum += w * dist.ChiSquare{DF: df + 2*j}.Survival(x)
+		weights += w
+		if 1-weights < 1e-14 || j > mu+40*math.Sqrt(mu)+40 {
+			break
+		}
+	}
+	return min(1, sum)
+}
+
+// tableTotals returns the row, column and grand totals of a table of
+// counts.
+func tableTotals(table [][]float64) (rows, cols []float64, n float64) {
+	rows = make([]float64, len(table))
+	cols = make([]float64, len(table[0]))
+	for i, row := range table {
+		for j, v := range row {
+			rows[i] += v
+			cols[j] += v
+			n += v
+		}
+	}
+	return rows, cols, n
+}
+
+// CramersV returns Cramér's V of a contingency table of counts,
+// √(χ²/(n (k − 1))) with k the smaller of its numbers of rows and
+// columns: 0 for independence, 1 for a perfect association. Its
+// magnitude thresholds are Cohen's for w, 0.1, 0.3 and 0.5, divided by
+// √(k − 1). The interval inverts the noncentral chi-square
+// distribution for the noncentrality λ and reports √(λ/(n (k − 1))).
+func CramersV(table [][]float64) EffectSize {
+	chi2, df, n := chiSquare(table)
+	k := float64(min(len(table), len(table[0])))
+	v := math.Sqrt(chi2 / (n * (k - 1)))
+	e := EffectSize{Name: "Cramér's V", Symbol: "V", Value: v, Lower: math.NaN(), Upper: math.NaN(), Level: EffectLevel}
+	s := math.Sqrt(k - 1)
+	e.Magnitude = magnitude(v, 0.1/s, 0.3/s, 0.5/s)
+	if !math.IsNaN(chi2) {
+		a := (1 - EffectLevel) / 2
+		sf := func(l float64) float64 { return chiSquareSurvival(chi2, df, l) }
+		ll, lu := noncentrality(sf, a), noncentrality(sf, 1-a)
+		e.Lower, e.Upper = min(1, math.Sqrt(ll/(n*(k-1)))), min(1, math.Sqrt(lu/(n*(k-1))))
+	}
+	return e
+}
+
+// chiSquare returns Pearson's chi-square statistic of a table of
+// counts, its degrees of freedom and the total count; the statistic is
+// NaN if a row or column is empty.
+func chiSquare(table [][]float64) (chi2, df, n float64) {
+	rows, cols, n := tableTotals(table)
+	for i, row := range table {
+		for j, o := range row {
+			e := rows[i] * cols[j] / n
+			if e == 0 {
+				return math.NaN(), 0, n
+			}
+			chi2 += (o - e) * (o - e) / e
+		}
+	}
+	return chi2, float64((len(rows) - 1) * (len(cols) - 1)), n
+}
+
+// Phi returns the φ coefficient of a 2 × 2 table of counts
+// [[a, b], [c, d]], (ad − bc)/√(row and column totals), the
+// correlation of the two binary variables, with Fisher's interval. It
+// is NaN for other tables.
+func Phi(table [][]float64) EffectSize {
+	if len(table) != 2 || len(table[0]) != 2 {
+		return EffectSize{Name: "phi", Symbol: "φ", Value: math.NaN(), Lower: math.NaN(), Upper: math.NaN(), Level: EffectLevel}
+	}
+	rows, cols, n := tableTotals(table)
+	phi := (table[0][0]*table[1][1] - table[0][1]*table[1][0]) / math.Sqrt(rows[0]*rows[1]*cols[0]*cols[1])
+	return fisherEffect("phi", "φ", phi, 1/math.Sqrt(n-3))
+}
+
+// OddsRatio returns the odds ratio ad/bc of a 2 × 2 table of counts
+// [[a, b], [c, d]], the odds of the first column in the first row over
+// those in the second, with the interval exp(ln OR ± z √(1/a + 1/b +
+// 1/c + 1/d)). With a zero count, 0.5 is added to every cell. The
+// magnitude thresholds, on OR or 1/OR, are those of Chen, Cohen and
+// Chen (2010), 1.68, 3.47 and 6.71. It is NaN for other tables.
+func OddsRatio(table [][]float64) EffectSize {
+	e := EffectSize{Name: "odds ratio", Symbol: "OR", Value: math.NaN(), Lower: math.NaN(), Upper: math.NaN(), Level: EffectLevel}
+	if len(table) != 2 || len(table[0]) != 2 {
+		return e
+	}
+	a, b, c, d := table[0][0], table[0][1], table[1][0], table[1][1]
+	if a == 0 || b == 0 || c == 0 || d == 0 {
+		a, b, c, d = a+0.5, b+0.5, c+0.5, d+0.5
+	}
+	l := math.Log(a * d / (b * c))
+	h := z(EffectLevel) * math.Sqrt(1/a+1/b+1/c+1/d)
+	e.Value, e.Lower, e.Upper = math.Exp(l), math.Exp(l-h), math.Exp(l+h)
+	e.Magnitude = magnitude(math.Exp(math.Abs(l)), 1.68, 3.47, 6.71)
+	return e
+}
@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// The reference intervals in this file were computed independently of
+// the Poisson mixtures of the package: the noncentral F by integrating
+// the noncentral chi-square of the numerator over the density of the
+// denominator, and the noncentral chi-square with 2 degrees of freedom
+// as (Z₁ + √λ)² + Z₂², integrating over Z₁.
+
+// TestVarianceExplained checks the 95% interval of the share of
+// variance explained, λ/(λ + N), for F statistics of one-way ANOVAs,
+// including one whose p-value is above 0.025, where the lower bound is
+// 0.
+func TestVarianceExplained(t *testing.T) {
+	for _, c := range []struct {
+		f, df1, df2, n float64
+		lo, hi         float64
+	}{
+		{4, 2, 27, 30, 0, 0.4316102843},
+		{10, 4, 45, 50, 0.2068969781, 0.5886167947},
+		{3, 2, 57, 60, 0, 0.2353572872},
+	} {
+		lo, hi := varianceExplained(c.f, c.df1, c.df2, c.n)
+		if math.Abs(lo-c.lo) > 1e-6 || math.Abs(hi-c.hi) > 1e-6 {
+			t.Errorf("F(%g, %g) = %g, N = %g: [%.10f, %.10f], want [%.10f, %.10f]", c.df1, c.df2, c.f, c.n, lo, hi, c.lo, c.hi)
+		}
+	}
+}
+
+// TestCramersV checks Cramér's V of party identification by gender in
+// the 2000 General Social Survey, whose χ² = 30.07 on 2 degrees of
+// freedom is given in Table 2.5 of Agresti, An Introduction to
+// Categorical Data Analysis (2nd ed., 2007).
+func TestCramersV(t *testing.T) {
+	e := CramersV([][]float64{{762, 327, 468}, {484, 239, 477}})
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"V", e.Value, 0.1044358024},
+		{"lower", e.Lower, 0.0649075970},
+		{"upper", e.Upper, 0.1402640638},
+	} {
+		if math.Abs(c.got-c.want) > 1e-6 {
+			t.Errorf("%s = %.10f, want %.10f", c.name, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/mayura-andrew/applied-statistics/frame"
+)
+
+// The functions in this file take their data from a frame.Frame by
+// column name, for the functions of the package that take slices.
+
+// Column returns the values of the numeric column name of f that a
+// statistic is computed from under p, and false if the statistic is
+// missing instead, as Policy.Apply.
+func Column(f *frame.Frame, name string, p Policy) ([]float64, bool, error) {
+	x, err := f.Floats(name)
+	if err != nil {
+		return nil, false, err
+	}
+	return p.Apply(x)
+}
+
+// WeightedColumn is Column for the numeric column name of f weighted by
+// the numeric column weights, returning the values and their weights. A
+// value with a missing weight counts as missing.
+func WeightedColumn(f *frame.Frame, name, weights string, p Policy) (x, w []float64, ok bool, err error) {
+	all, err := f.Floats(name)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	allW, err := f.Floats(weights)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	paired := slices.Clone(all)
+	for i, v := range allW {
+		if math.IsNaN(v) {
+			paired[i] = math.NaN()
+		}
+	}
+	if _, ok, err := p.Apply(paired); !ok || err != nil {
+		return nil, nil, ok, err
+	}
+	for i, v := range paired {
+		if !math.IsNaN(v) {
+			x = append(x, v)
+			w = append(w, allW[i])
+		}
+	}
+	return x, w, true, nil
+}
+
+// Groups splits the numeric column name of f by the values of the
+// columns by, in the order of frame.Frame.GroupBy, leaving out missing
+// values and the groups they leave empty. The keys are the values of by
+// joined by ", ".
+func Groups(f *frame.Frame, name string, by ...string) (keys []string, samples [][]float64, err error) {
+	if _, err := f.Floats(name); err != nil {
+		return nil, nil, err
+	}
+	gs, err := f.GroupBy(by...)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, g := range gs {
+		x, err := g.Floats(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if x = Present(x); len(x) > 0 {
+			keys = append(keys, strings.Join(g.Key, ", "))
+			samples = append(samples, x)
+		}
+	}
+	return keys, samples, nil
+}
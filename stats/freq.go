@@ -0,0 +1,71 @@
+package stats
+
+import "math"
+
+// Class is one class of a grouped frequency table, holding the values
+// in [Lower, Upper); the last class also holds its upper limit. Weight
+// is the sum of the weights of the values, equal to Count unless the
+// table is weighted.
+type Class struct {
+	Lower, Upper float64
+	Count        int
+	Weight       float64
+}
+
+// Midpoint returns the class mark, the midpoint of the class.
+func (c Class) Midpoint() float64 {
+	return (c.Lower + c.Upper) / 2
+}
+
+// SqrtClasses returns the square-root rule's number of classes for a
+// sample of size n, round(√n), at least 1.
+func SqrtClasses(n int) int {
+	return max(int(math.Round(math.Sqrt(float64(n)))), 1)
+}
+
+// FrequencyTable groups x into k classes of equal width starting at the
+// minimum, the width being the range divided by k rounded up to a whole
+// number of the data's resolution unit (1 for integer data).
+func FrequencyTable(x []float64, k int) []Class {
+	if len(x) == 0 || k < 1 {
+		return nil
+	}
+	s := Sorted(x)
+	lo, hi := s[0], s[len(s)-1]
+	unit := resolution(s)
+	width := math.Ceil((hi-lo)/float64(k)/unit-1e-9) * unit
+	if width == 0 {
+		width = unit
+	}
+	classes := make([]Class, k)
+	for i := range classes {
+		classes[i].Lower = lo + float64(i)*width
+		classes[i].Upper = lo + float64(i+1)*width
+	}
+	for _, v := range s {
+		i := min(int((v-lo)/width+1e-9), k-1)
+		classes[i].Count++
+		classes[i].Weight++
+	}
+	return classes
+}
+
+// resolution returns the largest power of ten, at most 1, of which
+// every value of the sorted sample s is a multiple.
+func resolution(s []float64) float64 {
+	unit := 1.0
+	for range 6 {
+		exact := true
+		for _, v := range s {
+			if math.Abs(v/unit-math.Round(v/unit)) > 1e-9 {
+				exact = false
+				break
+			}
+		}
+		if exact {
+			break
+		}
+		unit /= 10
+	}
+	return unit
+}
@@ -0,0 +1,393 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Kernel is a smoothing kernel for kernel density estimation.
+//
+// Every kernel is scaled to unit variance, so a KDE's Bandwidth is the
+// standard deviation of the kernel whichever kernel is used (as in R's
+// density()), and bandwidth selectors derived for the Gaussian kernel
+// carry over unchanged.
+type Kernel int
+
+const (
+	Gaussian Kernel = iota
+	Epanechnikov
+	Triangular
+	Rectangular
+	Biweight
+	Triweight
+	Cosine
+)
+
+var kernelNames = [...]string{
+	Gaussian:     "gaussian",
+	Epanechnikov: "epanechnikov",
+	Triangular:   "triangular",
+	Rectangular:  "rectangular",
+	Biweight:     "biweight",
+	Triweight:    "triweight",
+	Cosine:       "cosine",
+}
+
+// String returns the lower-case name of the kernel.
+func (k Kernel) String() string {
+	if k < 0 || int(k) >= len(kernelNames) {
+		return fmt.Sprintf("Kernel(%d)", int(k))
+	}
+	return kernelNames[k]
+}
+
+// ParseKernel returns the kernel with the given name.
+func ParseKernel(name string) (Kernel, error) {
+	for k, n := range kernelNames {
+		if n == name {
+			return Kernel(k), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown kernel %q", name)
+}
+
+// radius returns the half-width of the kernel's support in units of its
+// standard deviation; the Gaussian kernel has unbounded support and is
+// truncated at 8 standard deviations.
+func (k Kernel) radius() float64 {
+	switch k {
+	case Epanechnikov:
+		return math.Sqrt(5)
+	case Triangular:
+		return math.Sqrt(6)
+	case Rectangular:
+		return math.Sqrt(3)
+	case Biweight:
+		return math.Sqrt(7)
+	case Triweight:
+		return 3
+	case Cosine:
+		return 1 / math.Sqrt(1.0/3-2/(math.Pi*math.Pi))
+	}
+	return 8
+}
+
+// Eval returns the unit-variance kernel evaluated at u.
+func (k Kernel) Eval(u float64) float64 {
+	if k == Gaussian {
+		return math.Exp(-0.5*u*u) / math.Sqrt(2*math.Pi)
+	}
+	// Evaluate the kernel on its canonical support [-1, 1] and rescale.
+	a := k.radius()
+	t := math.Abs(u) / a
+	if t > 1 {
+		return 0
+	}
+	var v float64
+	switch k {
+	case Epanechnikov:
+		v = 0.75 * (1 - t*t)
+	case Triangular:
+		v = 1 - t
+	case Rectangular:
+		v = 0.5
+	case Biweight:
+		v = 15.0 / 16 * (1 - t*t) * (1 - t*t)
+	case Triweight:
+		v = 35.0 / 32 * (1 - t*t) * (1 - t*t) * (1 - t*t)
+	case Cosine:
+		v = (1 + math.Cos(math.Pi*t)) / 2
+	}
+	return v / a
+}
+
+// KDE is a kernel density estimate of a univariate sample.
+type KDE struct {
+	// Data is the sorted sample.
+	Data []float64
+
+	// Kernel is the smoothing kernel. The zero value is Gaussian.
+	Kernel Kernel
+
+	// Bandwidth is the kernel standard deviation h.
+	Bandwidth float64
+}
+
+// NewKDE returns the kernel density estimate of x using a Gaussian
+// kernel and Silverman's rule-of-thumb bandwidth, which is NaN for an
+// empty sample. The Kernel and Bandwidth fields may be changed
+// afterwards, e.g.
+//
+//	k := stats.NewKDE(x)
+//	k.Kernel = stats.Epanechnikov
+//	k.Bandwidth = stats.Scott(x)
+func NewKDE(x []float64) *KDE {
+	s := Sorted(x)
+	return &KDE{Data: s, Bandwidth: silverman(s)}
+}
+
+// Density returns the estimated density at x.
+func (k *KDE) Density(x float64) float64 {
+	h := k.Bandwidth
+	lo, hi := x-k.Kernel.radius()*h, x+k.Kernel.radius()*h
+	var sum float64
+	for _, v := range k.Data[lowerBound(k.Data, lo):] {
+		if v > hi {
+			break
+		}
+		sum += k.Kernel.Eval((x - v) / h)
+	}
+	return sum / (float64(len(k.Data)) * h)
+}
+
+// Range returns the interval over which the density is non-negligible:
+// the sample range extended by cut bandwidths on either side. It is
+// NaN for an empty sample.
+func (k *KDE) Range(cut float64) (lo, hi float64) {
+	if len(k.Data) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	pad := cut * k.Bandwidth
+	return k.Data[0] - pad, k.Data[len(k.Data)-1] + pad
+}
+
+// Grid evaluates the density at n equally spaced points across
+// k.Range(3).
+func (k *KDE) Grid(n int) (xs, ds []float64) {
+	lo, hi := k.Range(3)
+	xs = make([]float64, n)
+	ds = make([]float64, n)
+	for i := range xs {
+		xs[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+		ds[i] = k.Density(xs[i])
+	}
+	return xs, ds
+}
+
+// Modes returns the locations of the local maxima of the estimated
+// density in increasing order. A unimodal sample yields a single mode;
+// more than one mode is evidence of multimodality at this bandwidth.
+// Ripples whose dip is under 1% of the highest density, which bounded
+// kernels produce on evenly spaced data, are not counted as modes.
+func (k *KDE) Modes() []float64 {
+	const n = 512
+	xs, ds := k.Grid(n)
+	step := xs[1] - xs[0]
+
+	// Collect the grid peaks and the lowest density between each pair
+	// of neighbouring peaks.
+	var peaks []int
+	var dips []float64
+	var top float64
+	low := math.Inf(1)
+	for i := 1; i < n-1; i++ {
+		low = math.Min(low, ds[i])
+		// Plateaus count once, at their left edge.
+		if ds[i] > ds[i-1] && ds[i] >= ds[i+1] {
+			if len(peaks) > 0 {
+				dips = append(dips, low)
+			}
+			peaks = append(peaks, i)
+			low = math.Inf(1)
+			top = math.Max(top, ds[i])
+		}
+	}
+
+	// Merge neighbouring peaks separated by an insignificant dip,
+	// keeping the higher one.
+	tol := 0.01 * top
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(dips); i++ {
+			a, b := peaks[i], peaks[i+1]
+			if math.Min(ds[a], ds[b])-dips[i] >= tol {
+				continue
+			}
+			if ds[b] > ds[a] {
+				peaks[i] = b
+			}
+			peaks = append(peaks[:i+1], peaks[i+2:]...)
+			dips = append(dips[:i], dips[i+1:]...)
+			merged = true
+			break
+		}
+	}
+
+	modes := make([]float64, len(peaks))
+	for j, i := range peaks {
+		// Refine with the vertex of the parabola through the three
+		// grid points.
+		modes[j] = xs[i]
+		if den := ds[i-1] - 2*ds[i] + ds[i+1]; den != 0 {
+			modes[j] += 0.5 * step * (ds[i-1] - ds[i+1]) / den
+		}
+	}
+	return modes
+}
+
+// lowerBound returns the index of the first element of the sorted slice
+// s that is not less than x.
+func lowerBound(s []float64, x float64) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		m := (lo + hi) / 2
+		if s[m] < x {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return lo
+}
+
+// spread returns min(s, IQR/1.349), the robust scale estimate used by
+// the rule-of-thumb bandwidths, for the sorted sample s; 1.349 is the
+// IQR of the standard normal. It is NaN for an empty sample.
+func spread(s []float64) float64 {
+	if len(s) == 0 {
+		return math.NaN()
+	}
+	sd := StdDev(s)
+	iqr := (Quantile(s, 0.75) - Quantile(s, 0.25)) / 1.349
+	switch {
+	case iqr > 0 && (iqr < sd || math.IsNaN(sd)):
+		return iqr
+	case sd > 0:
+		return sd
+	case s[0] != 0:
+		return math.Abs(s[0])
+	}
+	return 1
+}
+
+// silverman returns Silverman's rule-of-thumb bandwidth for the sorted
+// sample s.
+func silverman(s []float64) float64 {
+	return 0.9 * spread(s) * math.Pow(float64(len(s)), -0.2)
+}
+
+// Silverman returns Silverman's rule-of-thumb bandwidth
+// 0.9 · min(s, IQR/1.349) · n^(-1/5) (R's bw.nrd0). It is NaN for an
+// empty sample.
+func Silverman(x []float64) float64 {
+	return silverman(Sorted(x))
+}
+
+// Scott returns Scott's rule-of-thumb bandwidth
+// 1.06 · min(s, IQR/1.349) · n^(-1/5) (R's bw.nrd). It is NaN for an
+// empty sample.
+func Scott(x []float64) float64 {
+	return 1.06 * spread(Sorted(x)) * math.Pow(float64(len(x)), -0.2)
+}
+
+// SheatherJones returns the Sheather–Jones "solve-the-equation" plug-in
+// bandwidth (R's bw.SJ), which adapts to multimodal and skewed samples
+// better than the rules of thumb. The pairwise sums are computed
+// exactly, so the cost is O(n²) per iteration.
+func SheatherJones(x []float64) (float64, error) {
+	s := Sorted(x)
+	n := float64(len(s))
+	if len(s) < 2 {
+		return 0, errors.New("Sheather–Jones bandwidth needs at least two values")
+	}
+
+	// Squared pairwise differences.
+	d2 := make([]float64, 0, len(s)*(len(s)-1)/2)
+	for i := range s {
+		for j := i + 1; j < len(s); j++ {
+			d := s[j] - s[i]
+			d2 = append(d2, d*d)
+		}
+	}
+	// phi4 and phi6 estimate the integrated squared second and third
+	// derivatives of the density with a Gaussian pilot bandwidth h.
+	phi4 := func(h float64) float64 {
+		var sum float64
+		for _, v := range d2 {
+			u := v / (h * h)
+			sum += math.Exp(-u/2) * (u*u - 6*u + 3)
+		}
+		sum = 2*sum + 3*n
+		return sum / (n * (n - 1) * math.Pow(h, 5) * math.Sqrt(2*math.Pi))
+	}
+	phi6 := func(h float64) float64 {
+		var sum float64
+		for _, v := range d2 {
+			u := v / (h * h)
+			sum += math.Exp(-u/2) * (u*u*u - 15*u*u + 45*u - 15)
+		}
+		sum = 2*sum - 15*n
+		return sum / (n * (n - 1) * math.Pow(h, 7) * math.Sqrt(2*math.Pi))
+	}
+
+	scale := spread(s)
+	a := 1.24 * scale * math.Pow(n, -1.0/7)
+	b := 1.23 * scale * math.Pow(n, -1.0/9)
+	c1 := 1 / (2 * math.Sqrt(math.Pi) * n)
+	td := -phi6(b)
+	if !(td > 0) || math.IsInf(td, 0) {
+		return 0, errors.New("sample is too sparse for the Sheather–Jones bandwidth")
+	}
+	alph2 := 1.357 * math.Pow(phi4(a)/td, 1.0/7)
+	if math.IsNaN(alph2) || math.IsInf(alph2, 0) {
+		return 0, errors.New("sample is too sparse for the Sheather–Jones bandwidth")
+	}
+	f := func(h float64) float64 {
+		return math.Pow(c1/phi4(alph2*math.Pow(h, 5.0/7)), 0.2) - h
+	}
+
+	hmax := 1.144 * scale * math.Pow(n, -0.2)
+	lower, upper := 0.1*hmax, hmax
+	for try := 1; f(lower)*f(upper) > 0; try++ {
+		if try > 99 {
+			return 0, errors.New("no Sheather–Jones bandwidth in the search range")
+		}
+		if try%2 == 1 {
+			upper *= 1.2
+		} else {
+			lower /= 1.2
+		}
+	}
+	return bisect(f, lower, upper, 1e-6*hmax), nil
+}
+
+// bisect returns a root of f in [lo, hi], where f(lo) and f(hi) have
+// opposite signs, to within tol.
+func bisect(f func(float64) float64, lo, hi, tol float64) float64 {
+	flo := f(lo)
+	for hi-lo > tol {
+		mid := (lo + hi) / 2
+		fm := f(mid)
+		if fm == 0 {
+			return mid
+		}
+		if (fm < 0) == (flo < 0) {
+			lo, flo = mid, fm
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// SelectBandwidth returns the bandwidth for x chosen by the named
+// selector: "silverman" (or "nrd0"), "scott" (or "nrd"), "sj" (or
+// "sheather-jones"). Any other name is parsed as a fixed bandwidth.
+func SelectBandwidth(name string, x []float64) (float64, error) {
+	switch strings.ToLower(name) {
+	case "silverman", "nrd0":
+		return Silverman(x), nil
+	case "scott", "nrd":
+		return Scott(x), nil
+	case "sj", "sheather-jones":
+		return SheatherJones(x)
+	}
+	h, err := strconv.ParseFloat(name, 64)
+	if err != nil || !(h > 0) {
+		return 0, fmt.Errorf("unknown bandwidth selector %q", name)
+	}
+	return h, nil
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// TestBandwidths checks the rules of thumb on 1, ..., 10, whose standard
+// deviation is below IQR/1.349, against R's bw.nrd0 and bw.nrd, and the
+// Gaussian density estimate at the middle of the sample.
+func TestBandwidths(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	k := NewKDE(x)
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Silverman", Silverman(x), 1.719286404692283},
+		{"Scott", Scott(x), 2.024937321082022},
+		{"NewKDE bandwidth", k.Bandwidth, 1.719286404692283},
+		{"density at 5.5", k.Density(5.5), 0.0996815678211498},
+	} {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("%s = %.15g, want %.15g", c.name, c.got, c.want)
+		}
+	}
+	if h := Silverman(nil); !math.IsNaN(h) {
+		t.Errorf("Silverman of no data = %g, want NaN", h)
+	}
+}
+
+// TestKernels checks that every kernel is a density with mean 0 and
+// variance 1, by the midpoint rule over its support.
+func TestKernels(t *testing.T) {
+	for k := range Kernel(len(kernelNames)) {
+		a := k.radius()
+		const n = 200000
+		h := 2 * a / n
+		var mass, variance float64
+		for i := range n {
+			u := -a + (float64(i)+0.5)*h
+			mass += h * k.Eval(u)
+			variance += h * u * u * k.Eval(u)
+		}
+		if math.Abs(mass-1) > 1e-6 || math.Abs(variance-1) > 1e-6 {
+			t.Errorf("%v kernel: mass %.8f and variance %.8f, want 1 and 1", k, mass, variance)
+		}
+		if p, err := ParseKernel(k.String()); err != nil || p != k {
+			t.Errorf("ParseKernel(%q) = %v, %v", k.String(), p, err)
+		}
+	}
+}
+
+// TestModes checks that two well separated clusters give two modes,
+// near their centres: the tails of the other cluster pull each mode in
+// a little.
+func TestModes(t *testing.T) {
+	var x []float64
+	for _, c := range []float64{0, 10} {
+		for _, d := range []float64{-1, -0.5, 0, 0.5, 1} {
+			x = append(x, c+d)
+		}
+	}
+	modes := NewKDE(x).Modes()
+	if len(modes) != 2 || math.Abs(modes[0]) > 0.1 || math.Abs(modes[1]-10) > 0.1 {
+		t.Errorf("modes %v, want about 0 and 10", modes)
+	}
+}
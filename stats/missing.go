@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// Missing values are NaN throughout the package. The functions that
+// compute statistics expect data without them; Policy and Impute decide
+// what happens to them first.
+
+// Policy says how a statistic treats missing values.
+type Policy int
+
+// The missing-value policies.
+const (
+	// Omit computes the statistic from the values present.
+	Omit Policy = iota
+	// Propagate makes the statistic missing if any value is.
+	Propagate
+	// Refuse fails if any value is missing.
+	Refuse
+)
+
+var policyNames = [...]string{Omit: "omit", Propagate: "propagate", Refuse: "error"}
+
+func (p Policy) String() string {
+	return policyNames[p]
+}
+
+// ParsePolicy returns the policy named name: omit, propagate or error.
+func ParsePolicy(name string) (Policy, error) {
+	for p, n := range policyNames {
+		if n == name {
+			return Policy(p), nil
+		}
+	}
+	return Omit, fmt.Errorf("unknown missing-value policy %q (want omit, propagate or error)", name)
+}
+
+// ErrMissing is returned under the Refuse policy for data with missing
+// values.
+var ErrMissing = errors.New("missing values")
+
+// CountMissing returns the number of missing values of x.
+func CountMissing(x []float64) int {
+	n := 0
+	for _, v := range x {
+		if math.IsNaN(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// Present returns the values of x that are not missing.
+func Present(x []float64) []float64 {
+	return slices.DeleteFunc(slices.Clone(x), math.IsNaN)
+}
+
+// Apply returns the values of x to compute a statistic from under p,
+// and false if the statistic is missing instead.
+func (p Policy) Apply(x []float64) ([]float64, bool, error) {
+	n := CountMissing(x)
+	switch {
+	case n == 0:
+		return x, true, nil
+	case p == Propagate:
+		return nil, false, nil
+	case p == Refuse:
+		return nil, false, fmt.Errorf("%w: %d of %d", ErrMissing, n, len(x))
+	}
+	return Present(x), true, nil
+}
+
+// Imputation is a method of filling in missing values.
+type Imputation string
+
+// The imputation methods.
+const (
+	// ImputeMean fills in the mean of the values present.
+	ImputeMean Imputation = "mean"
+	// ImputeMedian fills in their median.
+	ImputeMedian Imputation = "median"
+	// ImputeMode fills in their mode, the smallest if there are several.
+	ImputeMode Imputation = "mode"
+	// ImputeLOCF carries the last observation forward; missing values
+	// before the first observation take its value.
+	ImputeLOCF Imputation = "locf"
+	// ImputeHotDeck fills in values drawn at random from those present.
+	ImputeHotDeck Imputation = "hotdeck"
+)
+
+// Imputations are the imputation methods, in the order of their
+// documentation.
+var Imputations = []Imputation{ImputeMean, ImputeMedian, ImputeMode, ImputeLOCF, ImputeHotDeck}
+
+// ParseImputation returns the imputation method named name.
+func ParseImputation(name string) (Imputation, error) {
+	if slices.Contains(Imputations, Imputation(name)) {
+		return Imputation(name), nil
+	}
+	return "", fmt.Errorf("unknown imputation %q (want mean, median, mode, locf or hotdeck)", name)
+}
+
+// Impute returns a copy of x with the missing values filled in by m,
+// and how many were. Hot-deck draws from r. It fails if no value is
+// present, or for mode imputation if every value is distinct.
+func Impute(x []float64, m Imputation, r *rand.Rand) ([]float64, int, error) {
+	n := CountMissing(x)
+	if n == 0 {
+		return x, 0, nil
+	}
+	present := Present(x)
+	if len(present) == 0 {
+		return nil, 0, fmt.Errorf("cannot impute: every value is missing")
+	}
+	y := slices.Clone(x)
+	fill := func(v float64) {
+		for i := range y {
+			if math.IsNaN(y[i]) {
+				y[i] = v
+			}
+		}
+	}
+	switch m {
+	case ImputeMean:
+		fill(Mean(present))
+	case ImputeMedian:
+		fill(Median(Sorted(present)))
+	case ImputeMode:
+		modes := Modes(present)
+		if len(modes) == 0 {
+			return nil, 0, fmt.Errorf("cannot impute the mode: every value present is distinct")
+		}
+		fill(modes[0])
+	case ImputeLOCF:
+		last := present[0]
+		for i, v := range y {
+			if math.IsNaN(v) {
+				y[i] = last
+			} else {
+				last = v
+			}
+		}
+	case ImputeHotDeck:
+		for i, v := range y {
+			if math.IsNaN(v) {
+				y[i] = present[r.IntN(len(present))]
+			}
+		}
+	default:
+		return nil, 0, fmt.Errorf("unknown imputation %q", m)
+	}
+	return y, n, nil
+}
@@ -0,0 +1,204 @@
+package stats
+
+import "math"
+
+// Accumulator computes the count, extremes, mean and central moments of
+// a stream of values in one pass and constant memory, by the updates of
+// Welford and Terriberry. Accumulators of parts of a stream, filled for
+// example by separate goroutines, combine with Merge (Chan et al.) into
+// that of the whole stream. The zero value is an empty accumulator.
+type Accumulator struct {
+	n          int
+	mean       float64
+	m2, m3, m4 float64 // sums of powers of deviations from the mean
+	lo, hi     float64 // smallest and largest value
+}
+
+// Add adds the value x.
+func (a *Accumulator) Add(x float64) {
+	if a.n == 0 {
+		a.lo, a.hi = x, x
+	}
+	a.lo = min(a.lo, x)
+	a.hi = max(a.hi, x)
+	n1 := float64(a.n)
+	a.n++
+	n := float64(a.n)
+	delta := x - a.mean
+	dn := delta / n
+	dn2 := dn * dn
+	term := delta * dn * n1
+	a.mean += dn
+	a.m4 += term*dn2*(n*n-3*n+3) + 6*dn2*a.m2 - 4*dn*a.m3
+	a.m3 += term*dn*(n-2) - 3*dn*a.m2
+	a.m2 += term
+}
+
+// Merge adds the values added to b.
+func (a *Accumulator) Merge(b *Accumulator) {
+	if b.n == 0 {
+		return
+	}
+	if a.n == 0 {
+		*a = *b
+		return
+	}
+	na, nb := float64(a.n), float64(b.n)
+	n := na + nb
+	delta := b.mean - a.mean
+	d2 := delta * delta
+	m2 := a.m2 + b.m2 + d2*na*nb/n
+	m3 := a.m3 + b.m3 + d2*delta*na*nb*(na-nb)/(n*n) + 3*delta*(na*b.m2-nb*a.m2)/n
+	m4 := a.m4 + b.m4 + d2*d2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*d2*(na*na*b.m2+nb*nb*a.m2)/(n*n) + 4*delta*(na*b.m3-nb*a.m3)/n
+	a.mean += delta * nb / n
+	a.m2, a.m3, a.m4 = m2, m3, m4
+	a.n += b.n
+	a.lo = min(a.lo, b.lo)
+	a.hi = max(a.hi, b.hi)
+}
+
+// N returns the number of values added.
+func (a *Accumulator) N() int {
+	return a.n
+}
+
+// Mean returns the mean, or NaN when empty.
+func (a *Accumulator) Mean() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.mean
+}
+
+// Min returns the smallest value, or NaN when empty.
+func (a *Accumulator) Min() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.lo
+}
+
+// Max returns the largest value, or NaN when empty.
+func (a *Accumulator) Max() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.hi
+}
+
+// Variance returns the sample variance, as Variance does.
+func (a *Accumulator) Variance() float64 {
+	if a.n < 2 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n-1)
+}
+
+// PopVariance returns the population variance, as PopVariance does.
+func (a *Accumulator) PopVariance() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n)
+}
+
+// Skewness returns the moment coefficient of skewness, as Skewness
+// does.
+func (a *Accumulator) Skewness() float64 {
+	n := float64(a.n)
+	return math.Sqrt(n) * a.m3 / math.Pow(a.m2, 1.5)
+}
+
+// Kurtosis returns the excess kurtosis m4 / m2² - 3, with the central
+// moments taken over n; it is 0 for normal data.
+func (a *Accumulator) Kurtosis() float64 {
+	n := float64(a.n)
+	return n*a.m4/(a.m2*a.m2) - 3
+}
+
+// CovAccumulator computes the means, variances and covariance of a
+// stream of pairs in one pass, updating the co-moment Σ(xᵢ - x̄)(yᵢ - ȳ)
+// with the deviations from the old and the new means rather than
+// accumulating raw cross products, whose difference cancels
+// catastrophically. Like Accumulator, it merges. The zero value is
+// empty.
+type CovAccumulator struct {
+	n        int
+	mx, my   float64
+	sxx, syy float64
+	sxy      float64 // co-moment
+}
+
+// Add adds the pair (x, y).
+func (c *CovAccumulator) Add(x, y float64) {
+	c.n++
+	n := float64(c.n)
+	dx := x - c.mx
+	dy := y - c.my
+	c.mx += dx / n
+	c.my += dy / n
+	c.sxx += dx * (x - c.mx)
+	c.syy += dy * (y - c.my)
+	c.sxy += dx * (y - c.my)
+}
+
+// Merge adds the pairs added to d.
+func (c *CovAccumulator) Merge(d *CovAccumulator) {
+	if d.n == 0 {
+		return
+	}
+	if c.n == 0 {
+		*c = *d
+		return
+	}
+	na, nb := float64(c.n), float64(d.n)
+	n := na + nb
+	dx, dy := d.mx-c.mx, d.my-c.my
+	f := na * nb / n
+	c.sxx += d.sxx + dx*dx*f
+	c.syy += d.syy + dy*dy*f
+	c.sxy += d.sxy + dx*dy*f
+	c.mx += dx * nb / n
+	c.my += dy * nb / n
+	c.n += d.n
+}
+
+// N returns the number of pairs added.
+func (c *CovAccumulator) N() int {
+	return c.n
+}
+
+// Means returns the means of x and y.
+func (c *CovAccumulator) Means() (mx, my float64) {
+	if c.n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	return c.mx, c.my
+}
+
+// Covariance returns the sample covariance, as Covariance does.
+func (c *CovAccumulator) Covariance() float64 {
+	if c.n < 2 {
+		return math.NaN()
+	}
+	return c.sxy / float64(c.n-1)
+}
+
+// PopCovariance returns the population covariance, as PopCovariance
+// does.
+func (c *CovAccumulator) PopCovariance() float64 {
+	if c.n == 0 {
+		return math.NaN()
+	}
+	return c.sxy / float64(c.n)
+}
+
+// Correlation returns Pearson's r of the pairs, NaN if either sample is
+// constant.
+func (c *CovAccumulator) Correlation() float64 {
+	if c.sxx <= 0 || c.syy <= 0 {
+		return math.NaN()
+	}
+	return c.sxy / math.Sqrt(c.sxx*c.syy)
+}
@@ -0,0 +1,177 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mayura-andrew/applied-statistics/dist"
+)
+
+// The power functions return the probability that a two-sided test at
+// level alpha rejects the null hypothesis when the standardized effect
+// is as given, for samples of size n (per group, for the tests that
+// compare groups). They follow Cohen's Statistical Power Analysis for
+// the Behavioral Sciences: the effect is d for means, h for
+// proportions, f for one-way ANOVA and r for correlation.
+
+// PowerOneSampleT returns the power of the one-sample (or paired)
+// t-test to detect a mean d standard deviations from the hypothesised
+// one with n observations.
+func PowerOneSampleT(d float64, n int, alpha float64) float64 {
+	if n < 2 {
+		return math.NaN()
+	}
+	return tPower(d*math.Sqrt(float64(n)), float64(n-1), alpha)
+}
+
+// PowerTwoSampleT returns the power of the two-sample t-test with
+// equal variances to detect means d standard deviations apart with n
+// observations in each group.
+func PowerTwoSampleT(d float64, n int, alpha float64) float64 {
+	if n < 2 {
+		return math.NaN()
+	}
+	return tPower(d*math.Sqrt(float64(n)/2), float64(2*n-2), alpha)
+}
+
+// tPower returns the power of the two-sided t-test with df degrees of
+// freedom when the noncentrality is delta. With T = (Z + δ)/S, where
+// S² is χ²(df)/df, P(|T| > c) is the average over S of
+// Φ(δ − cS) + Φ(−δ − cS), integrated by Simpson's rule over the
+// density of S.
+func tPower(delta, df, alpha float64) float64 {
+	c := dist.StudentT{DF: df}.Quantile(1 - alpha/2)
+	z := dist.Normal{Mu: 0, Sigma: 1}
+	// S has mean about 1 and standard deviation about 1/√(2 df).
+	sd := 1 / math.Sqrt(2*df)
+	lo, hi := max(0, 1-12*sd), 1+12*sd
+	lg, _ := math.Lgamma(df / 2)
+	logK := math.Log(2) + df/2*math.Log(df/2) - lg
+	f := func(s float64) float64 {
+		density := 0.0
+		switch {
+		case s > 0:
+			density = math.Exp(logK + (df-1)*math.Log(s) - df*s*s/2)
+		case df == 1:
+			// The density of S, a half-normal, is largest at 0 with
+			// one degree of freedom and vanishes there with more.
+			density = math.Exp(logK)
+		}
+		return density * (z.CDF(delta-c*s) + z.CDF(-delta-c*s))
+	}
+	const m = 2000 // even
+	h := (hi - lo) / m
+	sum := f(lo) + f(hi)
+	for i := 1; i < m; i++ {
+		w := 2.0
+		if i%2 == 1 {
+			w = 4
+		}
+		sum += w * f(lo+float64(i)*h)
+	}
+	return min(1, sum*h/3)
+}
+
+// CohenH returns Cohen's effect size h between the proportions p1 and
+// p2, the difference of their arcsine square roots, 2 asin √p1 −
+// 2 asin √p2, which makes equal values of h equally detectable
+// whatever the proportions.
+func CohenH(p1, p2 float64) float64 {
+	return 2*math.Asin(math.Sqrt(p1)) - 2*math.Asin(math.Sqrt(p2))
+}
+
+// PowerOneProportion returns the power of the test that a proportion
+// is p0 when it is p1, h = CohenH(p1, p0), with n trials, by the normal
+// approximation on the arcsine scale.
+func PowerOneProportion(h float64, n int, alpha float64) float64 {
+	return normalPower(h*math.Sqrt(float64(n)), alpha)
+}
+
+// PowerTwoProportions returns the power of the test that two
+// proportions are equal when they are p1 and p2, h = CohenH(p1, p2),
+// with n trials in each group, by the normal approximation on the
+// arcsine scale.
+func PowerTwoProportions(h float64, n int, alpha float64) float64 {
+	return normalPower(h*math.Sqrt(float64(n)/2), alpha)
+}
+
+// PowerCorrelation returns the power of the test that a correlation is
+// zero when it is r, with n pairs, by Fisher's z transformation.
+func PowerCorrelation(r float64, n int, alpha float64) float64 {
+	if n < 4 {
+		return math.NaN()
+	}
+	return normalPower(math.Atanh(r)*math.Sqrt(float64(n-3)), alpha)
+}
+
+// normalPower returns the power of a two-sided z-test when the
+// statistic has mean delta.
+func normalPower(delta, alpha float64) float64 {
+	z := dist.Normal{Mu: 0, Sigma: 1}
+	c := z.Quantile(1 - alpha/2)
+	return z.CDF(delta-c) + z.CDF(-delta-c)
+}
+
+// PowerANOVA returns the power of the one-way ANOVA F-test across k
+// groups of n observations when the effect size is f, the standard
+// deviation of the group means divided by the common within-group
+// standard deviation. The F statistic is then noncentral with
+// noncentrality λ = k n f².
+func PowerANOVA(f float64, k, n int, alpha float64) float64 {
+	if k < 2 || n < 2 {
+		return math.NaN()
+	}
+	d1, d2 := float64(k-1), float64(k*(n-1))
+	c := dist.F{D1: d1, D2: d2}.Quantile(1 - alpha)
+	return fSurvival(c, d1, d2, float64(k*n)*f*f)
+}
+
+// fSurvival returns P(F > x) for the noncentral F distribution with d1
+// and d2 degrees of freedom and noncentrality lambda, a Poisson(λ/2)
+// mixture of the beta distributions of central F variables with d1 +
+// 2j numerator degrees of freedom.
+func fSurvival(x, d1, d2, lambda float64) float64 {
+	u := d1 * x / (d1*x + d2)
+	mu := lambda / 2
+	var sum, weights float64
+	for j := 0.0; ; j++ {
+		lg, _ := math.Lgamma(j + 1)
+		w := math.Exp(-mu + j*math.Log(mu) - lg)
+		if mu == 0 {
+			w = 1
+		}
+		sum += w * dist.Beta{Alpha: d1/2 + j, Beta: d2 / 2}.Survival(u)
+		weights += w
+		if 1-weights < 1e-14 || j > mu+40*math.Sqrt(mu)+40 {
+			break
+		}
+	}
+	return min(1, sum)
+}
+
+// SampleSize returns the smallest sample size, at least from, whose
+// power, given by the function power of n, reaches target. It assumes
+// that the power increases with n.
+func SampleSize(power func(n int) float64, target float64, from int) (int, error) {
+	if !(target > 0 && target < 1) {
+		return 0, fmt.Errorf("target power %v is not between 0 and 1", target)
+	}
+	const limit = 1 << 24
+	lo, hi := from, from
+	for !(power(hi) >= target) {
+		if hi >= limit {
+			return 0, errors.New("the effect is too small to detect with any practical sample size")
+		}
+		lo, hi = hi+1, 2*hi
+	}
+	for lo < hi {
+		m := lo + (hi-lo)/2
+		if power(m) >= target {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return hi, nil
+}
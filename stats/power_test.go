@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPowerSmallDF checks the power of the t-tests with one and two
+// degrees of freedom, where the density of S = √(χ²/df) does not vanish
+// at 0 or is steepest there. The reference values were computed by
+// integrating the noncentral t to 1e-9 and agree with 2·10⁷ simulated
+// tests.
+func TestPowerSmallDF(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		power float64
+		want  float64
+	}{
+		{"one-sample, d = 1, n = 2", PowerOneSampleT(1, 2, 0.05), 0.0928092},
+		{"one-sample, d = 1, n = 3", PowerOneSampleT(1, 3, 0.05), 0.1792554},
+		{"two-sample, d = 1, n = 2", PowerTwoSampleT(1, 2, 0.05), 0.0952018},
+	} {
+		if math.Abs(c.power-c.want) > 1e-6 {
+			t.Errorf("%s: power %.7f, want %.7f", c.name, c.power, c.want)
+		}
+	}
+}
+
+// TestSampleSize checks the sample sizes for a power of 0.8 at α =
+// 0.05, and the power they reach, against G*Power 3, and that one
+// observation fewer falls short. The normal approximations are checked
+// against their closed forms rounded up, 2((z_(α/2) + z_β)/h)² and
+// ((z_(α/2) + z_β)/atanh r)² + 3, which Cohen (1988) tabulates rounded
+// to 392 and 85.
+func TestSampleSize(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		power func(n int) float64
+		from  int
+		n     int
+		at    float64 // the power at n, where G*Power gives it
+	}{
+		{"one-sample t, d = 0.5", func(n int) float64 { return PowerOneSampleT(0.5, n, 0.05) }, 2, 34, 0.8077775},
+		{"two-sample t, d = 0.2", func(n int) float64 { return PowerTwoSampleT(0.2, n, 0.05) }, 2, 394, 0.8005931},
+		{"two-sample t, d = 0.5", func(n int) float64 { return PowerTwoSampleT(0.5, n, 0.05) }, 2, 64, 0.8014596},
+		{"two-sample t, d = 0.8", func(n int) float64 { return PowerTwoSampleT(0.8, n, 0.05) }, 2, 26, 0.8074866},
+		{"ANOVA, 3 groups, f = 0.25", func(n int) float64 { return PowerANOVA(0.25, 3, n, 0.05) }, 2, 53, 0.8048873},
+		{"correlation, r = 0.3", func(n int) float64 { return PowerCorrelation(0.3, n, 0.05) }, 4, 85, 0},
+		{"two proportions, h = 0.2", func(n int) float64 { return PowerTwoProportions(0.2, n, 0.05) }, 1, 393, 0},
+	} {
+		n, err := SampleSize(c.power, 0.8, c.from)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if n != c.n {
+			t.Errorf("%s: n = %d, want %d", c.name, n, c.n)
+			continue
+		}
+		if p := c.power(n); c.at != 0 && math.Abs(p-c.at) > 1e-6 {
+			t.Errorf("%s: power %.7f at n = %d, want %.7f", c.name, p, n, c.at)
+		}
+		if p := c.power(n - 1); p >= 0.8 {
+			t.Errorf("%s: power %.4f at n = %d already reaches 0.8", c.name, p, n-1)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+// Fit is a least-squares fit of a linear model with an intercept.
+type Fit struct {
+	// Coef are the intercept followed by the coefficients of the
+	// predictors, in order.
+	Coef []float64
+	// ResidualSD is √(SSE / (n - p)), p counting the intercept.
+	ResidualSD float64
+	// RSquared is 1 - SSE / SST.
+	RSquared float64
+}
+
+// LeastSquares fits y = b₀ + b₁x₁ + … + bₖxₖ by least squares, x[j]
+// holding the values of predictor j. The predictors and y are centred
+// on their means, then solved by Householder QR, which avoids forming
+// the normal equations XᵀX and squaring their condition number.
+func LeastSquares(x [][]float64, y []float64) (Fit, error) {
+	n, k := len(y), len(x)
+	if n <= k+1 {
+		return Fit{}, errors.New("regression needs more observations than coefficients")
+	}
+	for _, col := range x {
+		if len(col) != n {
+			return Fit{}, errors.New("predictors and response of different lengths")
+		}
+	}
+
+	// a is the centred design, column by column; b the centred response.
+	means := make([]float64, k)
+	a := make([][]float64, k)
+	for j, col := range x {
+		means[j] = Mean(col)
+		a[j] = make([]float64, n)
+		for i, v := range col {
+			a[j][i] = v - means[j]
+		}
+	}
+	my := Mean(y)
+	b := make([]float64, n)
+	for i, v := range y {
+		b[i] = v - my
+	}
+
+	// Householder QR: reflect column j onto its diagonal, applying the
+	// same reflection to the later columns and to b.
+	diag := make([]float64, k)
+	for j := range k {
+		var norm float64
+		for i := j; i < n; i++ {
+			norm = math.Hypot(norm, a[j][i])
+		}
+		if norm == 0 {
+			return Fit{}, errors.New("predictors are linearly dependent")
+		}
+		if a[j][j] > 0 {
+			norm = -norm
+		}
+		// v = a[j][j:] - norm·e₁, stored in place.
+		a[j][j] -= norm
+		vv := 0.0
+		for i := j; i < n; i++ {
+			vv += a[j][i] * a[j][i]
+		}
+		reflect := func(c []float64) {
+			var dot float64
+			for i := j; i < n; i++ {
+				dot += a[j][i] * c[i]
+			}
+			f := 2 * dot / vv
+			for i := j; i < n; i++ {
+				c[i] -= f * a[j][i]
+			}
+		}
+		for l := j + 1; l < k; l++ {
+			reflect(a[l])
+		}
+		reflect(b)
+		diag[j] = norm
+	}
+	for j := range diag {
+		if math.Abs(diag[j]) <= 1e-13*math.Abs(diag[0]) {
+			return Fit{}, errors.New("predictors are linearly dependent")
+		}
+	}
+
+	// Back-substitute R c = Qᵀb; R has diag on its diagonal and a[l][j]
+	// above it.
+	c := make([]float64, k)
+	for j := k - 1; j >= 0; j-- {
+		s := b[j]
+		for l := j + 1; l < k; l++ {
+			s -= a[l][j] * c[l]
+		}
+		c[j] = s / diag[j]
+	}
+
+	fit := Fit{Coef: make([]float64, k+1)}
+	var intercept compensated
+	intercept.add(my)
+	for j := range c {
+		fit.Coef[j+1] = c[j]
+		intercept.add(-c[j] * means[j])
+	}
+	fit.Coef[0] = intercept.value()
+
+	// The residuals, from the centred data.
+	var sse compensated
+	for i := range y {
+		var r compensated
+		r.add(y[i] - my)
+		for j := range x {
+			r.add(-c[j] * (x[j][i] - means[j]))
+		}
+		e := r.value()
+		sse.add(e * e)
+	}
+	fit.ResidualSD = math.Sqrt(sse.value() / float64(n-k-1))
+	fit.RSquared = 1 - sse.value()/sumSquares(y, my)
+	return fit, nil
+}
@@ -0,0 +1,146 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Sketch is a KLL quantile sketch (Karnin, Lang and Liberty, 2016): it
+// keeps O(k) of the values of a stream, from which it estimates any
+// quantile with a rank error of about 1.7/k of the count, and sketches
+// of parts of a stream merge into one of the whole stream.
+//
+// The values are kept in levels, those of level h standing for 2^h
+// values each. When a level is full, it is sorted and every other value
+// is promoted to the next level. The values promoted alternate, level
+// by level, between the odd and even positions rather than being drawn
+// at random, so the results are reproducible.
+type Sketch struct {
+	k      int
+	n      int
+	levels [][]float64
+	odd    []bool // of each level, whether to promote the odd positions next
+}
+
+// DefaultSketchSize is the k of NewSketch(0), a rank error of about
+// 1%.
+const DefaultSketchSize = 200
+
+// NewSketch returns an empty sketch of accuracy k, DefaultSketchSize if
+// k is not positive.
+func NewSketch(k int) *Sketch {
+	if k <= 0 {
+		k = DefaultSketchSize
+	}
+	return &Sketch{k: k, levels: make([][]float64, 1), odd: make([]bool, 1)}
+}
+
+// K returns the accuracy parameter of s.
+func (s *Sketch) K() int {
+	return s.k
+}
+
+// N returns the number of values added to s.
+func (s *Sketch) N() int {
+	return s.n
+}
+
+// Add adds the value x.
+func (s *Sketch) Add(x float64) {
+	s.levels[0] = append(s.levels[0], x)
+	s.n++
+	s.compress()
+}
+
+// Merge adds the values added to t, which must have the same k.
+func (s *Sketch) Merge(t *Sketch) {
+	for h, level := range t.levels {
+		if h == len(s.levels) {
+			s.levels = append(s.levels, nil)
+			s.odd = append(s.odd, false)
+		}
+		s.levels[h] = append(s.levels[h], level...)
+	}
+	s.n += t.n
+	s.compress()
+}
+
+// capacity returns the number of values level h may hold: k at the top
+// level, shrinking by 2/3 per level below, and at least 2.
+func (s *Sketch) capacity(h int) int {
+	depth := len(s.levels) - 1 - h
+	return max(int(math.Ceil(float64(s.k)*math.Pow(2.0/3, float64(depth)))), 2)
+}
+
+// compress promotes half of each full level to the next, until no level
+// is full.
+func (s *Sketch) compress() {
+	for h := 0; h < len(s.levels); h++ {
+		if len(s.levels[h]) < s.capacity(h) {
+			continue
+		}
+		if h+1 == len(s.levels) {
+			s.levels = append(s.levels, nil)
+			s.odd = append(s.odd, false)
+		}
+		level := s.levels[h]
+		sort.Float64s(level)
+		start := 0
+		if s.odd[h] {
+			start = 1
+		}
+		s.odd[h] = !s.odd[h]
+		// An odd value out stays at this level.
+		var keep []float64
+		if len(level)%2 == 1 {
+			keep = []float64{level[len(level)-1]}
+			level = level[:len(level)-1]
+		}
+		for i := start; i < len(level); i += 2 {
+			s.levels[h+1] = append(s.levels[h+1], level[i])
+		}
+		s.levels[h] = keep
+	}
+}
+
+// Quantile returns an estimate of the p-quantile of the values added,
+// the smallest value whose estimated rank is at least p of the count,
+// or NaN when empty.
+func (s *Sketch) Quantile(p float64) float64 {
+	type item struct {
+		x float64
+		w int
+	}
+	var items []item
+	for h, level := range s.levels {
+		for _, x := range level {
+			items = append(items, item{x, 1 << h})
+		}
+	}
+	if len(items) == 0 {
+		return math.NaN()
+	}
+	slices.SortFunc(items, func(a, b item) int {
+		switch {
+		case a.x < b.x:
+			return -1
+		case a.x > b.x:
+			return 1
+		}
+		return 0
+	})
+	total := 0
+	for _, it := range items {
+		total += it.w
+	}
+	target := p * float64(total)
+	cum := 0
+	for _, it := range items {
+		cum += it.w
+		if float64(cum) >= target {
+			return it.x
+		}
+	}
+	return items[len(items)-1].x
+}
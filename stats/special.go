@@ -0,0 +1,15 @@
+package stats
+
+import "github.com/mayura-andrew/applied-statistics/dist"
+
+// StudentTSF returns P(T > t) for Student's t distribution with df
+// degrees of freedom.
+func StudentTSF(t, df float64) float64 {
+	return dist.StudentT{DF: df}.Survival(t)
+}
+
+// ChiSquareSF returns P(X > x) for the chi-square distribution with df
+// degrees of freedom.
+func ChiSquareSF(x, df float64) float64 {
+	return dist.ChiSquare{DF: df}.Survival(x)
+}
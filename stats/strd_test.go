@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// The tests in this file check the package against the certified values
+// of the NIST Statistical Reference Datasets (StRD): the univariate
+// summary datasets NumAcc1 to NumAcc4, whose large constant offsets
+// break naive sums of squares, and the Longley linear regression, whose
+// predictors are nearly collinear.
+
+// numAcc returns a NumAcc dataset of n values: first, then values
+// alternating between first - step and first + step, as in the NIST
+// files.
+func numAcc(first, step float64, n int) []float64 {
+	x := []float64{first}
+	for i := 1; i < n; i++ {
+		if i%2 == 1 {
+			x = append(x, first-step)
+		} else {
+			x = append(x, first+step)
+		}
+	}
+	return x
+}
+
+// lre returns the log relative error of x against the certified value
+// c, the number of significant digits that agree, capped at 15.
+func lre(x, c float64) float64 {
+	if math.IsNaN(x) {
+		return 0
+	}
+	var e float64
+	if c == 0 {
+		e = math.Abs(x)
+	} else {
+		e = math.Abs(x-c) / math.Abs(c)
+	}
+	if e == 0 {
+		return 15
+	}
+	return math.Max(0, math.Min(15, -math.Log10(e)))
+}
+
+// certify reports an error unless got agrees with the certified value
+// want to at least digits significant digits.
+func certify(t *testing.T, name string, got, want, digits float64) {
+	t.Helper()
+	if d := lre(got, want); d < digits {
+		t.Errorf("%s = %.15g, certified %.15g: %.1f digits agree, want %g", name, got, want, d, digits)
+	}
+}
+
+func TestNumAcc(t *testing.T) {
+	for _, d := range []struct {
+		name     string
+		x        []float64
+		mean, sd float64
+	}{
+		{"NumAcc1", []float64{10000001, 10000003, 10000002}, 10000002, 1},
+		{"NumAcc2", numAcc(1.2, 0.1, 1001), 1.2, 0.1},
+		{"NumAcc3", numAcc(1000000.2, 0.1, 1001), 1000000.2, 0.1},
+		{"NumAcc4", numAcc(10000000.2, 0.1, 1001), 10000000.2, 0.1},
+	} {
+		var a Accumulator
+		for _, v := range d.x {
+			a.Add(v)
+		}
+		certify(t, d.name+" mean", Mean(d.x), d.mean, 14)
+		certify(t, d.name+" std_dev", StdDev(d.x), d.sd, 8)
+		certify(t, d.name+" std_dev (one pass)", math.Sqrt(a.Variance()), d.sd, 8)
+	}
+}
+
+// The Longley data: total employment, then the GNP deflator, GNP,
+// unemployment, armed forces, population over 14 and year, 1947-1962.
+var longley = [][7]float64{
+	{60323, 83.0, 234289, 2356, 1590, 107608, 1947},
+	{61122, 88.5, 259426, 2325, 1456, 108632, 1948},
+	{60171, 88.2, 258054, 3682, 1616, 109773, 1949},
+	{61187, 89.5, 284599, 3351, 1650, 110929, 1950},
+	{63221, 96.2, 328975, 2099, 3099, 112075, 1951},
+	{63639, 98.1, 346999, 1932, 3594, 113270, 1952},
+	{64989, 99.0, 365385, 1870, 3547, 115094, 1953},
+	{63761, 100.0, 363112, 3578, 3350, 116219, 1954},
+	{66019, 101.2, 397469, 2904, 3048, 117388, 1955},
+	{67857, 104.6, 419180, 2822, 2857, 118734, 1956},
+	{68169, 108.4, 442769, 2936, 2798, 120445, 1957},
+	{66513, 110.8, 444546, 4681, 2637, 121950, 1958},
+	{68655, 112.6, 482704, 3813, 2552, 123366, 1959},
+	{69564, 114.2, 502601, 3931, 2514, 125368, 1960},
+	{69331, 115.7, 518173, 4806, 2572, 127852, 1961},
+	{70551, 116.9, 554894, 4007, 2827, 130081, 1962},
+}
+
+func TestLongley(t *testing.T) {
+	// The certified coefficients, intercept first.
+	coef := []float64{
+		-3482258.63459582, 15.0618722713733, -0.358191792925910e-01,
+		-2.02022980381683, -1.03322686717359, -0.511041056535807e-01,
+		1829.15146461355,
+	}
+	y := make([]float64, len(longley))
+	x := make([][]float64, 6)
+	for i, row := range longley {
+		y[i] = row[0]
+		for j := range x {
+			x[j] = append(x[j], row[j+1])
+		}
+	}
+	fit, err := LeastSquares(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, c := range coef {
+		certify(t, fmt.Sprintf("Longley b%d", j), fit.Coef[j], c, 12)
+	}
+	certify(t, "Longley residual SD", fit.ResidualSD, 304.854073561965, 12)
+	certify(t, "Longley R²", fit.RSquared, 0.995479004577296, 12)
+}
@@ -0,0 +1,81 @@
+package stats
+
+import "math"
+
+// Sum returns the sum of x by Neumaier's variant of Kahan's compensated
+// summation: the rounding error of each addition is carried in a second
+// sum, so the result is accurate to about one rounding whatever the
+// length of x, where a plain loop loses up to n of them.
+func Sum(x []float64) float64 {
+	var s compensated
+	for _, v := range x {
+		s.add(v)
+	}
+	return s.value()
+}
+
+// compensated is a running sum by Neumaier's algorithm.
+type compensated struct {
+	sum, c float64
+}
+
+func (s *compensated) add(v float64) {
+	t := s.sum + v
+	if math.Abs(s.sum) >= math.Abs(v) {
+		s.c += (s.sum - t) + v
+	} else {
+		s.c += (v - t) + s.sum
+	}
+	s.sum = t
+}
+
+func (s *compensated) value() float64 {
+	return s.sum + s.c
+}
+
+// sumSquares returns Σ(xᵢ - m)² by the corrected two-pass algorithm of
+// Chan, Golub and LeVeque: the compensated sum of squared deviations
+// less (Σ(xᵢ - m))² / n, which removes the error of m itself.
+func sumSquares(x []float64, m float64) float64 {
+	var ss, d compensated
+	for _, v := range x {
+		dv := v - m
+		ss.add(dv * dv)
+		d.add(dv)
+	}
+	e := d.value()
+	return ss.value() - e*e/float64(len(x))
+}
+
+// coSum returns Σ(xᵢ - mx)(yᵢ - my), corrected as sumSquares is.
+func coSum(x, y []float64, mx, my float64) float64 {
+	var s, dx, dy compensated
+	for i := range x {
+		a, b := x[i]-mx, y[i]-my
+		s.add(a * b)
+		dx.add(a)
+		dy.add(b)
+	}
+	return s.value() - dx.value()*dy.value()/float64(len(x))
+}
+
+// Covariance returns the sample covariance of the paired samples x and
+// y, Σ(xᵢ - x̄)(yᵢ - ȳ) / (n - 1), or NaN if they differ in length or
+// have fewer than 2 pairs.
+func Covariance(x, y []float64) float64 {
+	n := len(x)
+	if n != len(y) || n < 2 {
+		return math.NaN()
+	}
+	return coSum(x, y, Mean(x), Mean(y)) / float64(n-1)
+}
+
+// PopCovariance returns the population covariance of x and y,
+// Σ(xᵢ - x̄)(yᵢ - ȳ) / n.
+func PopCovariance(x, y []float64) float64 {
+	n := len(x)
+	if n != len(y) || n == 0 {
+		return math.NaN()
+	}
+	return coSum(x, y, Mean(x), Mean(y)) / float64(n)
+}
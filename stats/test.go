@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// TestResult is the outcome of a two-sided hypothesis test, with the
+// effect sizes that say how large the effect it tests for is.
+type TestResult struct {
+	Name      string
+	Statistic string
+	Value     float64
+	DF        []float64
+	PValue    float64
+	Effects   []EffectSize
+}
+
+// Reject reports whether the null hypothesis is rejected at level
+// alpha.
+func (r TestResult) Reject(alpha float64) bool {
+	return r.PValue < alpha
+}
+
+// OneSampleT tests whether the mean of x equals mu with Student's
+// one-sample t-test.
+func OneSampleT(x []float64, mu float64) (TestResult, error) {
+	n := len(x)
+	if n < 2 {
+		return TestResult{}, errors.New("t-test needs at least 2 observations")
+	}
+	se := StdDev(x) / math.Sqrt(float64(n))
+	if se == 0 {
+		return TestResult{}, errors.New("t-test undefined for a constant sample")
+	}
+	t := (Mean(x) - mu) / se
+	df := float64(n - 1)
+	return TestResult{
+		Name:      "one-sample t-test",
+		Statistic: "t",
+		Value:     t,
+		DF:        []float64{df},
+		PValue:    2 * StudentTSF(math.Abs(t), df),
+		Effects:   []EffectSize{CohensD(x, mu)},
+	}, nil
+}
+
+// WelchT tests whether the means of x and y are equal with Welch's
+// two-sample t-test, which does not assume equal variances. The
+// degrees of freedom are given by the Welch–Satterthwaite equation.
+// Glass's Δ takes y as the control group.
+func WelchT(x, y []float64) (TestResult, error) {
+	nx, ny := float64(len(x)), float64(len(y))
+	if nx < 2 || ny < 2 {
+		return TestResult{}, errors.New("t-test needs at least 2 observations per group")
+	}
+	vx, vy := Variance(x)/nx, Variance(y)/ny
+	if vx+vy == 0 {
+		return TestResult{}, errors.New("t-test undefined for constant samples")
+	}
+	t := (Mean(x) - Mean(y)) / math.Sqrt(vx+vy)
+	df := (vx + vy) * (vx + vy) / (vx*vx/(nx-1) + vy*vy/(ny-1))
+	return TestResult{
+		Name:      "Welch two-sample t-test",
+		Statistic: "t",
+		Value:     t,
+		DF:        []float64{df},
+		PValue:    2 * StudentTSF(math.Abs(t), df),
+		Effects: []EffectSize{
+			CohensDTwo(x, y), HedgesG(x, y), GlassDelta(x, y), RankBiserial(x, y),
+			EtaSquared([][]float64{x, y}), OmegaSquared([][]float64{x, y}),
+		},
+	}, nil
+}
+
+// ChiSquareIndependence tests whether the row and column variables of
+// the contingency table of counts are independent with Pearson's
+// chi-square test. It also returns the expected counts. For a 2 × 2
+// table the effect sizes include φ and the odds ratio besides
+// Cramér's V.
+func ChiSquareIndependence(table [][]float64) (TestResult, [][]float64, error) {
+	r := len(table)
+	if r < 2 || len(table[0]) < 2 {
+		return TestResult{}, nil, errors.New("chi-square test needs at least a 2 × 2 table")
+	}
+	c := len(table[0])
+	rows := make([]float64, r)
+	cols := make([]float64, c)
+	var total float64
+	for i, row := range table {
+		if len(row) != c {
+			return TestResult{}, nil, fmt.Errorf("row %d of the table has %d cells, want %d", i+1, len(row), c)
+		}
+		for j, v := range row {
+			rows[i] += v
+			cols[j] += v
+			total += v
+		}
+	}
+	expected := make([][]float64, r)
+	var chi2 float64
+	for i := range table {
+		expected[i] = make([]float64, c)
+		for j, o := range table[i] {
+			e := rows[i] * cols[j] / total
+			if e == 0 {
+				return TestResult{}, nil, errors.New("chi-square test undefined with an empty row or column")
+			}
+			expected[i][j] = e
+			chi2 += (o - e) * (o - e) / e
+		}
+	}
+	df := float64((r - 1) * (c - 1))
+	effects := []EffectSize{CramersV(table)}
+	if r == 2 && c == 2 {
+		effects = append(effects, Phi(table), OddsRatio(table))
+	}
+	return TestResult{
+		Name:      "Pearson's chi-square test of independence",
+		Statistic: "χ²",
+		Value:     chi2,
+		DF:        []float64{df},
+		PValue:    ChiSquareSF(chi2, df),
+		Effects:   effects,
+	}, expected, nil
+}
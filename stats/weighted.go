@@ -0,0 +1,182 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Weights says what the weights of a weighted statistic mean, which
+// matters for the variance.
+type Weights int
+
+// The kinds of weights.
+const (
+	// FrequencyWeights count how many times each value was observed,
+	// so that a weight of 3 is three identical observations. The sample
+	// size is the sum of the weights.
+	FrequencyWeights Weights = iota
+	// ReliabilityWeights are proportional to the precision of each
+	// value, such as inverse variances or survey design weights. Only
+	// their ratios matter.
+	ReliabilityWeights
+)
+
+var weightsNames = [...]string{FrequencyWeights: "frequency", ReliabilityWeights: "reliability"}
+
+func (k Weights) String() string {
+	return weightsNames[k]
+}
+
+// ParseWeights returns the kind of weights named name: frequency or
+// reliability.
+func ParseWeights(name string) (Weights, error) {
+	for k, n := range weightsNames {
+		if n == name {
+			return Weights(k), nil
+		}
+	}
+	return FrequencyWeights, fmt.Errorf("unknown kind of weights %q (want frequency or reliability)", name)
+}
+
+// CheckWeights returns an error unless w has one weight per value of x,
+// none negative and not all zero.
+func CheckWeights(x, w []float64) error {
+	if len(w) != len(x) {
+		return fmt.Errorf("%d weights for %d values", len(w), len(x))
+	}
+	var sum float64
+	for i, v := range w {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("weight %d is %v, want a non-negative number", i+1, v)
+		}
+		sum += v
+	}
+	if sum == 0 {
+		return fmt.Errorf("the weights are all zero")
+	}
+	return nil
+}
+
+// WeightedMean returns Σ wᵢxᵢ / Σ wᵢ, or NaN when x is empty.
+func WeightedMean(x, w []float64) float64 {
+	var sum, sw compensated
+	for i, v := range x {
+		sum.add(w[i] * v)
+		sw.add(w[i])
+	}
+	if sw.value() == 0 {
+		return math.NaN()
+	}
+	return sum.value() / sw.value()
+}
+
+// weightedSumSquares returns Σ wᵢ(xᵢ - m)², corrected as sumSquares is,
+// and Σ wᵢ.
+func weightedSumSquares(x, w []float64, m float64) (ss, sw float64) {
+	var s, d, v1 compensated
+	for i, v := range x {
+		dv := v - m
+		s.add(w[i] * dv * dv)
+		d.add(w[i] * dv)
+		v1.add(w[i])
+	}
+	e := d.value()
+	return s.value() - e*e/v1.value(), v1.value()
+}
+
+// WeightedVariance returns the variance of x with weights w of kind k:
+// Σ wᵢ(xᵢ - x̄w)² divided by V₁ - 1 for frequency weights and by
+// V₁ - V₂/V₁ for reliability weights, where V₁ = Σ wᵢ and V₂ = Σ wᵢ².
+// Both are unbiased, and reduce to the sample variance for unit
+// weights. It is NaN when the divisor is not positive.
+func WeightedVariance(x, w []float64, k Weights) float64 {
+	ss, v1 := weightedSumSquares(x, w, WeightedMean(x, w))
+	var v2 float64
+	for _, v := range w {
+		v2 += v * v
+	}
+	div := v1 - 1
+	if k == ReliabilityWeights {
+		div = v1 - v2/v1
+	}
+	if div <= 0 {
+		return math.NaN()
+	}
+	return ss / div
+}
+
+// WeightedPopVariance returns Σ wᵢ(xᵢ - x̄w)² / Σ wᵢ, the variance of
+// the population described by the weighted values.
+func WeightedPopVariance(x, w []float64) float64 {
+	ss, sw := weightedSumSquares(x, w, WeightedMean(x, w))
+	if sw == 0 {
+		return math.NaN()
+	}
+	return ss / sw
+}
+
+// WeightedQuantile returns the p-quantile of x with weights w. The
+// values are sorted and each is placed at the midpoint of its share of
+// the cumulative weight, rescaled so that the smallest sits at 0 and the
+// largest at 1: x₍ₖ₎ is at (Sₖ - wₖ/2 - w₁/2) / (W - w₁/2 - wₙ/2), where
+// Sₖ is the cumulative weight up to x₍ₖ₎ and W the total. The quantile
+// interpolates linearly between these positions, so only the ratios of
+// the weights matter, and equal weights give Quantile.
+func WeightedQuantile(x, w []float64, p float64) float64 {
+	idx := make([]int, 0, len(x))
+	var total float64
+	for i := range x {
+		if w[i] > 0 {
+			idx = append(idx, i)
+			total += w[i]
+		}
+	}
+	if len(idx) == 0 {
+		return math.NaN()
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return x[idx[a]] < x[idx[b]] })
+	first, last := w[idx[0]], w[idx[len(idx)-1]]
+	span := total - first/2 - last/2
+	if len(idx) == 1 || p <= 0 {
+		return x[idx[0]]
+	}
+	if p >= 1 {
+		return x[idx[len(idx)-1]]
+	}
+
+	var cum, prev float64
+	for k, i := range idx {
+		cum += w[i]
+		at := (cum - w[i]/2 - first/2) / span
+		if k > 0 && p <= at {
+			j := idx[k-1]
+			return x[j] + (p-prev)/(at-prev)*(x[i]-x[j])
+		}
+		prev = at
+	}
+	return x[idx[len(idx)-1]]
+}
+
+// WeightedMedian returns the weighted 0.5-quantile of x.
+func WeightedMedian(x, w []float64) float64 {
+	return WeightedQuantile(x, w, 0.5)
+}
+
+// WeightedFrequencyTable is FrequencyTable with the classes holding the
+// sum of the weights of their values in Weight.
+func WeightedFrequencyTable(x, w []float64, k int) []Class {
+	classes := FrequencyTable(x, k)
+	if classes == nil {
+		return nil
+	}
+	for i := range classes {
+		classes[i].Weight = 0
+	}
+	lo, width := classes[0].Lower, classes[0].Upper-classes[0].Lower
+	for i, v := range x {
+		j := min(int((v-lo)/width+1e-9), k-1)
+		classes[j].Weight += w[i]
+	}
+	return classes
+}
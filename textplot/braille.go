@@ -0,0 +1,109 @@
+package textplot
+
+import "strings"
+
+// braille is a canvas of braille characters, each cell holding a 2 × 4
+// grid of dots, giving a terminal plot twice the horizontal and four
+// times the vertical resolution of the character grid.
+type braille struct {
+	w, h   int
+	dots   [][]rune
+	series [][]int
+	marks  [][]rune
+}
+
+// brailleBits maps the dot at column x (0–1) and row y (0–3) of a cell
+// to its bit in the Unicode braille pattern block.
+var brailleBits = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+func newBraille(w, h int) *braille {
+	b := &braille{w: w, h: h}
+	b.dots = make([][]rune, h)
+	b.series = make([][]int, h)
+	b.marks = make([][]rune, h)
+	for r := range h {
+		b.dots[r] = make([]rune, w)
+		b.series[r] = make([]int, w)
+		b.marks[r] = make([]rune, w)
+	}
+	return b
+}
+
+// set turns on the dot at (x, y), counted from the top left, for
+// series i. The last series drawn in a cell gives the cell its color.
+func (b *braille) set(x, y, i int) {
+	if x < 0 || y < 0 || x >= 2*b.w || y >= 4*b.h {
+		return
+	}
+	b.dots[y/4][x/2] |= brailleBits[y%4][x%2]
+	b.series[y/4][x/2] = i
+}
+
+// mark replaces the cell containing dot (x, y) with the marker r.
+func (b *braille) mark(x, y, i int, r rune) {
+	if x < 0 || y < 0 || x >= 2*b.w || y >= 4*b.h {
+		return
+	}
+	b.marks[y/4][x/2] = r
+	b.series[y/4][x/2] = i
+}
+
+// line draws the line from (x0, y0) to (x1, y1) with Bresenham's
+// algorithm.
+func (b *braille) line(x0, y0, x1, y1, i int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		b.set(x0, y0, i)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// rows renders the canvas, passing each non-empty cell through paint
+// with the index of its series.
+func (b *braille) rows(paint func(int, string) string) []string {
+	out := make([]string, b.h)
+	for r := range b.h {
+		var sb strings.Builder
+		for c := range b.w {
+			switch {
+			case b.marks[r][c] != 0:
+				sb.WriteString(paint(b.series[r][c], string(b.marks[r][c])))
+			case b.dots[r][c] != 0:
+				sb.WriteString(paint(b.series[r][c], string(0x2800+b.dots[r][c])))
+			default:
+				sb.WriteByte(' ')
+			}
+		}
+		out[r] = sb.String()
+	}
+	return out
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
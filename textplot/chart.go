@@ -0,0 +1,394 @@
+package textplot
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// TermWidth returns the width of the terminal in columns: the size of
+// the terminal attached to standard output, else $COLUMNS, else 80.
+func TermWidth() int {
+	if w := ttyWidth(); w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+// Chart holds the layout shared by the terminal charts. The chart
+// methods take the same bins, counts and box plot summaries that the
+// gonum/plot commands compute, so the terminal and image versions of a
+// chart always show the same numbers.
+type Chart struct {
+	Title          string
+	XLabel, YLabel string
+
+	// Width and Height are the size of the chart in character cells.
+	// A zero Width uses the terminal width and a zero Height a quarter
+	// of the width.
+	Width, Height int
+
+	// Color distinguishes series with ANSI colors rather than with
+	// different glyphs.
+	Color bool
+}
+
+// Series is a named set of bar heights, one per category.
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// XYSeries is a named set of points for a scatter chart. Line joins
+// the points in order instead of drawing them individually.
+type XYSeries struct {
+	Name string
+	XYs  plotter.XYs
+	Line bool
+}
+
+// ansi are the foreground color codes given to successive series.
+var ansi = []string{"32", "31", "34", "33", "35", "36"}
+
+// shades are the bar fills given to successive series without color.
+var shades = []string{"█", "▓", "▒", "░"}
+
+// eighths are the partial blocks used for the fractional end of a bar.
+var eighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+func (c Chart) width() int {
+	if c.Width > 0 {
+		return c.Width
+	}
+	return TermWidth()
+}
+
+func (c Chart) height() int {
+	if c.Height > 0 {
+		return c.Height
+	}
+	return max(c.width()/4, 8)
+}
+
+// paint wraps s in the ANSI color of series i when colors are enabled.
+func (c Chart) paint(i int, s string) string {
+	if !c.Color || s == "" {
+		return s
+	}
+	return "\x1b[" + ansi[i%len(ansi)] + "m" + s + "\x1b[0m"
+}
+
+// header writes the title, if any.
+func (c Chart) header(b *strings.Builder) {
+	if c.Title != "" {
+		b.WriteString(c.Title)
+		b.WriteByte('\n')
+	}
+}
+
+// bar returns a horizontal bar of length v/scale cells, using partial
+// blocks for the last cell when the fill is solid.
+func bar(v, scale float64, fill string) string {
+	cells := v / scale
+	if cells <= 0 || math.IsNaN(cells) {
+		return ""
+	}
+	whole := int(cells)
+	s := strings.Repeat(fill, whole)
+	if fill == "█" {
+		s += eighths[int((cells-float64(whole))*8)]
+	} else if cells-float64(whole) >= 0.5 {
+		s += fill
+	}
+	return s
+}
+
+// Histogram renders the bins of a gonum histogram as horizontal bars,
+// one row per bin, labelled with the bin limits and weight.
+func (c Chart) Histogram(bins []plotter.HistogramBin) string {
+	labels := make([]string, len(bins))
+	values := make([]float64, len(bins))
+	prec := 0
+	for _, bin := range bins {
+		prec = max(prec, decimals(bin.Max-bin.Min))
+	}
+	for i, bin := range bins {
+		labels[i] = fmt.Sprintf("%.*f – %.*f", prec, bin.Min, prec, bin.Max)
+		values[i] = bin.Weight
+	}
+	return c.Bars(labels, Series{Values: values})
+}
+
+// Bars renders a horizontal bar chart with one row per category and
+// series. Several series are drawn as grouped bars with a legend.
+func (c Chart) Bars(labels []string, series ...Series) string {
+	var b strings.Builder
+	c.header(&b)
+
+	lw := utf8.RuneCountInString(c.XLabel)
+	var top float64
+	vw := 0
+	for i, l := range labels {
+		lw = max(lw, utf8.RuneCountInString(l))
+		for _, s := range series {
+			if i < len(s.Values) {
+				top = math.Max(top, s.Values[i])
+				vw = max(vw, len(formatNumber(s.Values[i])))
+			}
+		}
+	}
+	room := max(c.width()-lw-vw-4, 10)
+	scale := top / float64(room)
+	if scale == 0 {
+		scale = 1
+	}
+
+	if c.XLabel != "" || c.YLabel != "" {
+		fmt.Fprintf(&b, "%-*s │ %s\n", lw, c.XLabel, c.YLabel)
+		fmt.Fprintf(&b, "%s┼%s\n", strings.Repeat("─", lw+1), strings.Repeat("─", room+vw+2))
+	}
+	for i, l := range labels {
+		for j, s := range series {
+			if i >= len(s.Values) {
+				continue
+			}
+			name := ""
+			if j == 0 {
+				name = l
+			}
+			fill := "█"
+			if !c.Color {
+				fill = shades[j%len(shades)]
+			}
+			pad := lw - utf8.RuneCountInString(name)
+			fmt.Fprintf(&b, "%s%s │%s %s\n", name, strings.Repeat(" ", pad),
+				c.paint(j, bar(s.Values[i], scale, fill)), formatNumber(s.Values[i]))
+		}
+	}
+	if len(series) > 1 {
+		b.WriteString(c.legend(series, func(j int) string {
+			if c.Color {
+				return "█"
+			}
+			return shades[j%len(shades)]
+		}))
+	}
+	return b.String()
+}
+
+// legend lists the series names next to their marker.
+func (c Chart) legend(series []Series, marker func(int) string) string {
+	parts := make([]string, len(series))
+	for j, s := range series {
+		parts[j] = c.paint(j, marker(j)) + " " + s.Name
+	}
+	return "Legend: " + strings.Join(parts, "   ") + "\n"
+}
+
+// BoxPlots renders gonum box plots as horizontal text box plots on a
+// shared axis: whiskers to the adjacent values, the box from Q1 to Q3
+// split at the median, ◆ at the mean and ○ for outside points.
+func (c Chart) BoxPlots(labels []string, means []float64, boxes ...*plotter.BoxPlot) string {
+	var b strings.Builder
+	c.header(&b)
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	lw := 0
+	for i, box := range boxes {
+		lo = math.Min(lo, box.Min)
+		hi = math.Max(hi, box.Max)
+		if i < len(labels) {
+			lw = max(lw, utf8.RuneCountInString(labels[i]))
+		}
+	}
+	if hi == lo {
+		lo, hi = lo-1, hi+1
+	}
+	w := max(c.width()-lw-3, 20)
+	col := func(v float64) int {
+		return int(math.Round((v - lo) / (hi - lo) * float64(w-1)))
+	}
+
+	for i, box := range boxes {
+		rows := [3][]rune{}
+		for r := range rows {
+			rows[r] = []rune(strings.Repeat(" ", w))
+		}
+		q1, med, q3 := col(box.Quartile1), col(box.Median), col(box.Quartile3)
+		aLo, aHi := col(box.AdjLow), col(box.AdjHigh)
+		for x := aLo; x <= aHi; x++ {
+			rows[1][x] = '─'
+		}
+		rows[1][aLo], rows[1][aHi] = '├', '┤'
+		for x := q1; x <= q3; x++ {
+			rows[0][x], rows[1][x], rows[2][x] = '─', ' ', '─'
+		}
+		rows[0][q1], rows[1][q1], rows[2][q1] = '┌', '┤', '└'
+		rows[0][q3], rows[1][q3], rows[2][q3] = '┐', '├', '┘'
+		if aLo == q1 {
+			rows[1][q1] = '│'
+		}
+		if aHi == q3 {
+			rows[1][q3] = '│'
+		}
+		rows[0][med], rows[1][med], rows[2][med] = '┬', '│', '┴'
+		for _, out := range box.Outside {
+			rows[1][col(box.Value(out))] = '○'
+		}
+		if i < len(means) {
+			rows[1][col(means[i])] = '◆'
+		}
+
+		label := ""
+		if i < len(labels) {
+			label = labels[i]
+		}
+		for r, row := range rows {
+			name := ""
+			if r == 1 {
+				name = label
+			}
+			pad := lw - utf8.RuneCountInString(name)
+			line := name + strings.Repeat(" ", pad) + "  " + c.paint(i, string(row))
+			b.WriteString(strings.TrimRight(line, " "))
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString(axis(strings.Repeat(" ", lw+2), lo, hi, w))
+	if c.XLabel != "" {
+		fmt.Fprintf(&b, "%s%s\n", strings.Repeat(" ", lw+2+max(w-utf8.RuneCountInString(c.XLabel), 0)/2), c.XLabel)
+	}
+	if len(means) > 0 {
+		b.WriteString("◆ mean   ○ outside the 1.5 × IQR fences\n")
+	}
+	return b.String()
+}
+
+// Scatter renders the series with the axis ranges marked and a legend.
+// Points are drawn as one marker per character cell, distinct for each
+// series unless colors tell them apart; lines are drawn on a braille
+// canvas, each cell holding 2 × 4 dots.
+func (c Chart) Scatter(series ...XYSeries) string {
+	var b strings.Builder
+	c.header(&b)
+
+	xlo, xhi := math.Inf(1), math.Inf(-1)
+	ylo, yhi := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, p := range s.XYs {
+			xlo, xhi = math.Min(xlo, p.X), math.Max(xhi, p.X)
+			ylo, yhi = math.Min(ylo, p.Y), math.Max(yhi, p.Y)
+		}
+	}
+	if xhi == xlo {
+		xlo, xhi = xlo-1, xhi+1
+	}
+	if yhi == ylo {
+		ylo, yhi = ylo-1, yhi+1
+	}
+
+	ytop, ybot := formatNumber(yhi), formatNumber(ylo)
+	yw := max(len(ytop), len(ybot))
+	w := max(c.width()-yw-2, 20)
+	h := c.height()
+	canvas := newBraille(w, h)
+	markers := []rune{'●', '○', '×', '+', '◇'}
+	marker := func(j int) rune {
+		if c.Color {
+			return markers[0]
+		}
+		return markers[j%len(markers)]
+	}
+
+	for j, s := range series {
+		dot := func(p plotter.XY) (int, int) {
+			x := int(math.Round((p.X - xlo) / (xhi - xlo) * float64(2*w-1)))
+			y := int(math.Round((yhi - p.Y) / (yhi - ylo) * float64(4*h-1)))
+			return x, y
+		}
+		if s.Line {
+			for i := 1; i < len(s.XYs); i++ {
+				x0, y0 := dot(s.XYs[i-1])
+				x1, y1 := dot(s.XYs[i])
+				canvas.line(x0, y0, x1, y1, j)
+			}
+			continue
+		}
+		for _, p := range s.XYs {
+			x, y := dot(p)
+			canvas.mark(x, y, j, marker(j))
+		}
+	}
+
+	for r, row := range canvas.rows(c.paint) {
+		label := ""
+		switch r {
+		case 0:
+			label = ytop
+		case h - 1:
+			label = ybot
+		}
+		b.WriteString(strings.TrimRight(fmt.Sprintf("%*s ┤%s", yw, label, row), " "))
+		b.WriteByte('\n')
+	}
+	b.WriteString(axis(strings.Repeat(" ", yw+1), xlo, xhi, w+1))
+	if c.XLabel != "" || c.YLabel != "" {
+		fmt.Fprintf(&b, "%sx: %s   y: %s\n", strings.Repeat(" ", yw+2), c.XLabel, c.YLabel)
+	}
+
+	names := make([]Series, len(series))
+	for j, s := range series {
+		names[j].Name = s.Name
+	}
+	if len(series) > 1 || series[0].Name != "" {
+		b.WriteString(c.legend(names, func(j int) string {
+			if series[j].Line {
+				return "──"
+			}
+			return string(marker(j))
+		}))
+	}
+	return b.String()
+}
+
+// axis returns a horizontal axis of width w cells from lo to hi with
+// labels at both ends and in the middle, indented by indent.
+func axis(indent string, lo, hi float64, w int) string {
+	line := []rune(strings.Repeat("─", w))
+	line[0], line[w/2], line[w-1] = '┬', '┬', '┬'
+	labels := []rune(strings.Repeat(" ", w+12))
+	put := func(at int, s string) {
+		at = max(at, 0)
+		copy(labels[at:], []rune(s))
+	}
+	l, m, r := formatNumber(lo), formatNumber((lo+hi)/2), formatNumber(hi)
+	put(0, l)
+	put(w/2-len(m)/2, m)
+	put(w-len(r), r)
+	return indent + string(line) + "\n" + strings.TrimRight(indent+string(labels), " ") + "\n"
+}
+
+// formatNumber formats v compactly with 4 significant digits, without
+// exponents for values a chart is likely to show.
+func formatNumber(v float64) string {
+	if math.Abs(v) >= 1e4 {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// decimals returns how many decimals are needed to show a step of w.
+func decimals(w float64) int {
+	if w <= 0 || w >= 10 {
+		return 0
+	}
+	return min(max(int(math.Ceil(-math.Log10(w)))+1, 0), 6)
+}
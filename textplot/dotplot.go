@@ -0,0 +1,151 @@
+package textplot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DotPlot is a dot plot: one dot per observation, stacked above its
+// value on a number line.
+type DotPlot struct {
+	// Unit is the resolution of the number line; values are rounded to
+	// the nearest multiple of Unit.
+	Unit float64
+
+	// Min is the value of the first column.
+	Min float64
+
+	// Counts holds the number of observations in each column.
+	Counts []int
+}
+
+// maxDotColumns is the widest number line produced when the unit is
+// chosen automatically.
+const maxDotColumns = 72
+
+// NewDotPlot returns the dot plot of x. A zero unit uses 1 for integer
+// data and otherwise the smallest power of ten that resolves the data,
+// coarsened until the number line fits on a terminal line.
+func NewDotPlot(x []float64, unit float64) (*DotPlot, error) {
+	if len(x) == 0 {
+		return nil, errors.New("textplot: dot plot needs at least one value")
+	}
+	if unit < 0 {
+		return nil, errors.New("textplot: negative dot plot unit")
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range x {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if unit == 0 {
+		unit = resolution(x)
+		for (hi-lo)/unit >= maxDotColumns {
+			unit *= 10
+		}
+	}
+
+	first := math.Round(lo / unit)
+	d := &DotPlot{
+		Unit:   unit,
+		Min:    first * unit,
+		Counts: make([]int, int(math.Round(hi/unit)-first)+1),
+	}
+	for _, v := range x {
+		d.Counts[int(math.Round(v/unit)-first)]++
+	}
+	return d, nil
+}
+
+// resolution returns the largest power of ten (at most 1) of which
+// every value of x is a multiple, to 6 decimal places.
+func resolution(x []float64) float64 {
+	unit := 1.0
+	for dec := 0; dec < 6; dec++ {
+		exact := true
+		for _, v := range x {
+			if math.Abs(v/unit-math.Round(v/unit)) > 1e-9 {
+				exact = false
+				break
+			}
+		}
+		if exact {
+			return unit
+		}
+		unit /= 10
+	}
+	return unit
+}
+
+// Value returns the value of column i.
+func (d *DotPlot) Value(i int) float64 {
+	return d.Min + float64(i)*d.Unit
+}
+
+// String renders the dot plot with a "●" per observation.
+func (d *DotPlot) String() string {
+	return d.Render("●")
+}
+
+// Render renders the dot plot using dot for each observation, followed
+// by a number line with a labelled tick every few columns.
+func (d *DotPlot) Render(dot string) string {
+	top := 0
+	for _, c := range d.Counts {
+		top = max(top, c)
+	}
+
+	// Label every k-th column, far enough apart for the widest label,
+	// and indent everything so the first label fits.
+	width := 0
+	for i := range d.Counts {
+		width = max(width, len(formatValue(d.Value(i), d.Unit)))
+	}
+	k := 1
+	for 2*k < width+1 {
+		k++
+	}
+	for _, step := range []int{1, 2, 5, 10, 20, 25, 50, 100} {
+		if step >= k {
+			k = step
+			break
+		}
+	}
+	margin := strings.Repeat(" ", width/2)
+
+	// Two characters per column keep adjacent stacks apart.
+	var b strings.Builder
+	for level := top; level >= 1; level-- {
+		line := make([]string, len(d.Counts))
+		for i, c := range d.Counts {
+			if c >= level {
+				line[i] = dot + " "
+			} else {
+				line[i] = "  "
+			}
+		}
+		b.WriteString(strings.TrimRight(margin+strings.Join(line, ""), " "))
+		b.WriteByte('\n')
+	}
+
+	axis := []rune(strings.Repeat("─", 2*len(d.Counts)-1))
+	labels := []rune(strings.Repeat(" ", len(margin)+2*len(d.Counts)+width))
+	for i := range d.Counts {
+		if math.Mod(math.Round(d.Value(i)/d.Unit), float64(k)) != 0 {
+			continue
+		}
+		axis[2*i] = '┼'
+		label := formatValue(d.Value(i), d.Unit)
+		copy(labels[len(margin)+2*i-len(label)/2:], []rune(label))
+	}
+	b.WriteString(margin + string(axis))
+	b.WriteByte('\n')
+	b.WriteString(strings.TrimRight(string(labels), " "))
+	b.WriteByte('\n')
+	if d.Unit != 1 {
+		fmt.Fprintf(&b, "(each column is %s wide)\n", formatValue(d.Unit, d.Unit))
+	}
+	return b.String()
+}
@@ -0,0 +1,282 @@
+// Package textplot renders statistical displays as plain text for the
+// terminal: stem-and-leaf displays, dot plots, and Unicode histograms,
+// bar charts, box plots and scatter plots sized to the terminal.
+package textplot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// StemLeaf is a stem-and-leaf display of a sample.
+type StemLeaf struct {
+	// LeafUnit is the value of one unit in the leaf digit, e.g. 1 for
+	// whole numbers shown as 3 | 4 = 34, or 0.1 for 3 | 4 = 3.4.
+	LeafUnit float64
+
+	// Split is the number of lines per stem: 1, 2 (leaves 0–4 and
+	// 5–9) or 5 (leaves 0–1, 2–3, 4–5, 6–7, 8–9).
+	Split int
+
+	// Lines are the rows of the display in increasing order, including
+	// empty rows between the smallest and largest values.
+	Lines []StemLine
+}
+
+// StemLine is one row of a stem-and-leaf display.
+type StemLine struct {
+	// Stem is the stem value; Negative distinguishes the stem -0
+	// from 0.
+	Stem     int
+	Negative bool
+
+	// Part is the index of the line within a split stem.
+	Part int
+
+	// Leaves are the leaf digits in display order.
+	Leaves []int
+}
+
+// splitMarks are Tukey's markers for the lines of a split stem.
+var splitMarks = map[int][]string{
+	1: {""},
+	2: {"*", "."},
+	5: {"*", "t", "f", "s", "."},
+}
+
+// NewStemLeaf returns the stem-and-leaf display of x. A zero leafUnit
+// selects a power of ten giving at most 10 stems, and a zero split
+// splits the stems when there would otherwise be fewer than 5 lines.
+func NewStemLeaf(x []float64, leafUnit float64, split int) (*StemLeaf, error) {
+	if len(x) == 0 {
+		return nil, errors.New("textplot: stem-and-leaf needs at least one value")
+	}
+	if split != 0 && splitMarks[split] == nil {
+		return nil, fmt.Errorf("textplot: split must be 1, 2 or 5, not %d", split)
+	}
+	if leafUnit < 0 {
+		return nil, errors.New("textplot: negative leaf unit")
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range x {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if leafUnit == 0 {
+		leafUnit = autoLeafUnit(hi - lo)
+	}
+	if split == 0 {
+		split = autoSplit((hi - lo) / (10 * leafUnit))
+	}
+
+	type key struct {
+		neg        bool
+		stem, part int
+	}
+	keyOf := func(scaled int) (key, int) {
+		neg := scaled < 0
+		mag := scaled
+		if neg {
+			mag = -mag
+		}
+		leaf := mag % 10
+		return key{neg, mag / 10, leaf * split / 10}, leaf
+	}
+	// order places keys along the number line.
+	order := func(k key) int {
+		pos := k.stem*split + k.part
+		if k.neg {
+			return -pos - 1
+		}
+		return pos
+	}
+
+	leaves := make(map[key][]int)
+	first, last := math.MaxInt, math.MinInt
+	for _, v := range x {
+		k, leaf := keyOf(int(math.Round(v / leafUnit)))
+		leaves[k] = append(leaves[k], leaf)
+		first = min(first, order(k))
+		last = max(last, order(k))
+	}
+
+	s := &StemLeaf{LeafUnit: leafUnit, Split: split}
+	for pos := first; pos <= last; pos++ {
+		var k key
+		if pos < 0 {
+			p := -pos - 1
+			k = key{true, p / split, p % split}
+		} else {
+			k = key{false, pos / split, pos % split}
+		}
+		ls := leaves[k]
+		// Leaves grow away from the stem: outwards from zero.
+		sort.Ints(ls)
+		if k.neg {
+			sort.Sort(sort.Reverse(sort.IntSlice(ls)))
+		}
+		s.Lines = append(s.Lines, StemLine{Stem: k.stem, Negative: k.neg, Part: k.part, Leaves: ls})
+	}
+	return s, nil
+}
+
+// autoLeafUnit picks a power-of-ten leaf unit so that the data span
+// between 1 and 10 stems.
+func autoLeafUnit(span float64) float64 {
+	if span <= 0 {
+		return 1
+	}
+	return math.Pow(10, math.Floor(math.Log10(span))-1)
+}
+
+// autoSplit splits stems when there would otherwise be too few lines.
+func autoSplit(stems float64) int {
+	switch {
+	case stems < 2:
+		return 5
+	case stems < 5:
+		return 2
+	}
+	return 1
+}
+
+// label returns the stem label of line l, e.g. "3", "-0" or "3*".
+func (s *StemLeaf) label(l StemLine) string {
+	stem := fmt.Sprint(l.Stem)
+	if l.Negative {
+		stem = "-" + stem
+	}
+	return stem + splitMarks[s.Split][l.Part]
+}
+
+// Key returns the legend explaining how to read the display.
+func (s *StemLeaf) Key() string {
+	var example StemLine
+	for _, l := range s.Lines {
+		if len(l.Leaves) > 0 && !l.Negative {
+			example = l
+			break
+		}
+	}
+	leaf := 0
+	if len(example.Leaves) > 0 {
+		leaf = example.Leaves[0]
+	}
+	v := float64(example.Stem*10+leaf) * s.LeafUnit
+	return fmt.Sprintf("Key: %s | %d = %s   (leaf unit = %s)",
+		s.label(example), leaf, formatValue(v, s.LeafUnit), formatValue(s.LeafUnit, s.LeafUnit))
+}
+
+// String renders the display with a depth column (cumulative counts
+// from the nearer end, the median line showing its own count in
+// parentheses) and a key.
+func (s *StemLeaf) String() string {
+	var total int
+	for _, l := range s.Lines {
+		total += len(l.Leaves)
+	}
+	depths := make([]string, len(s.Lines))
+	below := 0
+	for i, l := range s.Lines {
+		n := len(l.Leaves)
+		switch {
+		case below+n <= total/2:
+			depths[i] = fmt.Sprint(below + n)
+		case below >= (total+1)/2:
+			depths[i] = fmt.Sprint(total - below)
+		default:
+			depths[i] = fmt.Sprintf("(%d)", n)
+		}
+		below += n
+	}
+
+	dw, sw := 0, 0
+	for i, l := range s.Lines {
+		dw = max(dw, len(depths[i]))
+		sw = max(sw, len(s.label(l)))
+	}
+	var b strings.Builder
+	for i, l := range s.Lines {
+		line := fmt.Sprintf("%*s  %*s | %s", dw, depths[i], sw, s.label(l), leafString(l.Leaves))
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	b.WriteString(s.Key())
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// BackToBack renders the stem-and-leaf displays of a (leaves to the
+// left) and b (leaves to the right) around shared stems, for comparing
+// two groups. Zero leafUnit or split are chosen from the pooled data.
+func BackToBack(a, b []float64, nameA, nameB string, leafUnit float64, split int) (string, error) {
+	pooled, err := NewStemLeaf(append(append([]float64{}, a...), b...), leafUnit, split)
+	if err != nil {
+		return "", err
+	}
+	left, err := NewStemLeaf(a, pooled.LeafUnit, pooled.Split)
+	if err != nil {
+		return "", err
+	}
+	right, err := NewStemLeaf(b, pooled.LeafUnit, pooled.Split)
+	if err != nil {
+		return "", err
+	}
+
+	type lineKey struct {
+		neg        bool
+		stem, part int
+	}
+	index := func(s *StemLeaf) map[lineKey][]int {
+		m := make(map[lineKey][]int)
+		for _, l := range s.Lines {
+			m[lineKey{l.Negative, l.Stem, l.Part}] = l.Leaves
+		}
+		return m
+	}
+	ls, rs := index(left), index(right)
+
+	lw, sw := len(nameA), 0
+	for _, l := range pooled.Lines {
+		lw = max(lw, len(leafString(ls[lineKey{l.Negative, l.Stem, l.Part}])))
+		sw = max(sw, len(pooled.label(l)))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%*s   %*s   %s\n", lw, nameA, sw, "", nameB)
+	for _, l := range pooled.Lines {
+		k := lineKey{l.Negative, l.Stem, l.Part}
+		leftLeaves := append([]int{}, ls[k]...)
+		// Leaves on the left grow leftwards from the stem.
+		for i, j := 0, len(leftLeaves)-1; i < j; i, j = i+1, j-1 {
+			leftLeaves[i], leftLeaves[j] = leftLeaves[j], leftLeaves[i]
+		}
+		line := fmt.Sprintf("%*s | %*s | %s", lw, leafString(leftLeaves), sw, pooled.label(l), leafString(rs[k]))
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(pooled.Key())
+	sb.WriteByte('\n')
+	return sb.String(), nil
+}
+
+func leafString(leaves []int) string {
+	var b strings.Builder
+	for _, l := range leaves {
+		b.WriteByte(byte('0' + l))
+	}
+	return b.String()
+}
+
+// formatValue formats v with as many decimals as the unit needs.
+func formatValue(v, unit float64) string {
+	dec := 0
+	if unit < 1 {
+		dec = int(math.Ceil(-math.Log10(unit) - 1e-9))
+	}
+	return fmt.Sprintf("%.*f", dec, v)
+}
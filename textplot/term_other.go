@@ -0,0 +1,7 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package textplot
+
+// ttyWidth reports that the terminal size is unknown on this platform;
+// TermWidth then falls back to $COLUMNS or 80 columns.
+func ttyWidth() int { return 0 }